@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/lighting"
 	"github.com/Team254/cheesy-arena/model"
 	"github.com/Team254/cheesy-arena/network"
 	"github.com/Team254/cheesy-arena/partner"
@@ -34,6 +35,11 @@ const (
 	MaxMatchGapMin           = 20
 )
 
+// Audience display modes that can be selected from the match play page or by external controllers.
+var AudienceDisplayModes = []string{
+	"blank", "intro", "match", "score", "bracket", "logo", "logoLuma", "sponsor", "allianceSelection", "timeout",
+}
+
 // Progression of match states.
 type MatchState int
 
@@ -50,14 +56,24 @@ const (
 )
 
 type Arena struct {
-	Database         *model.Database
-	EventSettings    *model.EventSettings
-	accessPoint      network.AccessPoint
-	networkSwitch    *network.Switch
-	Plc              plc.Plc
-	BlackmagicClient *partner.BlackmagicClient
-	AllianceStations map[string]*AllianceStation
-	Displays         map[string]*Display
+	Database           *model.Database
+	EventSettings      *model.EventSettings
+	accessPoint        network.AccessPoint
+	networkSwitch      network.Switch
+	networkDiagnostics *network.NetworkDiagnostics
+	RadioKiosk         network.RadioKiosk
+	Plc                plc.Plc
+	BlackmagicClient   *partner.BlackmagicClient
+	TbaClient          *partner.TbaClient
+	NexusClient        *partner.NexusClient
+	ObsClient          *partner.ObsClient
+	Lighting           *lighting.DmxLights
+	OscClient          *partner.OscClient
+	WebhookClient      *partner.WebhookClient
+	MqttClient         *partner.MqttClient
+	TeamSigns          *TeamSigns
+	AllianceStations   map[string]*AllianceStation
+	Displays           map[string]*Display
 	ScoringPanelRegistry
 	ArenaNotifiers
 	MatchState
@@ -89,24 +105,39 @@ type Arena struct {
 	soundsPlayed                      map[*game.MatchSound]struct{}
 	breakDescription                  string
 	preloadedTeams                    *[6]*model.Team
+	wifiHistories                     []*model.WifiHistory
+	mqttUnsubscribers                 []func()
 }
 
 type AllianceStation struct {
-	DsConn     *DriverStationConnection
-	Ethernet   bool
-	AStop      bool
-	EStop      bool
-	Bypass     bool
-	Team       *model.Team
-	WifiStatus network.TeamWifiStatus
-	aStopReset bool
+	DsConn             *DriverStationConnection
+	Ethernet           bool
+	AStop              bool
+	EStop              bool
+	Bypass             bool
+	Team               *model.Team
+	WifiStatus         network.TeamWifiStatus
+	NetworkDiagnostics network.StationDiagnostics
+	aStopReset         bool
 }
 
 // Creates the arena and sets it to its initial state.
 func NewArena(dbPath string) (*Arena, error) {
 	arena := new(Arena)
 	arena.configureNotifiers()
+	arena.accessPoint = new(network.Vh113AccessPoint)
+	arena.networkDiagnostics = network.NewNetworkDiagnostics()
+	arena.RadioKiosk = new(network.Vh109RadioKiosk)
 	arena.Plc = new(plc.ModbusPlc)
+	arena.TbaClient = partner.NewTbaClient()
+	arena.NexusClient = partner.NewNexusClient()
+	arena.BlackmagicClient = partner.NewBlackmagicClient("")
+	arena.ObsClient = partner.NewObsClient()
+	arena.Lighting = lighting.NewDmxLights()
+	arena.OscClient = partner.NewOscClient()
+	arena.WebhookClient = partner.NewWebhookClient()
+	arena.MqttClient = partner.NewMqttClient(arena.handleMqttControl)
+	arena.TeamSigns = NewTeamSigns()
 
 	arena.AllianceStations = make(map[string]*AllianceStation)
 	arena.AllianceStations["R1"] = new(AllianceStation)
@@ -169,9 +200,34 @@ func (arena *Arena) LoadSettings() error {
 		settings.NetworkSecurityEnabled,
 		accessPointWifiStatuses,
 	)
-	arena.networkSwitch = network.NewSwitch(settings.SwitchAddress, settings.SwitchPassword)
+	arena.networkSwitch, err = network.NewSwitch(settings.SwitchType, settings.SwitchAddress, settings.SwitchPassword)
+	if err != nil {
+		return err
+	}
+	arena.networkDiagnostics.SetSettings(
+		settings.NetworkSecurityEnabled,
+		arena.networkSwitch,
+		[6]*network.StationDiagnostics{
+			&arena.AllianceStations["R1"].NetworkDiagnostics,
+			&arena.AllianceStations["R2"].NetworkDiagnostics,
+			&arena.AllianceStations["R3"].NetworkDiagnostics,
+			&arena.AllianceStations["B1"].NetworkDiagnostics,
+			&arena.AllianceStations["B2"].NetworkDiagnostics,
+			&arena.AllianceStations["B3"].NetworkDiagnostics,
+		},
+	)
+	arena.RadioKiosk.SetSettings(settings.RadioKioskAddress, settings.RadioKioskPassword)
 	arena.Plc.SetAddress(settings.PlcAddress)
-	arena.BlackmagicClient = partner.NewBlackmagicClient(settings.BlackmagicAddresses)
+	arena.BlackmagicClient.SetAddresses(settings.BlackmagicAddresses)
+	arena.TbaClient.SetSettings(settings.TbaEventCode, settings.TbaSecretId, settings.TbaSecret, settings.TbaApiKey)
+	arena.NexusClient.SetSettings(settings.TbaEventCode, settings.NexusApiKey)
+	arena.UpdateNexusUpcomingMatches()
+	arena.ObsClient.SetSettings(settings.ObsAddress, settings.ObsPassword)
+	arena.configureLighting()
+	arena.OscClient.SetTargets(settings.OscTargets)
+	arena.MqttClient.SetSettings(settings.MqttBrokerAddress, settings.MqttTopicPrefix)
+	arena.configureMqtt(settings.MqttBrokerAddress != "")
+	arena.TeamSigns.SetIds(settings)
 
 	game.MatchTiming.WarmupDurationSec = settings.WarmupDurationSec
 	game.MatchTiming.AutoDurationSec = settings.AutoDurationSec
@@ -224,6 +280,13 @@ func (arena *Arena) LoadMatch(match *model.Match) error {
 		return fmt.Errorf("cannot load match while there is a match still in progress or with results pending")
 	}
 
+	if arena.EventSettings.NexusEnabled && match.ShouldAllowNexusSubstitution() {
+		// Substitute the lineup into a copy so that the stored schedule is unaffected.
+		loadedMatch := *match
+		arena.applyNexusLineup(&loadedMatch)
+		match = &loadedMatch
+	}
+
 	arena.CurrentMatch = match
 
 	err := arena.assignTeam(match.Red1, "R1")
@@ -284,11 +347,18 @@ func (arena *Arena) LoadMatch(match *model.Match) error {
 
 	// Notify any listeners about the new match.
 	arena.MatchLoadNotifier.Notify()
+	arena.TriggerWebhook(model.WebhookMatchLoaded, arena.CurrentMatch)
 	arena.RealtimeScoreNotifier.Notify()
 	arena.AllianceStationDisplayMode = "match"
 	arena.AllianceStationDisplayModeNotifier.Notify()
 	arena.ScoringStatusNotifier.Notify()
 
+	arena.UpdateNexusUpcomingMatches()
+	if arena.EventSettings.NexusEnabled && match.Type != model.Test {
+		arena.updateNexusQueueStatuses(match)
+	}
+	arena.triggerObsActions(arena.EventSettings.ObsMatchLoadActions)
+
 	return nil
 }
 
@@ -428,6 +498,7 @@ func (arena *Arena) StartMatch() error {
 			arena.BlueRealtimeScore.CurrentScore.RobotsBypassed[i] = arena.AllianceStations["B"+stationNumber].Bypass
 		}
 
+		arena.startWifiHistories()
 		arena.MatchState = StartMatch
 	}
 	return err
@@ -453,6 +524,8 @@ func (arena *Arena) AbortMatch() error {
 	arena.AudienceDisplayMode = "blank"
 	arena.AudienceDisplayModeNotifier.Notify()
 	go arena.BlackmagicClient.StopRecording()
+	arena.handleObsMatchEnd()
+	arena.sendOscCues(arena.EventSettings.OscMatchAbortCues, "")
 	return nil
 }
 
@@ -492,6 +565,7 @@ func (arena *Arena) StartTimeout(description string, durationSec int) error {
 	arena.LastMatchTimeSec = -1
 	arena.AllianceStationDisplayMode = "timeout"
 	arena.AllianceStationDisplayModeNotifier.Notify()
+	arena.triggerObsActions(arena.EventSettings.ObsTimeoutActions)
 
 	return nil
 }
@@ -501,8 +575,12 @@ func (arena *Arena) SetAudienceDisplayMode(mode string) {
 	if arena.AudienceDisplayMode != mode {
 		arena.AudienceDisplayMode = mode
 		arena.AudienceDisplayModeNotifier.Notify()
-		if mode == "score" {
+		switch mode {
+		case "score":
 			arena.playSound("match_result")
+			arena.triggerObsActions(arena.EventSettings.ObsScorePostedActions)
+		case "allianceSelection":
+			arena.triggerObsActions(arena.EventSettings.ObsAllianceSelectionActions)
 		}
 	}
 }
@@ -544,7 +622,10 @@ func (arena *Arena) Update() {
 		arena.AudienceDisplayModeNotifier.Notify()
 		arena.AllianceStationDisplayMode = "match"
 		arena.AllianceStationDisplayModeNotifier.Notify()
-		go arena.BlackmagicClient.StartRecording()
+		go arena.recordMatchVideo(*arena.CurrentMatch)
+		arena.handleObsMatchStart()
+		arena.TriggerWebhook(model.WebhookMatchStarted, arena.CurrentMatch)
+		arena.sendOscCues(arena.EventSettings.OscMatchStartCues, "")
 		if game.MatchTiming.WarmupDurationSec > 0 {
 			arena.MatchState = WarmupPeriod
 			enabled = false
@@ -598,6 +679,9 @@ func (arena *Arena) Update() {
 			enabled = false
 			sendDsPacket = true
 			go arena.BlackmagicClient.StopRecording()
+			arena.handleObsMatchEnd()
+			arena.sendOscCues(arena.EventSettings.OscMatchEndCues, "")
+			arena.TriggerWebhook(model.WebhookMatchEnded, arena.CurrentMatch)
 			go func() {
 				// Leave the scores on the screen briefly at the end of the match.
 				time.Sleep(time.Second * matchEndScoreDwellSec)
@@ -633,6 +717,18 @@ func (arena *Arena) Update() {
 		arena.MatchTimeNotifier.Notify()
 	}
 
+	// Record the Wi-Fi statistics once per second while the match is running, and save them once it ends.
+	switch arena.MatchState {
+	case WarmupPeriod, AutoPeriod, PausePeriod, TeleopPeriod:
+		if int(matchTimeSec) != int(arena.LastMatchTimeSec) {
+			arena.recordWifiSamples(matchTimeSec)
+		}
+	case PostMatch:
+		if arena.lastMatchState != PostMatch {
+			arena.saveWifiHistories()
+		}
+	}
+
 	// Send a packet if at a period transition point or if it's been long enough since the last one.
 	msSinceLastDsPacket := int(time.Since(arena.lastDsPacketTime).Seconds() * 1000)
 	if sendDsPacket || msSinceLastDsPacket >= dsPacketPeriodMs {
@@ -647,6 +743,10 @@ func (arena *Arena) Update() {
 
 	// Handle field sensors/lights/actuators.
 	arena.handlePlcInputOutput()
+	arena.handleLighting()
+	if arena.TeamSigns.Update(arena) {
+		arena.TeamSignsNotifier.Notify()
+	}
 
 	arena.LastMatchTimeSec = matchTimeSec
 	arena.lastMatchState = arena.MatchState
@@ -658,7 +758,14 @@ func (arena *Arena) Run() {
 	go arena.listenForDriverStations()
 	go arena.listenForDsUdpPackets()
 	go arena.accessPoint.Run()
+	go arena.networkDiagnostics.Run()
 	go arena.Plc.Run()
+	go arena.TbaClient.Run()
+	go arena.NexusClient.Run()
+	go arena.BlackmagicClient.Run()
+	go arena.ObsClient.Run()
+	go arena.Lighting.Run()
+	go arena.MqttClient.Run()
 
 	for {
 		loopStartTime := time.Now()
@@ -815,6 +922,7 @@ func (arena *Arena) setupNetwork(teams [6]*model.Team, isPreload bool) {
 			teams[2] = nil // R3
 			teams[5] = nil // B3
 		}
+		arena.networkDiagnostics.SetTeams(teams)
 		if err := arena.accessPoint.ConfigureTeamWifi(teams); err != nil {
 			log.Printf("Failed to configure team WiFi: %s", err.Error())
 		}
@@ -826,6 +934,11 @@ func (arena *Arena) setupNetwork(teams [6]*model.Team, isPreload bool) {
 	}
 }
 
+// Returns true if the match can be started.
+func (arena *Arena) CanStartMatch() bool {
+	return arena.checkCanStartMatch() == nil
+}
+
 // Returns nil if the match can be started, and an error otherwise.
 func (arena *Arena) checkCanStartMatch() error {
 	if arena.MatchState != PreMatch {
@@ -979,9 +1092,7 @@ func (arena *Arena) handlePlcInputOutput() {
 		if arena.FieldReset {
 			arena.Plc.SetFieldResetLight(true)
 		}
-		scoreReady := arena.RedRealtimeScore.FoulsCommitted && arena.BlueRealtimeScore.FoulsCommitted &&
-			arena.positionPostMatchScoreReady("red_near") && arena.positionPostMatchScoreReady("red_far") &&
-			arena.positionPostMatchScoreReady("blue_near") && arena.positionPostMatchScoreReady("blue_far")
+		scoreReady := arena.PostMatchScoreReady()
 		arena.Plc.SetStackLights(false, false, !scoreReady, false)
 	case AutoPeriod, PausePeriod, TeleopPeriod:
 		arena.Plc.SetStackBuzzer(false)
@@ -1042,9 +1153,17 @@ func (arena *Arena) handleSounds(matchTimeSec float64) {
 func (arena *Arena) playSound(name string) {
 	if !arena.MuteMatchSounds {
 		arena.PlaySoundNotifier.NotifyWithMessage(name)
+		arena.sendOscCues(arena.EventSettings.OscSoundCues, name)
 	}
 }
 
+// Returns true if the referee and the scorers for every position have committed their scores after the match.
+func (arena *Arena) PostMatchScoreReady() bool {
+	return arena.RedRealtimeScore.FoulsCommitted && arena.BlueRealtimeScore.FoulsCommitted &&
+		arena.positionPostMatchScoreReady("red_near") && arena.positionPostMatchScoreReady("red_far") &&
+		arena.positionPostMatchScoreReady("blue_near") && arena.positionPostMatchScoreReady("blue_far")
+}
+
 func (arena *Arena) positionPostMatchScoreReady(position string) bool {
 	numPanels := arena.ScoringPanelRegistry.GetNumPanels(position)
 	return numPanels > 0 && arena.ScoringPanelRegistry.GetNumScoreCommitted(position) >= numPanels
@@ -6,10 +6,12 @@
 package field
 
 import (
+	"fmt"
 	"strconv"
 
 	"github.com/Team254/cheesy-arena/game"
 	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
 	"github.com/Team254/cheesy-arena/playoff"
 	"github.com/Team254/cheesy-arena/websocket"
 )
@@ -30,6 +32,7 @@ type ArenaNotifiers struct {
 	ReloadDisplaysNotifier             *websocket.Notifier
 	ScorePostedNotifier                *websocket.Notifier
 	ScoringStatusNotifier              *websocket.Notifier
+	TeamSignsNotifier                  *websocket.Notifier
 }
 
 type MatchTimeMessage struct {
@@ -61,10 +64,11 @@ func (arena *Arena) configureNotifiers() {
 	arena.MatchTimeNotifier = websocket.NewNotifier("matchTime", arena.generateMatchTimeMessage)
 	arena.MatchTimingNotifier = websocket.NewNotifier("matchTiming", arena.generateMatchTimingMessage)
 	arena.PlaySoundNotifier = websocket.NewNotifier("playSound", nil)
-	arena.RealtimeScoreNotifier = websocket.NewNotifier("realtimeScore", arena.generateRealtimeScoreMessage)
+	arena.RealtimeScoreNotifier = websocket.NewNotifier("realtimeScore", arena.GenerateRealtimeScoreMessage)
 	arena.ReloadDisplaysNotifier = websocket.NewNotifier("reload", nil)
 	arena.ScorePostedNotifier = websocket.NewNotifier("scorePosted", arena.GenerateScorePostedMessage)
 	arena.ScoringStatusNotifier = websocket.NewNotifier("scoringStatus", arena.generateScoringStatusMessage)
+	arena.TeamSignsNotifier = websocket.NewNotifier("teamSigns", arena.generateTeamSignsMessage)
 }
 
 func (arena *Arena) generateAllianceSelectionMessage() any {
@@ -96,16 +100,22 @@ func (arena *Arena) generateArenaStatusMessage() any {
 		PlcIsHealthy          bool
 		FieldEStop            bool
 		PlcArmorBlockStatuses map[string]bool
+		RecordingStatuses     []partner.BlackmagicDeviceStatus
+		ObsStatus             partner.ObsStatus
+		MqttStatus            partner.MqttStatus
 	}{
 		arena.CurrentMatch.Id,
 		arena.AllianceStations,
 		arena.MatchState,
-		arena.checkCanStartMatch() == nil,
-		arena.accessPoint.Status,
-		arena.networkSwitch.Status,
+		arena.CanStartMatch(),
+		arena.accessPoint.GetStatus(),
+		arena.networkSwitch.GetStatus(),
 		arena.Plc.IsHealthy(),
 		arena.Plc.GetFieldEStop(),
 		arena.Plc.GetArmorBlockStatuses(),
+		arena.BlackmagicClient.GetStatuses(),
+		arena.ObsClient.Status(),
+		arena.MqttClient.Status(),
 	}
 }
 
@@ -208,7 +218,7 @@ func (arena *Arena) generateMatchTimingMessage() any {
 	return &game.MatchTiming
 }
 
-func (arena *Arena) generateRealtimeScoreMessage() any {
+func (arena *Arena) GenerateRealtimeScoreMessage() any {
 	fields := struct {
 		Red       *audienceAllianceScoreFields
 		Blue      *audienceAllianceScoreFields
@@ -344,6 +354,31 @@ func (arena *Arena) generateScoringStatusMessage() any {
 	}
 }
 
+// Constructs the list of current team sign states sent to the team signs setup page.
+func (arena *Arena) generateTeamSignsMessage() any {
+	type teamSignMessage struct {
+		Name       string
+		Id         int
+		FrontText  string
+		FrontColor string
+		RearText   string
+	}
+	var signs []teamSignMessage
+	for _, sign := range arena.TeamSigns.All() {
+		signs = append(
+			signs,
+			teamSignMessage{
+				sign.Name,
+				sign.Id,
+				sign.FrontText,
+				fmt.Sprintf("#%02x%02x%02x", sign.FrontColor.R, sign.FrontColor.G, sign.FrontColor.B),
+				sign.RearText,
+			},
+		)
+	}
+	return signs
+}
+
 // Constructs the data object for one alliance sent to the audience display for the realtime scoring overlay.
 func getAudienceAllianceScoreFields(
 	allianceScore *RealtimeScore,
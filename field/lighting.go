@@ -0,0 +1,77 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Arena logic for selecting the DMX field lighting cue to output based on the match state.
+
+package field
+
+import (
+	"github.com/Team254/cheesy-arena/game"
+)
+
+// Names of the lighting cues that can be configured in the event settings.
+const (
+	lightingIdleCue    = "idle"
+	lightingReadyCue   = "ready"
+	lightingAutoCue    = "auto"
+	lightingTeleopCue  = "teleop"
+	lightingEndgameCue = "endgame"
+	lightingRedWinCue  = "redWin"
+	lightingBlueWinCue = "blueWin"
+	lightingTieCue     = "tie"
+)
+
+// Passes the cue definitions from the event settings to the lighting output.
+func (arena *Arena) configureLighting() {
+	settings := arena.EventSettings
+	arena.Lighting.SetSettings(
+		settings.LightingProtocol,
+		settings.LightingAddress,
+		settings.LightingUniverse,
+		map[string]string{
+			lightingIdleCue:    settings.LightingIdleCue,
+			lightingReadyCue:   settings.LightingReadyCue,
+			lightingAutoCue:    settings.LightingAutoCue,
+			lightingTeleopCue:  settings.LightingTeleopCue,
+			lightingEndgameCue: settings.LightingEndgameCue,
+			lightingRedWinCue:  settings.LightingRedWinCue,
+			lightingBlueWinCue: settings.LightingBlueWinCue,
+			lightingTieCue:     settings.LightingTieCue,
+		},
+	)
+}
+
+// Updates the lighting output to the cue corresponding to the current match state.
+func (arena *Arena) handleLighting() {
+	arena.Lighting.SetCue(arena.getLightingCue())
+}
+
+// Returns the name of the lighting cue that should currently be shown.
+func (arena *Arena) getLightingCue() string {
+	switch arena.MatchState {
+	case StartMatch, WarmupPeriod, AutoPeriod:
+		return lightingAutoCue
+	case PausePeriod, TeleopPeriod:
+		endgameStartSec := game.GetDurationToTeleopEnd().Seconds() - float64(game.MatchTiming.WarningRemainingDurationSec)
+		if arena.MatchTimeSec() >= endgameStartSec {
+			return lightingEndgameCue
+		}
+		return lightingTeleopCue
+	case PreMatch, PostMatch:
+		// Show the winner's color once the score has been posted, until the next match starts.
+		if arena.AudienceDisplayMode == "score" {
+			switch arena.SavedMatch.Status {
+			case game.RedWonMatch:
+				return lightingRedWinCue
+			case game.BlueWonMatch:
+				return lightingBlueWinCue
+			case game.TieMatch:
+				return lightingTieCue
+			}
+		}
+		if arena.MatchState == PreMatch && arena.CanStartMatch() {
+			return lightingReadyCue
+		}
+	}
+	return lightingIdleCue
+}
@@ -0,0 +1,56 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package field
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArenaLightingCue(t *testing.T) {
+	arena := setupTestArena(t)
+
+	arena.Update()
+	assert.Equal(t, lightingIdleCue, arena.Lighting.GetCue())
+
+	for _, station := range []string{"R1", "R2", "R3", "B1", "B2", "B3"} {
+		arena.AllianceStations[station].Bypass = true
+	}
+	arena.Update()
+	assert.Equal(t, lightingReadyCue, arena.Lighting.GetCue())
+
+	arena.MatchState = WarmupPeriod
+	assert.Equal(t, lightingAutoCue, arena.getLightingCue())
+	arena.MatchState = AutoPeriod
+	assert.Equal(t, lightingAutoCue, arena.getLightingCue())
+
+	arena.MatchState = TeleopPeriod
+	arena.MatchStartTime = time.Now().Add(-game.GetDurationToAutoEnd() - time.Second)
+	assert.Equal(t, lightingTeleopCue, arena.getLightingCue())
+	arena.MatchStartTime = time.Now().Add(
+		-game.GetDurationToTeleopEnd() + time.Duration(game.MatchTiming.WarningRemainingDurationSec-1)*time.Second,
+	)
+	assert.Equal(t, lightingEndgameCue, arena.getLightingCue())
+
+	arena.MatchState = PostMatch
+	assert.Equal(t, lightingIdleCue, arena.getLightingCue())
+
+	// The winner's color should be shown once the score is posted and until the next match starts.
+	arena.SavedMatch.Status = game.BlueWonMatch
+	arena.SetAudienceDisplayMode("score")
+	assert.Equal(t, lightingBlueWinCue, arena.getLightingCue())
+	arena.SavedMatch.Status = game.RedWonMatch
+	arena.MatchState = PreMatch
+	assert.Equal(t, lightingRedWinCue, arena.getLightingCue())
+	arena.SavedMatch.Status = game.TieMatch
+	assert.Equal(t, lightingTieCue, arena.getLightingCue())
+	arena.SetAudienceDisplayMode("blank")
+	assert.Equal(t, lightingReadyCue, arena.getLightingCue())
+
+	arena.MatchState = TimeoutActive
+	assert.Equal(t, lightingIdleCue, arena.getLightingCue())
+}
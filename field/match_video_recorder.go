@@ -0,0 +1,58 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Functions for starting the HyperDeck recording of a match and indexing the resulting clips.
+
+package field
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Team254/cheesy-arena/model"
+)
+
+// Starts recording the given match on all HyperDeck devices and saves a record of each resulting clip so that it can
+// be found afterward. Replays of a match get a distinct clip name so that they aren't mixed up with the original.
+func (arena *Arena) recordMatchVideo(match model.Match) {
+	startedAt := time.Now()
+	clipName := match.ShortName
+	if match.Type != model.Test {
+		matchVideos, err := arena.Database.GetMatchVideosForMatch(match.Id)
+		if err != nil {
+			log.Printf("Failed to get existing videos for match %s: %v", match.ShortName, err)
+		}
+		if playNumber := countMatchVideoPlays(matchVideos) + 1; playNumber > 1 {
+			clipName = fmt.Sprintf("%s_Play%d", match.ShortName, playNumber)
+		}
+	}
+
+	recordings := arena.BlackmagicClient.StartRecording(clipName)
+	if match.Type == model.Test {
+		// The match won't be saved, so there is nothing to link the clips to.
+		return
+	}
+	for _, recording := range recordings {
+		matchVideo := model.MatchVideo{
+			MatchId:       match.Id,
+			DeviceAddress: recording.Address,
+			ClipName:      recording.ClipName,
+			Timecode:      recording.Timecode,
+			StartedAt:     startedAt,
+		}
+		if err := arena.Database.CreateMatchVideo(&matchVideo); err != nil {
+			log.Printf("Failed to save video index for match %s: %v", match.ShortName, err)
+		}
+	}
+}
+
+// Returns the number of distinct plays represented by the given videos, given that all devices recording a single play
+// share the same start time.
+func countMatchVideoPlays(matchVideos []model.MatchVideo) int {
+	playStartTimes := make(map[int64]struct{})
+	for _, matchVideo := range matchVideos {
+		playStartTimes[matchVideo.StartedAt.UnixNano()] = struct{}{}
+	}
+	return len(playStartTimes)
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package field
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArenaRecordMatchVideo(t *testing.T) {
+	arena := setupTestArena(t)
+
+	// Start a minimal HyperDeck that accepts every command.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer listener.Close()
+	recordCommands := make(chan string, 10)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				fmt.Fprint(conn, "500 connection info:\r\n\r\n")
+				scanner := bufio.NewScanner(conn)
+				for scanner.Scan() {
+					switch command := scanner.Text(); {
+					case strings.HasPrefix(command, "record"):
+						recordCommands <- command
+						fmt.Fprint(conn, "200 ok\r\n")
+					case command == "transport info":
+						fmt.Fprint(conn, "208 transport info:\r\nstatus: record\r\ntimecode: 00:10:00:00\r\n\r\n")
+					default:
+						fmt.Fprint(conn, "202 slot info:\r\nrecording time: 7200\r\n\r\n")
+					}
+				}
+			}()
+		}
+	}()
+	address := listener.Addr().String()
+	arena.BlackmagicClient.SetAddresses(address)
+
+	match := model.Match{Type: model.Qualification, ShortName: "Q12"}
+	assert.Nil(t, arena.Database.CreateMatch(&match))
+	arena.recordMatchVideo(match)
+	assert.Equal(t, "record: name: Q12", <-recordCommands)
+	matchVideos, err := arena.Database.GetMatchVideosForMatch(match.Id)
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(matchVideos)) {
+		assert.Equal(t, address, matchVideos[0].DeviceAddress)
+		assert.Equal(t, "Q12", matchVideos[0].ClipName)
+		assert.Equal(t, "00:10:00:00", matchVideos[0].Timecode)
+	}
+
+	// A replay of the match should be recorded under a distinct clip name.
+	arena.recordMatchVideo(match)
+	assert.Equal(t, "record: name: Q12_Play2", <-recordCommands)
+	matchVideos, _ = arena.Database.GetMatchVideosForMatch(match.Id)
+	if assert.Equal(t, 2, len(matchVideos)) {
+		assert.Equal(t, "Q12_Play2", matchVideos[1].ClipName)
+	}
+
+	// Test matches should be recorded but not indexed.
+	testMatch := model.Match{Type: model.Test, ShortName: "T"}
+	arena.recordMatchVideo(testMatch)
+	assert.Equal(t, "record: name: T", <-recordCommands)
+	matchVideos, _ = arena.Database.GetMatchVideosForMatch(0)
+	assert.Empty(t, matchVideos)
+}
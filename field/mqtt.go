@@ -0,0 +1,90 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Arena logic for publishing live field state to an MQTT broker and accepting control messages from it.
+
+package field
+
+import (
+	"log"
+	"slices"
+
+	"github.com/Team254/cheesy-arena/websocket"
+)
+
+// Topics (relative to the configured prefix) that field state is published to.
+const (
+	mqttArenaStatusTopic         = "arena/status"
+	mqttAudienceDisplayModeTopic = "audience_display_mode"
+	mqttMatchLoadTopic           = "match/load"
+	mqttMatchTimeTopic           = "match/time"
+	mqttRankingsTopic            = "rankings"
+	mqttRealtimeScoreTopic       = "score/realtime"
+)
+
+// Names of the control topics (i.e. "<prefix>/control/<name>") that are accepted from the broker.
+const mqttAudienceDisplayModeControl = "audience_display_mode"
+
+// Starts or stops publishing the message from each relevant notifier to its corresponding MQTT topic, according to
+// whether a broker has been configured.
+func (arena *Arena) configureMqtt(enabled bool) {
+	if enabled == (arena.mqttUnsubscribers != nil) {
+		return
+	}
+	if !enabled {
+		for _, unsubscribe := range arena.mqttUnsubscribers {
+			unsubscribe()
+		}
+		arena.mqttUnsubscribers = nil
+		return
+	}
+
+	topics := map[string]*websocket.Notifier{
+		mqttArenaStatusTopic:         arena.ArenaStatusNotifier,
+		mqttAudienceDisplayModeTopic: arena.AudienceDisplayModeNotifier,
+		mqttMatchLoadTopic:           arena.MatchLoadNotifier,
+		mqttMatchTimeTopic:           arena.MatchTimeNotifier,
+		mqttRealtimeScoreTopic:       arena.RealtimeScoreNotifier,
+	}
+	for topic, notifier := range topics {
+		// The notifier serializes each message on the arena goroutine, so the live arena state isn't read concurrently.
+		unsubscribe := notifier.Subscribe(
+			func(messageJson []byte) {
+				arena.MqttClient.PublishJson(topic, messageJson)
+			},
+		)
+		arena.mqttUnsubscribers = append(arena.mqttUnsubscribers, unsubscribe)
+	}
+
+	// Rankings only change when a score is posted, but aren't part of that message in full.
+	unsubscribe := arena.ScorePostedNotifier.Subscribe(
+		func(messageJson []byte) {
+			arena.publishMqttRankings()
+		},
+	)
+	arena.mqttUnsubscribers = append(arena.mqttUnsubscribers, unsubscribe)
+}
+
+// Publishes the full current rankings to MQTT.
+func (arena *Arena) publishMqttRankings() {
+	rankings, err := arena.Database.GetAllRankings()
+	if err != nil {
+		log.Printf("Failed to get rankings for MQTT: %v", err)
+		return
+	}
+	arena.MqttClient.Publish(mqttRankingsTopic, rankings)
+}
+
+// Handles a message received on one of the MQTT control topics.
+func (arena *Arena) handleMqttControl(name, value string) {
+	switch name {
+	case mqttAudienceDisplayModeControl:
+		if !slices.Contains(AudienceDisplayModes, value) {
+			log.Printf("Ignoring MQTT message with unknown audience display mode %q.", value)
+			return
+		}
+		arena.SetAudienceDisplayMode(value)
+	default:
+		log.Printf("Ignoring MQTT message for unknown control topic %q.", name)
+	}
+}
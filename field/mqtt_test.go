@@ -0,0 +1,134 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package field
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArenaMqttControl(t *testing.T) {
+	arena := setupTestArena(t)
+
+	arena.handleMqttControl(mqttAudienceDisplayModeControl, "score")
+	assert.Equal(t, "score", arena.AudienceDisplayMode)
+	arena.handleMqttControl("bogus", "blank")
+	assert.Equal(t, "score", arena.AudienceDisplayMode)
+	arena.handleMqttControl(mqttAudienceDisplayModeControl, "bogus")
+	assert.Equal(t, "score", arena.AudienceDisplayMode)
+}
+
+func TestArenaMqttPublish(t *testing.T) {
+	arena := setupTestArena(t)
+	messages := startFakeMqttBroker(t)
+	go arena.MqttClient.Run()
+
+	// Nothing should be recorded for publishing while MQTT is disabled.
+	assert.Nil(t, arena.mqttUnsubscribers)
+	assert.Nil(t, arena.LoadTestMatch())
+
+	arena.EventSettings.MqttBrokerAddress = messages.address
+	assert.Nil(t, arena.Database.UpdateEventSettings(arena.EventSettings))
+	assert.Nil(t, arena.LoadSettings())
+	assert.NotNil(t, arena.mqttUnsubscribers)
+
+	// Field state should be published as of when the notification was sent, regardless of later changes. Since the
+	// test match was loaded while MQTT was disabled, this should be the first message the broker receives.
+	arena.RedRealtimeScore.CurrentScore.Mayhem.LeaveStatuses = [3]bool{true, false, false}
+	arena.RealtimeScoreNotifier.Notify()
+	arena.RedRealtimeScore.CurrentScore.Mayhem.LeaveStatuses = [3]bool{true, true, true}
+	payload := messages.next(t, "cheesy-arena/"+mqttRealtimeScoreTopic)
+	var realtimeScore struct{ Red struct{ Score game.Score } }
+	assert.Nil(t, json.Unmarshal(payload, &realtimeScore))
+	assert.Equal(t, [3]bool{true, false, false}, realtimeScore.Red.Score.Mayhem.LeaveStatuses)
+
+	arena.Database.CreateRanking(&game.Ranking{TeamId: 254, Rank: 1})
+	arena.ScorePostedNotifier.Notify()
+	payload = messages.next(t, "cheesy-arena/"+mqttRankingsTopic)
+	var rankings []game.Ranking
+	assert.Nil(t, json.Unmarshal(payload, &rankings))
+	if assert.Equal(t, 1, len(rankings)) {
+		assert.Equal(t, 254, rankings[0].TeamId)
+	}
+
+	// Disabling MQTT should cancel the subscriptions.
+	arena.EventSettings.MqttBrokerAddress = ""
+	assert.Nil(t, arena.Database.UpdateEventSettings(arena.EventSettings))
+	assert.Nil(t, arena.LoadSettings())
+	assert.Nil(t, arena.mqttUnsubscribers)
+}
+
+// Minimal stand-in for an MQTT broker that accepts a single client and passes along the messages it publishes.
+type fakeMqttMessages struct {
+	address  string
+	messages chan [2]string
+}
+
+func startFakeMqttBroker(t *testing.T) *fakeMqttMessages {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	t.Cleanup(func() { listener.Close() })
+	broker := &fakeMqttMessages{address: listener.Addr().String(), messages: make(chan [2]string, 100)}
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			packetType, body, err := readFakeMqttPacket(conn)
+			if err != nil {
+				return
+			}
+			switch packetType {
+			case 1: // CONNECT
+				conn.Write([]byte{0x20, 2, 0, 0})
+			case 3: // PUBLISH
+				topicLength := int(binary.BigEndian.Uint16(body))
+				broker.messages <- [2]string{string(body[2 : 2+topicLength]), string(body[2+topicLength:])}
+			}
+		}
+	}()
+	return broker
+}
+
+// Asserts that the next message published to the broker is on the given topic and returns its payload.
+func (broker *fakeMqttMessages) next(t *testing.T, topic string) []byte {
+	select {
+	case message := <-broker.messages:
+		assert.Equal(t, topic, message[0])
+		return []byte(message[1])
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "Timed out waiting for MQTT message on topic "+topic)
+		return nil
+	}
+}
+
+func readFakeMqttPacket(reader io.Reader) (byte, []byte, error) {
+	var header [1]byte
+	if _, err := io.ReadFull(reader, header[:]); err != nil {
+		return 0, nil, err
+	}
+	packetType := header[0] >> 4
+	length := 0
+	for multiplier := 1; ; multiplier *= 128 {
+		if _, err := io.ReadFull(reader, header[:]); err != nil {
+			return 0, nil, err
+		}
+		length += int(header[0]&0x7f) * multiplier
+		if header[0]&0x80 == 0 {
+			break
+		}
+	}
+	body := make([]byte, length)
+	_, err := io.ReadFull(reader, body)
+	return packetType, body, err
+}
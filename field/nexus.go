@@ -0,0 +1,145 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Arena logic for loading match lineups from and reporting queueing status to Nexus.
+
+package field
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
+)
+
+// Maximum time to wait when loading a match whose lineup wasn't fetched from Nexus ahead of time.
+const nexusLineupFallbackTimeoutMs = 300
+
+// Replaces the scheduled teams in the given loaded match with the lineup submitted in Nexus for any alliance that has
+// one, leaving the stored schedule untouched. Lineups are normally fetched ahead of time in the background; for a
+// match that wasn't, such as one loaded out of order, the lineup is fetched briefly before giving up. Falls back to the
+// scheduled teams if no lineup is on hand or it isn't valid.
+func (arena *Arena) applyNexusLineup(match *model.Match) {
+	lineup, err := arena.NexusClient.GetLineupWithTimeout(
+		match.TbaMatchKey, nexusLineupFallbackTimeoutMs*time.Millisecond,
+	)
+	if err != nil {
+		log.Printf("No lineup from Nexus is available for match %s; using scheduled teams: %v", match.ShortName, err)
+		return
+	}
+
+	changed := false
+	if len(lineup.Red) > 0 {
+		if teamIds, err := arena.checkNexusAllianceLineup(lineup.Red, match.PlayoffRedAlliance, match); err != nil {
+			log.Printf("Ignoring red lineup from Nexus for match %s: %v", match.ShortName, err)
+		} else {
+			match.Red1, match.Red2, match.Red3 = teamIds[0], teamIds[1], teamIds[2]
+			changed = true
+		}
+	}
+	if len(lineup.Blue) > 0 {
+		if teamIds, err := arena.checkNexusAllianceLineup(lineup.Blue, match.PlayoffBlueAlliance, match); err != nil {
+			log.Printf("Ignoring blue lineup from Nexus for match %s: %v", match.ShortName, err)
+		} else {
+			match.Blue1, match.Blue2, match.Blue3 = teamIds[0], teamIds[1], teamIds[2]
+			changed = true
+		}
+	}
+
+	if changed {
+		log.Printf(
+			"Loaded lineup for match %s from Nexus: %d %d %d vs. %d %d %d", match.ShortName, match.Red1, match.Red2,
+			match.Red3, match.Blue1, match.Blue2, match.Blue3,
+		)
+	}
+}
+
+// Validates the given alliance lineup from Nexus and returns it padded out to three stations.
+func (arena *Arena) checkNexusAllianceLineup(teamIds []int, allianceId int, match *model.Match) ([3]int, error) {
+	var lineup [3]int
+	if teamsPerAlliance := arena.EventSettings.TeamsPerAlliance(); len(teamIds) != teamsPerAlliance {
+		return lineup, fmt.Errorf("lineup has %d teams but alliances have %d", len(teamIds), teamsPerAlliance)
+	}
+	copy(lineup[:], teamIds)
+	if err := arena.validateTeams(lineup[:]...); err != nil {
+		return lineup, err
+	}
+
+	if match.Type == model.Playoff {
+		// Only members of the alliance may be substituted in.
+		alliance, err := arena.Database.GetAllianceById(allianceId)
+		if err != nil {
+			return lineup, err
+		}
+		if alliance == nil {
+			return lineup, fmt.Errorf("alliance %d does not exist", allianceId)
+		}
+		for _, teamId := range teamIds {
+			if !slices.Contains(alliance.TeamIds, teamId) {
+				return lineup, fmt.Errorf("team %d is not on alliance %d", teamId, allianceId)
+			}
+		}
+	}
+	return lineup, nil
+}
+
+// Reports the given match as being on the field and the following ones as on deck and queuing.
+func (arena *Arena) updateNexusQueueStatuses(match *model.Match) {
+	matches, err := arena.Database.GetMatchesByType(match.Type, false)
+	if err != nil {
+		log.Printf("Failed to update Nexus queueing status: %v", err)
+		return
+	}
+	statuses := []string{partner.NexusOnField, partner.NexusOnDeck, partner.NexusNowQueuing}
+	for i, scheduledMatch := range matches {
+		if scheduledMatch.Id != match.Id {
+			continue
+		}
+		for j, status := range statuses {
+			if i+j < len(matches) {
+				arena.NexusClient.SetMatchStatus(matches[i+j].TbaMatchKey, status)
+			}
+		}
+		return
+	}
+}
+
+// Has the lineups fetched in the background for the matches most likely to be loaded next, so that they are ready by
+// the time each is loaded: the two queued behind the current match, and the next unplayed match of each type for when
+// play moves on to a new schedule. Should be called whenever the current match or the schedule changes.
+func (arena *Arena) UpdateNexusUpcomingMatches() {
+	if !arena.EventSettings.NexusEnabled {
+		arena.NexusClient.SetUpcomingMatches(nil)
+		return
+	}
+
+	var upcomingMatches []model.TbaMatchKey
+	addMatch := func(match *model.Match) {
+		if match.ShouldAllowNexusSubstitution() && !slices.Contains(upcomingMatches, match.TbaMatchKey) {
+			upcomingMatches = append(upcomingMatches, match.TbaMatchKey)
+		}
+	}
+	for _, matchType := range []model.MatchType{model.Practice, model.Qualification, model.Playoff} {
+		matches, err := arena.Database.GetMatchesByType(matchType, false)
+		if err != nil {
+			log.Printf("Failed to get upcoming matches for Nexus: %v", err)
+			return
+		}
+		foundNextMatch := false
+		for i, match := range matches {
+			isCurrentMatch := arena.CurrentMatch != nil && match.Id == arena.CurrentMatch.Id
+			if isCurrentMatch {
+				for j := i + 1; j < len(matches) && j <= i+2; j++ {
+					addMatch(&matches[j])
+				}
+			} else if !foundNextMatch && !match.IsComplete() {
+				addMatch(&matches[i])
+				foundNextMatch = true
+			}
+		}
+	}
+	arena.NexusClient.SetUpcomingMatches(upcomingMatches)
+}
@@ -0,0 +1,228 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package field
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArenaNexusLineupAndQueueing(t *testing.T) {
+	arena := setupTestArena(t)
+	lineups := map[string]string{
+		"p1":  `{"red":["107","102","103"],"blue":null}`,
+		"p2":  `{"red":["107","102","103"],"blue":null}`,
+		"p3":  `{"red":["107","102"],"blue":["104","105","999"]}`,
+		"p4":  `{"red":null,"blue":["104","105","107"]}`,
+		"qm1": `{"red":["107","102","103"],"blue":null}`,
+	}
+	statuses := make(chan [2]string, 10)
+	var lineupRequests []string
+	slowMatchKey := ""
+	var mutex sync.Mutex
+	mux := http.NewServeMux()
+	mux.HandleFunc(
+		"GET /api/v1/event/2026test/match/{matchKey}/lineup",
+		func(w http.ResponseWriter, r *http.Request) {
+			matchKey := r.PathValue("matchKey")
+			mutex.Lock()
+			lineupRequests = append(lineupRequests, matchKey)
+			isSlow := matchKey == slowMatchKey
+			mutex.Unlock()
+			if isSlow {
+				// Simulate Nexus being too slow to respond.
+				<-r.Context().Done()
+				return
+			}
+			if lineup, ok := lineups[matchKey]; ok {
+				w.Write([]byte(lineup))
+				return
+			}
+			http.NotFound(w, r)
+		},
+	)
+	mux.HandleFunc(
+		"POST /api/v1/event/2026test/match/{matchKey}/status",
+		func(w http.ResponseWriter, r *http.Request) {
+			var status struct{ Status string }
+			json.NewDecoder(r.Body).Decode(&status)
+			statuses <- [2]string{r.PathValue("matchKey"), status.Status}
+		},
+	)
+	nexusServer := httptest.NewServer(mux)
+	defer nexusServer.Close()
+	arena.NexusClient.BaseUrl = nexusServer.URL
+	arena.EventSettings.NexusEnabled = true
+	arena.NexusClient.SetSettings("2026test", "api_key")
+	go arena.NexusClient.Run()
+
+	for teamId := 101; teamId <= 107; teamId++ {
+		arena.Database.CreateTeam(&model.Team{Id: teamId})
+	}
+	var matches []model.Match
+	for i := 1; i <= 5; i++ {
+		match := model.Match{
+			Type:        model.Practice,
+			TypeOrder:   i,
+			Red1:        101,
+			Red2:        102,
+			Red3:        103,
+			Blue1:       104,
+			Blue2:       105,
+			Blue3:       106,
+			TbaMatchKey: model.TbaMatchKey{CompLevel: "p", MatchNumber: i},
+		}
+		arena.Database.CreateMatch(&match)
+		matches = append(matches, match)
+	}
+	var qualificationMatches []model.Match
+	for i := 1; i <= 2; i++ {
+		match := model.Match{
+			Type:        model.Qualification,
+			TypeOrder:   i,
+			Red1:        101,
+			Red2:        102,
+			Red3:        103,
+			Blue1:       104,
+			Blue2:       105,
+			Blue3:       106,
+			TbaMatchKey: model.TbaMatchKey{CompLevel: "qm", MatchNumber: i},
+		}
+		arena.Database.CreateMatch(&match)
+		qualificationMatches = append(qualificationMatches, match)
+	}
+
+	// The first match of the schedule should have its lineup fetched ahead of time once the schedule is saved.
+	arena.UpdateNexusUpcomingMatches()
+	waitForNexusLineup(t, arena, matches[0])
+
+	// A submitted lineup should replace the scheduled teams only for the alliance that submitted it, and only in the
+	// loaded match rather than the stored schedule.
+	assert.Nil(t, arena.LoadMatch(&matches[0]))
+	assert.Equal(t, 107, arena.CurrentMatch.Red1)
+	assert.Equal(t, 107, arena.AllianceStations["R1"].Team.Id)
+	assert.Equal(t, 104, arena.AllianceStations["B1"].Team.Id)
+	assert.Equal(t, 101, matches[0].Red1)
+	match, _ := arena.Database.GetMatchById(matches[0].Id)
+	assert.Equal(t, 101, match.Red1)
+	assertNexusStatuses(
+		t,
+		statuses,
+		map[string]string{"p1": partner.NexusOnField, "p2": partner.NexusOnDeck, "p3": partner.NexusNowQueuing},
+	)
+	waitForNexusLineup(t, arena, matches[1])
+
+	// A match loaded out of order should have its lineup fetched on the spot.
+	assert.Nil(t, arena.NexusClient.GetCachedLineup(matches[3].TbaMatchKey))
+	assert.Nil(t, arena.LoadMatch(&matches[3]))
+	assert.Equal(t, 101, arena.AllianceStations["R1"].Team.Id)
+	assert.Equal(t, 107, arena.AllianceStations["B3"].Team.Id)
+	assertNexusStatuses(t, statuses, map[string]string{"p4": partner.NexusOnField, "p5": partner.NexusOnDeck})
+
+	// Lineups that are the wrong size or include unknown teams should be ignored in favor of the scheduled teams.
+	assert.Nil(t, arena.LoadMatch(&matches[2]))
+	assert.Equal(t, 101, arena.AllianceStations["R1"].Team.Id)
+	assert.Equal(t, 103, arena.AllianceStations["R3"].Team.Id)
+	assert.Equal(t, 106, arena.AllianceStations["B3"].Team.Id)
+	assertNexusStatuses(
+		t,
+		statuses,
+		map[string]string{"p3": partner.NexusOnField, "p4": partner.NexusOnDeck, "p5": partner.NexusNowQueuing},
+	)
+
+	// Loading a match shouldn't be held up for long by Nexus being slow to respond. Wait for the queued match's lineup
+	// to have been requested in the background first so that only the request made while loading is slowed down.
+	assert.Eventually(
+		t,
+		func() bool {
+			mutex.Lock()
+			defer mutex.Unlock()
+			return slices.Contains(lineupRequests, "p5")
+		},
+		5*time.Second,
+		time.Millisecond,
+	)
+	mutex.Lock()
+	slowMatchKey = "p5"
+	mutex.Unlock()
+	startTime := time.Now()
+	assert.Nil(t, arena.LoadMatch(&matches[4]))
+	assert.Less(t, time.Since(startTime), time.Second)
+	mutex.Lock()
+	slowMatchKey = ""
+	mutex.Unlock()
+	assert.Equal(t, 101, arena.AllianceStations["R1"].Team.Id)
+	assertNexusStatuses(t, statuses, map[string]string{"p5": partner.NexusOnField})
+
+	// Qualification matches should have their queueing status reported but never have their teams substituted.
+	assert.Nil(t, arena.LoadMatch(&qualificationMatches[0]))
+	assert.Equal(t, 101, arena.AllianceStations["R1"].Team.Id)
+	assertNexusStatuses(t, statuses, map[string]string{"qm1": partner.NexusOnField, "qm2": partner.NexusOnDeck})
+	mutex.Lock()
+	assert.NotContains(t, lineupRequests, "qm1")
+	mutex.Unlock()
+
+	// Nothing from Nexus should be used when the integration is disabled.
+	arena.EventSettings.NexusEnabled = false
+	assert.Nil(t, arena.LoadMatch(&matches[1]))
+	assert.Equal(t, 101, arena.AllianceStations["R1"].Team.Id)
+}
+
+func TestArenaCheckNexusAllianceLineup(t *testing.T) {
+	arena := setupTestArena(t)
+	arena.Database.CreateTeam(&model.Team{Id: 101})
+	arena.Database.CreateTeam(&model.Team{Id: 102})
+	arena.Database.CreateTeam(&model.Team{Id: 103})
+	match := &model.Match{Type: model.Practice}
+
+	lineup, err := arena.checkNexusAllianceLineup([]int{101, 102, 103}, 0, match)
+	assert.Nil(t, err)
+	assert.Equal(t, [3]int{101, 102, 103}, lineup)
+	_, err = arena.checkNexusAllianceLineup([]int{101, 102}, 0, match)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "lineup has 2 teams but alliances have 3", err.Error())
+	}
+
+	arena.EventSettings.TwoVsTwoMode = true
+	lineup, err = arena.checkNexusAllianceLineup([]int{101, 102}, 0, match)
+	assert.Nil(t, err)
+	assert.Equal(t, [3]int{101, 102, 0}, lineup)
+	_, err = arena.checkNexusAllianceLineup([]int{101, 102, 103}, 0, match)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "lineup has 3 teams but alliances have 2", err.Error())
+	}
+}
+
+// Waits for the lineup of the given match to be fetched from Nexus in the background.
+func waitForNexusLineup(t *testing.T, arena *Arena, match model.Match) {
+	assert.Eventually(
+		t,
+		func() bool { return arena.NexusClient.GetCachedLineup(match.TbaMatchKey) != nil },
+		5*time.Second,
+		time.Millisecond,
+	)
+}
+
+func assertNexusStatuses(t *testing.T, statuses chan [2]string, expectedStatuses map[string]string) {
+	actualStatuses := make(map[string]string)
+	for range expectedStatuses {
+		select {
+		case status := <-statuses:
+			actualStatuses[status[0]] = status[1]
+		case <-time.After(5 * time.Second):
+			assert.Fail(t, "Timed out waiting for Nexus status update")
+			return
+		}
+	}
+	assert.Equal(t, expectedStatuses, actualStatuses)
+}
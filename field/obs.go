@@ -0,0 +1,48 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Arena logic for switching OBS Studio scenes and recording in response to match events.
+
+package field
+
+import (
+	"log"
+	"time"
+
+	"github.com/Team254/cheesy-arena/partner"
+)
+
+// Delay after the end of a match before OBS recording is stopped, to capture the robots coming to rest.
+const obsStopRecordingDelaySec = 10
+
+// Queues the given configured OBS actions, if OBS integration is enabled.
+func (arena *Arena) triggerObsActions(actions string) {
+	if arena.EventSettings.ObsAddress == "" || actions == "" {
+		return
+	}
+	parsedActions, err := partner.ParseObsActions(actions)
+	if err != nil {
+		log.Printf("Ignoring invalid OBS actions: %v", err)
+		return
+	}
+	arena.ObsClient.PerformActions(parsedActions)
+}
+
+// Switches OBS to the match start scene and starts recording if configured to do so.
+func (arena *Arena) handleObsMatchStart() {
+	arena.triggerObsActions(arena.EventSettings.ObsMatchStartActions)
+	if arena.EventSettings.ObsRecordingEnabled {
+		arena.ObsClient.StartRecording()
+	}
+}
+
+// Switches OBS to the match end scene and stops recording after a delay if configured to do so.
+func (arena *Arena) handleObsMatchEnd() {
+	arena.triggerObsActions(arena.EventSettings.ObsMatchEndActions)
+	if arena.EventSettings.ObsRecordingEnabled {
+		go func() {
+			time.Sleep(obsStopRecordingDelaySec * time.Second)
+			arena.ObsClient.StopRecording()
+		}()
+	}
+}
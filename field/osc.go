@@ -0,0 +1,27 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Arena logic for sending OSC cues to show control systems in response to match events.
+
+package field
+
+import (
+	"log"
+	"strings"
+
+	"github.com/Team254/cheesy-arena/partner"
+)
+
+// Sends the given configured OSC cues after substituting the "{match}" and "{sound}" placeholders.
+func (arena *Arena) sendOscCues(cues, soundName string) {
+	if arena.EventSettings.OscTargets == "" || cues == "" {
+		return
+	}
+	replacer := strings.NewReplacer("{match}", arena.CurrentMatch.ShortName, "{sound}", soundName)
+	messages, err := partner.ParseOscCues(replacer.Replace(cues))
+	if err != nil {
+		log.Printf("Ignoring invalid OSC cues: %v", err)
+		return
+	}
+	arena.OscClient.Send(messages)
+}
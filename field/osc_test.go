@@ -0,0 +1,44 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package field
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArenaOscCues(t *testing.T) {
+	arena := setupTestArena(t)
+	listener, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	assert.Nil(t, err)
+	defer listener.Close()
+	readPacket := func() string {
+		buffer := make([]byte, 1024)
+		listener.SetReadDeadline(time.Now().Add(time.Second))
+		n, err := listener.Read(buffer)
+		assert.Nil(t, err)
+		return string(buffer[:n])
+	}
+
+	arena.EventSettings.OscTargets = listener.LocalAddr().String()
+	arena.EventSettings.OscMatchAbortCues = "/cue/{match}/abort"
+	arena.EventSettings.OscSoundCues = "/sound {sound}"
+	arena.OscClient.SetTargets(arena.EventSettings.OscTargets)
+	arena.CurrentMatch.ShortName = "Q12"
+
+	arena.MatchState = AutoPeriod
+	assert.Nil(t, arena.AbortMatch())
+	assert.Equal(t, "/sound\x00\x00,s\x00\x00abort\x00\x00\x00", readPacket())
+	assert.Equal(t, "/cue/Q12/abort\x00\x00,\x00\x00\x00", readPacket())
+
+	// Nothing should be sent for sounds while match sounds are muted.
+	arena.MuteMatchSounds = true
+	arena.playSound("match_result")
+	listener.SetReadDeadline(time.Now().Add(50 * time.Millisecond))
+	_, err = listener.Read(make([]byte, 1024))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,280 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Driver for the addressable LED team number and timer signs, which are sent their content over UDP.
+
+package field
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+	"log"
+	"math"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+)
+
+const (
+	teamSignAddressPrefix       = "10.0.100."
+	teamSignPort                = 10011
+	teamSignPacketMagicString   = "CYPRX"
+	teamSignCommandSetDisplay   = 0x04
+	teamSignPacketTypeFrontText = 0x01
+	teamSignPacketTypeColor     = 0x02
+	teamSignPacketTypeRearText  = 0x03
+	teamSignPacketPeriodMs      = 5000
+)
+
+var (
+	teamSignRedColor    = color.RGBA{R: 255, A: 255}
+	teamSignBlueColor   = color.RGBA{B: 255, A: 255}
+	teamSignWhiteColor  = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	teamSignOrangeColor = color.RGBA{R: 255, G: 80, A: 255}
+)
+
+// Represents the full set of team number and timer signs around the field.
+type TeamSigns struct {
+	Red1      *TeamSign
+	Red2      *TeamSign
+	Red3      *TeamSign
+	RedTimer  *TeamSign
+	Blue1     *TeamSign
+	Blue2     *TeamSign
+	Blue3     *TeamSign
+	BlueTimer *TeamSign
+}
+
+// Represents a single team number or timer sign and the content it is currently showing.
+type TeamSign struct {
+	Name           string
+	Id             int
+	FrontText      string
+	FrontColor     color.RGBA
+	RearText       string
+	isTimer        bool
+	address        string
+	udpConn        net.Conn
+	lastPacket     []byte
+	lastPacketTime time.Time
+}
+
+func NewTeamSigns() *TeamSigns {
+	return &TeamSigns{
+		Red1:      &TeamSign{Name: "Red 1"},
+		Red2:      &TeamSign{Name: "Red 2"},
+		Red3:      &TeamSign{Name: "Red 3"},
+		RedTimer:  &TeamSign{Name: "Red Timer", isTimer: true},
+		Blue1:     &TeamSign{Name: "Blue 1"},
+		Blue2:     &TeamSign{Name: "Blue 2"},
+		Blue3:     &TeamSign{Name: "Blue 3"},
+		BlueTimer: &TeamSign{Name: "Blue Timer", isTimer: true},
+	}
+}
+
+// Returns all the signs in a consistent order.
+func (signs *TeamSigns) All() []*TeamSign {
+	return []*TeamSign{
+		signs.Red1, signs.Red2, signs.Red3, signs.RedTimer, signs.Blue1, signs.Blue2, signs.Blue3, signs.BlueTimer,
+	}
+}
+
+// Configures the IDs of all the signs from the given settings.
+func (signs *TeamSigns) SetIds(settings *model.EventSettings) {
+	signs.Red1.SetId(settings.TeamSignRed1Id)
+	signs.Red2.SetId(settings.TeamSignRed2Id)
+	signs.Red3.SetId(settings.TeamSignRed3Id)
+	signs.RedTimer.SetId(settings.TeamSignRedTimerId)
+	signs.Blue1.SetId(settings.TeamSignBlue1Id)
+	signs.Blue2.SetId(settings.TeamSignBlue2Id)
+	signs.Blue3.SetId(settings.TeamSignBlue3Id)
+	signs.BlueTimer.SetId(settings.TeamSignBlueTimerId)
+}
+
+// Updates the content of all the signs from the current arena state and sends it to any that are configured. Returns
+// true if the content of any sign has changed.
+func (signs *TeamSigns) Update(arena *Arena) bool {
+	countdown := GenerateTimerText(arena)
+	changed := false
+	changed = signs.Red1.updateTeamContent(arena, "R1", teamSignRedColor) || changed
+	changed = signs.Red2.updateTeamContent(arena, "R2", teamSignRedColor) || changed
+	changed = signs.Red3.updateTeamContent(arena, "R3", teamSignRedColor) || changed
+	changed = signs.RedTimer.updateTimerContent(arena, countdown, true) || changed
+	changed = signs.Blue1.updateTeamContent(arena, "B1", teamSignBlueColor) || changed
+	changed = signs.Blue2.updateTeamContent(arena, "B2", teamSignBlueColor) || changed
+	changed = signs.Blue3.updateTeamContent(arena, "B3", teamSignBlueColor) || changed
+	changed = signs.BlueTimer.updateTimerContent(arena, countdown, false) || changed
+
+	for _, sign := range signs.All() {
+		if err := sign.sendPacketIfNeeded(); err != nil {
+			log.Printf("Failed to send packet to team sign %d: %v", sign.Id, err)
+		}
+	}
+	return changed
+}
+
+// Sets the sign's ID, which determines its IP address. An ID of zero disables output to the sign.
+func (sign *TeamSign) SetId(id int) {
+	address := ""
+	if id > 0 {
+		address = net.JoinHostPort(teamSignAddressPrefix+strconv.Itoa(id), strconv.Itoa(teamSignPort))
+	}
+	sign.Id = id
+	sign.setAddress(address)
+}
+
+func (sign *TeamSign) setAddress(address string) {
+	if address == sign.address {
+		return
+	}
+	if sign.udpConn != nil {
+		sign.udpConn.Close()
+		sign.udpConn = nil
+	}
+	sign.address = address
+	sign.lastPacket = nil
+}
+
+// Sets the content of a team number sign for the given alliance station. Returns true if it has changed.
+func (sign *TeamSign) updateTeamContent(arena *Arena, station string, allianceColor color.RGBA) bool {
+	allianceStation := arena.AllianceStations[station]
+	frontText := ""
+	frontColor := allianceColor
+	rearText := ""
+	if allianceStation.Team != nil {
+		frontText = strconv.Itoa(allianceStation.Team.Id)
+		rearText = fmt.Sprintf("%-5d %s", allianceStation.Team.Id, generateStationStatusText(allianceStation))
+	}
+	if allianceStation.EStop || allianceStation.AStop {
+		frontColor = teamSignOrangeColor
+	}
+	return sign.setContent(frontText, frontColor, rearText)
+}
+
+// Sets the content of a timer sign for the given alliance. Returns true if it has changed.
+func (sign *TeamSign) updateTimerContent(arena *Arena, countdown string, isRed bool) bool {
+	allianceColor := teamSignBlueColor
+	if isRed {
+		allianceColor = teamSignRedColor
+	}
+	redScore := arena.RedScoreSummary().Score
+	blueScore := arena.BlueScoreSummary().Score
+
+	switch arena.MatchState {
+	case PreMatch, PostMatch:
+		if arena.AudienceDisplayMode == "score" {
+			// Show the final score posted for the last match until the next one starts.
+			redScore = arena.SavedMatchResult.RedScoreSummary().Score
+			blueScore = arena.SavedMatchResult.BlueScoreSummary().Score
+			score := blueScore
+			if isRed {
+				score = redScore
+			}
+			return sign.setContent(
+				strconv.Itoa(score),
+				allianceColor,
+				fmt.Sprintf("%s Final R%d-B%d", arena.SavedMatch.ShortName, redScore, blueScore),
+			)
+		}
+		return sign.setContent("", teamSignWhiteColor, arena.CurrentMatch.ShortName)
+	default:
+		return sign.setContent(
+			countdown,
+			teamSignWhiteColor,
+			fmt.Sprintf("%s R%d-B%d", arena.CurrentMatch.ShortName, redScore, blueScore),
+		)
+	}
+}
+
+// Sets the given content on the sign and returns true if it differs from the previous content.
+func (sign *TeamSign) setContent(frontText string, frontColor color.RGBA, rearText string) bool {
+	if frontText == sign.FrontText && frontColor == sign.FrontColor && rearText == sign.RearText {
+		return false
+	}
+	sign.FrontText = frontText
+	sign.FrontColor = frontColor
+	sign.RearText = rearText
+	return true
+}
+
+// Sends the current content to the sign if it has changed or if it has been long enough since the last packet.
+func (sign *TeamSign) sendPacketIfNeeded() error {
+	if sign.address == "" {
+		return nil
+	}
+	packet := sign.generatePacket()
+	if bytes.Equal(packet, sign.lastPacket) && time.Since(sign.lastPacketTime).Milliseconds() < teamSignPacketPeriodMs {
+		return nil
+	}
+
+	if sign.udpConn == nil {
+		conn, err := net.Dial("udp4", sign.address)
+		if err != nil {
+			return err
+		}
+		sign.udpConn = conn
+	}
+	sign.lastPacket = packet
+	sign.lastPacketTime = time.Now()
+	_, err := sign.udpConn.Write(packet)
+	return err
+}
+
+// Serializes the sign's current content into a packet. Text fields are null-terminated and the color is given as
+// RGB bytes.
+func (sign *TeamSign) generatePacket() []byte {
+	var packet bytes.Buffer
+	packet.WriteString(teamSignPacketMagicString)
+	packet.WriteByte(teamSignCommandSetDisplay)
+	packet.WriteByte(byte(sign.Id))
+	packet.WriteByte(teamSignPacketTypeFrontText)
+	packet.WriteString(sign.FrontText)
+	packet.WriteByte(0)
+	packet.WriteByte(teamSignPacketTypeColor)
+	packet.Write([]byte{sign.FrontColor.R, sign.FrontColor.G, sign.FrontColor.B})
+	packet.WriteByte(teamSignPacketTypeRearText)
+	packet.WriteString(sign.RearText)
+	packet.WriteByte(0)
+	return packet.Bytes()
+}
+
+// Returns a short description of the given alliance station's readiness for the back of its team sign.
+func generateStationStatusText(allianceStation *AllianceStation) string {
+	switch {
+	case allianceStation.EStop:
+		return "E-STOP"
+	case allianceStation.AStop:
+		return "A-STOP"
+	case allianceStation.Bypass:
+		return "Bypassed"
+	case allianceStation.DsConn == nil || !allianceStation.DsConn.DsLinked:
+		return "No DS"
+	case !allianceStation.DsConn.RobotLinked:
+		return "No Robot"
+	default:
+		return "Ready"
+	}
+}
+
+// Returns the time remaining in the current match period or timeout, formatted as minutes and seconds.
+func GenerateTimerText(arena *Arena) string {
+	var remainingSec float64
+	switch arena.MatchState {
+	case PreMatch, StartMatch, WarmupPeriod:
+		remainingSec = float64(game.MatchTiming.AutoDurationSec)
+	case AutoPeriod:
+		remainingSec = game.GetDurationToAutoEnd().Seconds() - arena.MatchTimeSec()
+	case PausePeriod:
+		remainingSec = float64(game.MatchTiming.TeleopDurationSec)
+	case TeleopPeriod:
+		remainingSec = game.GetDurationToTeleopEnd().Seconds() - arena.MatchTimeSec()
+	case TimeoutActive:
+		remainingSec = float64(game.MatchTiming.TimeoutDurationSec) - arena.MatchTimeSec()
+	}
+	seconds := int(math.Max(math.Ceil(remainingSec), 0))
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
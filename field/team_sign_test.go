@@ -0,0 +1,169 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package field
+
+import (
+	"bytes"
+	"image/color"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// Captures the packets sent to a team sign by pointing it at a local UDP listener.
+type teamSignCapture struct {
+	listener *net.UDPConn
+}
+
+type teamSignPacket struct {
+	id         byte
+	frontText  string
+	frontColor color.RGBA
+	rearText   string
+}
+
+func captureTeamSign(t *testing.T, sign *TeamSign) *teamSignCapture {
+	listener, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	assert.Nil(t, err)
+	t.Cleanup(func() { listener.Close() })
+	sign.setAddress(listener.LocalAddr().String())
+	return &teamSignCapture{listener: listener}
+}
+
+// Returns the next packet received by the sign, or nil if none arrives within the given timeout.
+func (capture *teamSignCapture) read(t *testing.T, timeout time.Duration) *teamSignPacket {
+	buffer := make([]byte, 256)
+	capture.listener.SetReadDeadline(time.Now().Add(timeout))
+	n, err := capture.listener.Read(buffer)
+	if err != nil {
+		return nil
+	}
+	packet := buffer[:n]
+	if !assert.True(t, bytes.HasPrefix(packet, []byte(teamSignPacketMagicString))) {
+		return nil
+	}
+	assert.Equal(t, byte(teamSignCommandSetDisplay), packet[5])
+	decoded := teamSignPacket{id: packet[6]}
+	fields := packet[7:]
+	assert.Equal(t, byte(teamSignPacketTypeFrontText), fields[0])
+	frontText, fields, _ := bytes.Cut(fields[1:], []byte{0})
+	decoded.frontText = string(frontText)
+	assert.Equal(t, byte(teamSignPacketTypeColor), fields[0])
+	decoded.frontColor = color.RGBA{R: fields[1], G: fields[2], B: fields[3], A: 255}
+	assert.Equal(t, byte(teamSignPacketTypeRearText), fields[4])
+	rearText, _, _ := bytes.Cut(fields[5:], []byte{0})
+	decoded.rearText = string(rearText)
+	return &decoded
+}
+
+func TestTeamSignSetIds(t *testing.T) {
+	signs := NewTeamSigns()
+	signs.SetIds(&model.EventSettings{TeamSignRed1Id: 51, TeamSignBlueTimerId: 60})
+	assert.Equal(t, 51, signs.Red1.Id)
+	assert.Equal(t, "10.0.100.51:10011", signs.Red1.address)
+	assert.Equal(t, "10.0.100.60:10011", signs.BlueTimer.address)
+	assert.Equal(t, "", signs.Red2.address)
+
+	// Signs without an ID shouldn't be sent anything.
+	assert.Nil(t, signs.Red2.sendPacketIfNeeded())
+	assert.Nil(t, signs.Red2.udpConn)
+}
+
+func TestTeamSignTeamContent(t *testing.T) {
+	arena := setupTestArena(t)
+	arena.Database.CreateTeam(&model.Team{Id: 254})
+	assert.Nil(t, arena.assignTeam(254, "R1"))
+	arena.TeamSigns.Red1.Id = 51
+	capture := captureTeamSign(t, arena.TeamSigns.Red1)
+
+	assert.True(t, arena.TeamSigns.Update(arena))
+	packet := capture.read(t, time.Second)
+	if assert.NotNil(t, packet) {
+		assert.Equal(t, teamSignPacket{51, "254", teamSignRedColor, "254   No DS"}, *packet)
+	}
+
+	// Nothing should be resent until something changes or the keepalive period elapses.
+	assert.False(t, arena.TeamSigns.Update(arena))
+	assert.Nil(t, capture.read(t, 20*time.Millisecond))
+
+	arena.AllianceStations["R1"].DsConn = &DriverStationConnection{TeamId: 254, DsLinked: true}
+	arena.TeamSigns.Update(arena)
+	assert.Equal(t, "254   No Robot", capture.read(t, time.Second).rearText)
+	arena.AllianceStations["R1"].DsConn.RobotLinked = true
+	arena.TeamSigns.Update(arena)
+	assert.Equal(t, "254   Ready", capture.read(t, time.Second).rearText)
+
+	arena.AllianceStations["R1"].AStop = true
+	arena.TeamSigns.Update(arena)
+	packet = capture.read(t, time.Second)
+	assert.Equal(t, teamSignPacket{51, "254", teamSignOrangeColor, "254   A-STOP"}, *packet)
+	arena.AllianceStations["R1"].EStop = true
+	arena.TeamSigns.Update(arena)
+	assert.Equal(t, "254   E-STOP", capture.read(t, time.Second).rearText)
+
+	arena.TeamSigns.Red1.lastPacketTime = time.Now().Add(-teamSignPacketPeriodMs * time.Millisecond)
+	arena.TeamSigns.Update(arena)
+	assert.NotNil(t, capture.read(t, time.Second))
+
+	// An empty station should blank the sign.
+	arena.AllianceStations["R1"].Team = nil
+	arena.TeamSigns.Update(arena)
+	packet = capture.read(t, time.Second)
+	assert.Equal(t, "", packet.frontText)
+	assert.Equal(t, "", packet.rearText)
+}
+
+func TestTeamSignTimerContent(t *testing.T) {
+	arena := setupTestArena(t)
+	arena.CurrentMatch.ShortName = "Q12"
+	redCapture := captureTeamSign(t, arena.TeamSigns.RedTimer)
+	blueCapture := captureTeamSign(t, arena.TeamSigns.BlueTimer)
+
+	arena.TeamSigns.Update(arena)
+	assert.Equal(t, teamSignPacket{0, "", teamSignWhiteColor, "Q12"}, *redCapture.read(t, time.Second))
+	assert.Equal(t, teamSignPacket{0, "", teamSignWhiteColor, "Q12"}, *blueCapture.read(t, time.Second))
+
+	arena.MatchState = TeleopPeriod
+	arena.MatchStartTime = time.Now().Add(-game.GetDurationToTeleopEnd() + 65500*time.Millisecond)
+	arena.RedRealtimeScore.CurrentScore.Mayhem.LeaveStatuses = [3]bool{true, true, false}
+	arena.TeamSigns.Update(arena)
+	redScore := arena.RedScoreSummary().Score
+	packet := redCapture.read(t, time.Second)
+	assert.Equal(t, "1:06", packet.frontText)
+	assert.Equal(t, teamSignWhiteColor, packet.frontColor)
+	assert.Contains(t, packet.rearText, "Q12 R")
+	assert.Equal(t, "1:06", blueCapture.read(t, time.Second).frontText)
+
+	arena.MatchState = PostMatch
+	arena.AudienceDisplayMode = "score"
+	arena.SavedMatch = &model.Match{ShortName: "Q12"}
+	arena.SavedMatchResult = &model.MatchResult{
+		RedScore: &arena.RedRealtimeScore.CurrentScore, BlueScore: &arena.BlueRealtimeScore.CurrentScore,
+	}
+	arena.TeamSigns.Update(arena)
+	packet = redCapture.read(t, time.Second)
+	assert.Equal(t, teamSignRedColor, packet.frontColor)
+	assert.Equal(t, strconv.Itoa(redScore), packet.frontText)
+	packet = blueCapture.read(t, time.Second)
+	assert.Equal(t, teamSignBlueColor, packet.frontColor)
+	assert.Equal(t, "0", packet.frontText)
+}
+
+func TestGenerateTimerText(t *testing.T) {
+	arena := setupTestArena(t)
+	assert.Equal(t, "0:15", GenerateTimerText(arena))
+	arena.MatchState = AutoPeriod
+	arena.MatchStartTime = time.Now().Add(-game.GetDurationToAutoEnd() + 9500*time.Millisecond)
+	assert.Equal(t, "0:10", GenerateTimerText(arena))
+	arena.MatchState = PausePeriod
+	assert.Equal(t, "2:15", GenerateTimerText(arena))
+	arena.MatchState = TeleopPeriod
+	arena.MatchStartTime = time.Now().Add(-game.GetDurationToTeleopEnd() - time.Second)
+	assert.Equal(t, "0:00", GenerateTimerText(arena))
+}
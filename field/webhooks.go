@@ -0,0 +1,20 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Arena logic for notifying external webhook subscribers of arena and tournament events.
+
+package field
+
+import (
+	"log"
+)
+
+// Delivers the given event and data to all webhook subscriptions for that event.
+func (arena *Arena) TriggerWebhook(event string, data any) {
+	subscriptions, err := arena.Database.GetWebhookSubscriptionsForEvent(event)
+	if err != nil {
+		log.Printf("Failed to get webhook subscriptions: %v", err)
+		return
+	}
+	arena.WebhookClient.Deliver(subscriptions, event, data)
+}
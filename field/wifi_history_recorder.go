@@ -0,0 +1,64 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Functions for recording the access point's per-station Wi-Fi statistics over the course of a match.
+
+package field
+
+import (
+	"log"
+	"time"
+
+	"github.com/Team254/cheesy-arena/model"
+)
+
+var wifiHistoryStations = []string{"R1", "R2", "R3", "B1", "B2", "B3"}
+
+// Begins a new set of Wi-Fi histories for the teams in the current match.
+func (arena *Arena) startWifiHistories() {
+	arena.wifiHistories = nil
+	if !arena.EventSettings.NetworkSecurityEnabled || arena.CurrentMatch.Type == model.Test {
+		// The access point isn't being polled or the match won't be saved, so there is nothing meaningful to record.
+		return
+	}
+
+	startedAt := time.Now()
+	for _, station := range wifiHistoryStations {
+		if team := arena.AllianceStations[station].Team; team != nil {
+			arena.wifiHistories = append(
+				arena.wifiHistories,
+				&model.WifiHistory{
+					MatchId: arena.CurrentMatch.Id, TeamId: team.Id, Station: station, StartedAt: startedAt,
+				},
+			)
+		}
+	}
+}
+
+// Appends the current Wi-Fi statistics for each station to the in-progress histories.
+func (arena *Arena) recordWifiSamples(matchTimeSec float64) {
+	for _, wifiHistory := range arena.wifiHistories {
+		wifiStatus := arena.AllianceStations[wifiHistory.Station].WifiStatus
+		sample := model.WifiSample{MatchTimeSec: matchTimeSec}
+		if wifiStatus.TeamId == wifiHistory.TeamId {
+			// Ignore the statistics if the access point isn't (yet) configured for this team.
+			sample.RadioLinked = wifiStatus.RadioLinked
+			sample.MBits = wifiStatus.MBits
+			sample.RxRate = wifiStatus.RxRate
+			sample.TxRate = wifiStatus.TxRate
+			sample.SignalNoiseRatio = wifiStatus.SignalNoiseRatio
+			sample.ConnectionQuality = wifiStatus.ConnectionQuality
+		}
+		wifiHistory.Samples = append(wifiHistory.Samples, sample)
+	}
+}
+
+// Persists the in-progress Wi-Fi histories to the database, linked to the current match.
+func (arena *Arena) saveWifiHistories() {
+	for _, wifiHistory := range arena.wifiHistories {
+		if err := arena.Database.CreateWifiHistory(wifiHistory); err != nil {
+			log.Printf("Failed to save Wi-Fi history for Team %d: %v", wifiHistory.TeamId, err)
+		}
+	}
+	arena.wifiHistories = nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package field
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/network"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArenaRecordsWifiHistories(t *testing.T) {
+	arena := setupTestArena(t)
+
+	assert.Nil(t, arena.Database.CreateTeam(&model.Team{Id: 254}))
+	assert.Nil(t, arena.Database.CreateTeam(&model.Team{Id: 1114}))
+	match := model.Match{Type: model.Qualification, ShortName: "Q1", Red1: 254, Blue3: 1114}
+	assert.Nil(t, arena.Database.CreateMatch(&match))
+	assert.Nil(t, arena.LoadMatch(&match))
+	arena.EventSettings.NetworkSecurityEnabled = true
+	for _, allianceStation := range arena.AllianceStations {
+		allianceStation.Bypass = true
+	}
+
+	assert.Nil(t, arena.StartMatch())
+	arena.Update()
+	assert.Equal(t, WarmupPeriod, arena.MatchState)
+	arena.AllianceStations["R1"].WifiStatus = network.TeamWifiStatus{
+		TeamId: 254, RadioLinked: true, MBits: 1.5, RxRate: 800, TxRate: 700, SignalNoiseRatio: 42, ConnectionQuality: 4,
+	}
+	arena.AllianceStations["B3"].WifiStatus = network.TeamWifiStatus{TeamId: 1503, RadioLinked: true}
+	arena.MatchStartTime = time.Now().Add(-4 * time.Second)
+	arena.Update()
+	assert.Equal(t, AutoPeriod, arena.MatchState)
+
+	// Nothing should be saved until the match is over.
+	wifiHistories, err := arena.Database.GetWifiHistoriesForMatch(match.Id)
+	assert.Nil(t, err)
+	assert.Empty(t, wifiHistories)
+	assert.Nil(t, arena.AbortMatch())
+	arena.Update()
+
+	wifiHistories, err = arena.Database.GetWifiHistoriesForMatch(match.Id)
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(wifiHistories)) {
+		assert.Equal(t, 254, wifiHistories[0].TeamId)
+		assert.Equal(t, "R1", wifiHistories[0].Station)
+		if assert.Equal(t, 2, len(wifiHistories[0].Samples)) {
+			assert.Equal(t, model.WifiSample{}, wifiHistories[0].Samples[0])
+			assert.Equal(
+				t,
+				model.WifiSample{
+					MatchTimeSec:      4,
+					RadioLinked:       true,
+					MBits:             1.5,
+					RxRate:            800,
+					TxRate:            700,
+					SignalNoiseRatio:  42,
+					ConnectionQuality: 4,
+				},
+				roundSampleTime(wifiHistories[0].Samples[1]),
+			)
+		}
+		assert.Equal(t, 1114, wifiHistories[1].TeamId)
+		assert.Equal(t, "B3", wifiHistories[1].Station)
+		if assert.Equal(t, 2, len(wifiHistories[1].Samples)) {
+			// The access point is reporting a different team for this station, so its statistics should be ignored.
+			assert.False(t, wifiHistories[1].Samples[1].RadioLinked)
+		}
+	}
+
+	// Test matches and matches without network security should not be recorded.
+	for _, networkSecurityEnabled := range []bool{true, false} {
+		assert.Nil(t, arena.ResetMatch())
+		arena.EventSettings.NetworkSecurityEnabled = false
+		assert.Nil(t, arena.LoadTestMatch())
+		if !networkSecurityEnabled {
+			assert.Nil(t, arena.LoadMatch(&match))
+		}
+		arena.EventSettings.NetworkSecurityEnabled = networkSecurityEnabled
+		for _, allianceStation := range arena.AllianceStations {
+			allianceStation.Bypass = true
+		}
+		assert.Nil(t, arena.StartMatch())
+		arena.Update()
+		assert.Empty(t, arena.wifiHistories)
+		assert.Nil(t, arena.AbortMatch())
+		arena.Update()
+	}
+	wifiHistories, err = arena.Database.GetAllWifiHistories()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(wifiHistories))
+}
+
+func roundSampleTime(sample model.WifiSample) model.WifiSample {
+	sample.MatchTimeSec = float64(int(sample.MatchTimeSec))
+	return sample
+}
@@ -0,0 +1,362 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Methods for driving DMX field lighting over the network using either E1.31 (sACN) or Art-Net.
+
+package lighting
+
+import (
+	"encoding/binary"
+	"fmt"
+	"log"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/google/uuid"
+)
+
+const (
+	sacnPort             = 5568
+	artNetPort           = 6454
+	dmxUniverseSize      = 512
+	dmxLoopPeriodMs      = 100
+	cueStepDurationMs    = 250
+	sacnSourceName       = "Cheesy Arena"
+	sacnPriority         = 100
+	sacnPacketSize       = 126 + dmxUniverseSize
+	artNetPacketSize     = 18 + dmxUniverseSize
+	artNetProtocolVer    = 14
+	artNetOpDmx          = 0x5000
+	cueStepSeparator     = "|"
+	cueChannelSeparator  = "="
+	cueUniverseSeparator = ":"
+	sacnMinUniverse      = 1
+	sacnMaxUniverse      = 63999
+	artNetMaxUniverse    = 32767
+)
+
+// A lighting cue, consisting of one or more steps that are cycled through in sequence.
+type Cue []CueStep
+
+// One step of a lighting cue, consisting of a full frame for each universe that the step sets channels in.
+type CueStep map[int]*[dmxUniverseSize]byte
+
+type DmxLights struct {
+	protocol        model.LightingProtocol
+	address         string
+	universes       []int
+	cues            map[string]Cue
+	currentCue      string
+	cueStartTime    time.Time
+	conns           map[int]net.Conn
+	sequenceNumbers map[int]byte
+	sourceId        uuid.UUID
+	mutex           sync.Mutex
+}
+
+func NewDmxLights() *DmxLights {
+	return &DmxLights{
+		cues:            make(map[string]Cue),
+		conns:           make(map[int]net.Conn),
+		sequenceNumbers: make(map[int]byte),
+		sourceId:        uuid.New(),
+	}
+}
+
+// Sets the output protocol, destination address (with optional port), default universe and named cue definitions.
+// Output is sent for the default universe and for any other universe that a cue sets channels in, so that fixture
+// groups on different universes can be driven together. Invalid cues are logged and treated as blackouts. For sACN, an
+// empty address results in multicast output to each universe's group.
+func (lights *DmxLights) SetSettings(
+	protocol model.LightingProtocol, address string, universe int, cues map[string]string,
+) {
+	lights.mutex.Lock()
+	defer lights.mutex.Unlock()
+	if protocol != lights.protocol || address != lights.address {
+		lights.closeConns()
+	}
+	lights.protocol = protocol
+	lights.address = address
+	lights.universes = []int{universe}
+	lights.cues = make(map[string]Cue)
+	for name, cueString := range cues {
+		cue, err := ParseCue(cueString, universe)
+		if err == nil {
+			for _, cueUniverse := range cue.Universes() {
+				if err = CheckUniverse(protocol, cueUniverse); err != nil {
+					break
+				}
+			}
+		}
+		if err != nil {
+			log.Printf("Ignoring invalid lighting cue '%s': %v", name, err)
+			continue
+		}
+		lights.cues[name] = cue
+		for _, cueUniverse := range cue.Universes() {
+			if !slices.Contains(lights.universes, cueUniverse) {
+				lights.universes = append(lights.universes, cueUniverse)
+			}
+		}
+	}
+	slices.Sort(lights.universes)
+	for universe, conn := range lights.conns {
+		if !slices.Contains(lights.universes, universe) {
+			conn.Close()
+			delete(lights.conns, universe)
+		}
+	}
+}
+
+// Returns an error if the given universe number isn't valid for the given protocol.
+func CheckUniverse(protocol model.LightingProtocol, universe int) error {
+	switch protocol {
+	case model.SacnLighting:
+		if universe < sacnMinUniverse || universe > sacnMaxUniverse {
+			return fmt.Errorf("sACN universe must be between %d and %d", sacnMinUniverse, sacnMaxUniverse)
+		}
+	case model.ArtNetLighting:
+		if universe < 0 || universe > artNetMaxUniverse {
+			return fmt.Errorf("Art-Net universe must be between 0 and %d", artNetMaxUniverse)
+		}
+	}
+	return nil
+}
+
+// Switches the output to the given named cue, restarting its sequence if it is different from the current one.
+func (lights *DmxLights) SetCue(name string) {
+	lights.mutex.Lock()
+	defer lights.mutex.Unlock()
+	if name != lights.currentCue {
+		lights.currentCue = name
+		lights.cueStartTime = time.Now()
+	}
+}
+
+// Returns the name of the cue currently being output.
+func (lights *DmxLights) GetCue() string {
+	lights.mutex.Lock()
+	defer lights.mutex.Unlock()
+	return lights.currentCue
+}
+
+// Loops indefinitely to send the current frame, so that receivers don't time out and sequences advance.
+func (lights *DmxLights) Run() {
+	for {
+		if err := lights.sendFrame(); err != nil {
+			log.Printf("Failed to send lighting output: %v", err)
+			time.Sleep(time.Second)
+		}
+		time.Sleep(dmxLoopPeriodMs * time.Millisecond)
+	}
+}
+
+// Parses the given cue definition, in which steps are separated by "|" and each step is a list of "channel=value" or
+// "first-last=value" assignments separated by commas or spaces. An assignment may be prefixed with "universe:" to set
+// channels in a universe other than the given default one. Channels not assigned in a step are set to zero.
+func ParseCue(cueString string, defaultUniverse int) (Cue, error) {
+	var cue Cue
+	if strings.TrimSpace(cueString) == "" {
+		return cue, nil
+	}
+	for _, stepString := range strings.Split(cueString, cueStepSeparator) {
+		step := CueStep{defaultUniverse: new([dmxUniverseSize]byte)}
+		assignments := strings.FieldsFunc(stepString, func(r rune) bool { return r == ',' || r == ' ' })
+		for _, assignment := range assignments {
+			universe := defaultUniverse
+			universeString, channelAssignment, hasUniverse := strings.Cut(assignment, cueUniverseSeparator)
+			if hasUniverse {
+				var err error
+				if universe, err = strconv.Atoi(universeString); err != nil || universe < 0 {
+					return nil, fmt.Errorf("invalid universe %q in assignment %q", universeString, assignment)
+				}
+				if step[universe] == nil {
+					step[universe] = new([dmxUniverseSize]byte)
+				}
+			} else {
+				channelAssignment = assignment
+			}
+			channels, valueString, found := strings.Cut(channelAssignment, cueChannelSeparator)
+			if !found {
+				return nil, fmt.Errorf("invalid assignment %q; expected channel=value", assignment)
+			}
+			value, err := strconv.Atoi(valueString)
+			if err != nil || value < 0 || value > 255 {
+				return nil, fmt.Errorf(
+					"invalid value %q for channel %s; must be between 0 and 255", valueString, channels,
+				)
+			}
+			firstString, lastString, isRange := strings.Cut(channels, "-")
+			if !isRange {
+				lastString = firstString
+			}
+			first, err := parseChannel(firstString)
+			if err != nil {
+				return nil, err
+			}
+			last, err := parseChannel(lastString)
+			if err != nil {
+				return nil, err
+			}
+			if last < first {
+				return nil, fmt.Errorf("invalid channel range %q", channels)
+			}
+			for channel := first; channel <= last; channel++ {
+				step[universe][channel-1] = byte(value)
+			}
+		}
+		cue = append(cue, step)
+	}
+	return cue, nil
+}
+
+// Returns the sorted list of universes that the cue sets channels in.
+func (cue Cue) Universes() []int {
+	var universes []int
+	for _, step := range cue {
+		for universe := range step {
+			if !slices.Contains(universes, universe) {
+				universes = append(universes, universe)
+			}
+		}
+	}
+	slices.Sort(universes)
+	return universes
+}
+
+// Returns the frame of the current cue that should be output to the given universe at the given time.
+func (lights *DmxLights) currentFrame(universe int, now time.Time) [dmxUniverseSize]byte {
+	cue := lights.cues[lights.currentCue]
+	if len(cue) == 0 {
+		return [dmxUniverseSize]byte{}
+	}
+	step := int(now.Sub(lights.cueStartTime).Milliseconds()/cueStepDurationMs) % len(cue)
+	if frame := cue[step][universe]; frame != nil {
+		return *frame
+	}
+	return [dmxUniverseSize]byte{}
+}
+
+// Sends the current frame for each universe to the configured destination using the configured protocol.
+func (lights *DmxLights) sendFrame() error {
+	lights.mutex.Lock()
+	defer lights.mutex.Unlock()
+	if lights.protocol == model.NoLighting {
+		return nil
+	}
+
+	now := time.Now()
+	for _, universe := range lights.universes {
+		conn, ok := lights.conns[universe]
+		if !ok {
+			address, err := lights.destinationAddress(universe)
+			if err != nil {
+				return err
+			}
+			if conn, err = net.Dial("udp", address); err != nil {
+				return err
+			}
+			lights.conns[universe] = conn
+		}
+
+		frame := lights.currentFrame(universe, now)
+		sequenceNumber := lights.sequenceNumbers[universe]
+		var packet []byte
+		if lights.protocol == model.SacnLighting {
+			packet = buildSacnPacket(universe, sequenceNumber, lights.sourceId, frame)
+		} else {
+			packet = buildArtNetPacket(universe, sequenceNumber, frame)
+		}
+		lights.sequenceNumbers[universe] = sequenceNumber + 1
+		if _, err := conn.Write(packet); err != nil {
+			conn.Close()
+			delete(lights.conns, universe)
+			return err
+		}
+	}
+	return nil
+}
+
+// Closes the connections for all universes. Must be called with the mutex held.
+func (lights *DmxLights) closeConns() {
+	for universe, conn := range lights.conns {
+		conn.Close()
+		delete(lights.conns, universe)
+	}
+}
+
+// Returns the host and port to send packets for the given universe to, defaulting to the protocol's standard port and,
+// for sACN, to the universe's multicast group. Must be called with the mutex held.
+func (lights *DmxLights) destinationAddress(universe int) (string, error) {
+	port := artNetPort
+	if lights.protocol == model.SacnLighting {
+		port = sacnPort
+	}
+	address := lights.address
+	if address == "" {
+		if lights.protocol != model.SacnLighting {
+			return "", fmt.Errorf("an address is required for Art-Net output")
+		}
+		address = fmt.Sprintf("239.255.%d.%d", (universe>>8)&0xff, universe&0xff)
+	}
+	if _, _, err := net.SplitHostPort(address); err == nil {
+		return address, nil
+	}
+	return net.JoinHostPort(address, strconv.Itoa(port)), nil
+}
+
+// Builds an E1.31 data packet carrying the given frame.
+func buildSacnPacket(universe int, sequenceNumber byte, sourceId uuid.UUID, frame [dmxUniverseSize]byte) []byte {
+	packet := make([]byte, sacnPacketSize)
+
+	// Root layer.
+	binary.BigEndian.PutUint16(packet[0:], 0x0010)
+	copy(packet[4:], "ASC-E1.17")
+	binary.BigEndian.PutUint16(packet[16:], 0x7000|uint16(sacnPacketSize-16))
+	binary.BigEndian.PutUint32(packet[18:], 0x00000004)
+	copy(packet[22:38], sourceId[:])
+
+	// Framing layer.
+	binary.BigEndian.PutUint16(packet[38:], 0x7000|uint16(sacnPacketSize-38))
+	binary.BigEndian.PutUint32(packet[40:], 0x00000002)
+	copy(packet[44:108], sacnSourceName)
+	packet[108] = sacnPriority
+	packet[111] = sequenceNumber
+	binary.BigEndian.PutUint16(packet[113:], uint16(universe))
+
+	// DMP layer.
+	binary.BigEndian.PutUint16(packet[115:], 0x7000|uint16(sacnPacketSize-115))
+	packet[117] = 0x02
+	packet[118] = 0xa1
+	binary.BigEndian.PutUint16(packet[121:], 0x0001)
+	binary.BigEndian.PutUint16(packet[123:], dmxUniverseSize+1)
+	copy(packet[126:], frame[:])
+	return packet
+}
+
+// Builds an ArtDmx packet carrying the given frame.
+func buildArtNetPacket(universe int, sequenceNumber byte, frame [dmxUniverseSize]byte) []byte {
+	packet := make([]byte, artNetPacketSize)
+	copy(packet[0:8], "Art-Net\x00")
+	binary.LittleEndian.PutUint16(packet[8:], artNetOpDmx)
+	binary.BigEndian.PutUint16(packet[10:], artNetProtocolVer)
+	packet[12] = sequenceNumber
+	packet[14] = byte(universe & 0xff)
+	packet[15] = byte((universe >> 8) & 0x7f)
+	binary.BigEndian.PutUint16(packet[16:], dmxUniverseSize)
+	copy(packet[18:], frame[:])
+	return packet
+}
+
+func parseChannel(channelString string) (int, error) {
+	channel, err := strconv.Atoi(channelString)
+	if err != nil || channel < 1 || channel > dmxUniverseSize {
+		return 0, fmt.Errorf("invalid channel %q; must be between 1 and %d", channelString, dmxUniverseSize)
+	}
+	return channel, nil
+}
@@ -0,0 +1,222 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package lighting
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCue(t *testing.T) {
+	cue, err := ParseCue("", 1)
+	assert.Nil(t, err)
+	assert.Empty(t, cue)
+
+	cue, err = ParseCue("1=255, 3-5=128 512=1", 1)
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(cue)) {
+		assert.Equal(t, []byte{255, 0, 128, 128, 128, 0}, cue[0][1][0:6])
+		assert.Equal(t, byte(1), cue[0][1][511])
+	}
+	assert.Equal(t, []int{1}, cue.Universes())
+
+	cue, err = ParseCue("1=255|2=255|", 7)
+	assert.Nil(t, err)
+	if assert.Equal(t, 3, len(cue)) {
+		assert.Equal(t, []byte{255, 0}, cue[0][7][0:2])
+		assert.Equal(t, []byte{0, 255}, cue[1][7][0:2])
+		assert.Equal(t, [dmxUniverseSize]byte{}, *cue[2][7])
+	}
+
+	// Assignments prefixed with a universe should apply to that universe instead of the default one.
+	cue, err = ParseCue("1=255 3:1-2=9,3:4=1|4:512=8", 1)
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(cue)) {
+		assert.Equal(t, []byte{255, 0}, cue[0][1][0:2])
+		assert.Equal(t, []byte{9, 9, 0, 1}, cue[0][3][0:4])
+		assert.Nil(t, cue[0][4])
+		assert.Equal(t, [dmxUniverseSize]byte{}, *cue[1][1])
+		assert.Equal(t, byte(8), cue[1][4][511])
+	}
+	assert.Equal(t, []int{1, 3, 4}, cue.Universes())
+
+	_, err = ParseCue("1", 1)
+	assert.EqualError(t, err, "invalid assignment \"1\"; expected channel=value")
+	_, err = ParseCue("1=256", 1)
+	assert.EqualError(t, err, "invalid value \"256\" for channel 1; must be between 0 and 255")
+	_, err = ParseCue("0=1", 1)
+	assert.EqualError(t, err, "invalid channel \"0\"; must be between 1 and 512")
+	_, err = ParseCue("5-513=1", 1)
+	assert.EqualError(t, err, "invalid channel \"513\"; must be between 1 and 512")
+	_, err = ParseCue("5-3=1", 1)
+	assert.EqualError(t, err, "invalid channel range \"5-3\"")
+	_, err = ParseCue("a:1=1", 1)
+	assert.EqualError(t, err, "invalid universe \"a\" in assignment \"a:1=1\"")
+	_, err = ParseCue("2:1", 1)
+	assert.EqualError(t, err, "invalid assignment \"2:1\"; expected channel=value")
+}
+
+func TestCheckUniverse(t *testing.T) {
+	assert.Nil(t, CheckUniverse(model.SacnLighting, 1))
+	assert.Nil(t, CheckUniverse(model.SacnLighting, 63999))
+	assert.EqualError(t, CheckUniverse(model.SacnLighting, 0), "sACN universe must be between 1 and 63999")
+	assert.Nil(t, CheckUniverse(model.ArtNetLighting, 0))
+	assert.EqualError(t, CheckUniverse(model.ArtNetLighting, 32768), "Art-Net universe must be between 0 and 32767")
+	assert.Nil(t, CheckUniverse(model.NoLighting, 100000))
+}
+
+func TestDmxLightsCueSequence(t *testing.T) {
+	lights := NewDmxLights()
+	lights.SetSettings(model.SacnLighting, "", 1, map[string]string{"solid": "1=10", "flash": "1=255|1=0|2=255"})
+
+	lights.SetCue("solid")
+	assert.Equal(t, byte(10), lights.currentFrame(1, time.Now())[0])
+	assert.Equal(t, byte(10), lights.currentFrame(1, time.Now().Add(time.Minute))[0])
+
+	lights.SetCue("flash")
+	startTime := lights.cueStartTime
+	frame := lights.currentFrame(1, startTime)
+	assert.Equal(t, []byte{255, 0}, frame[0:2])
+	frame = lights.currentFrame(1, startTime.Add(cueStepDurationMs*time.Millisecond))
+	assert.Equal(t, []byte{0, 0}, frame[0:2])
+	frame = lights.currentFrame(1, startTime.Add(2*cueStepDurationMs*time.Millisecond))
+	assert.Equal(t, []byte{0, 255}, frame[0:2])
+	frame = lights.currentFrame(1, startTime.Add(3*cueStepDurationMs*time.Millisecond))
+	assert.Equal(t, []byte{255, 0}, frame[0:2])
+
+	// Setting the same cue again shouldn't restart its sequence.
+	lights.SetCue("flash")
+	assert.Equal(t, startTime, lights.cueStartTime)
+
+	// An undefined cue should black out the universe.
+	lights.SetCue("missing")
+	assert.Equal(t, [dmxUniverseSize]byte{}, lights.currentFrame(1, time.Now()))
+}
+
+func TestDmxLightsSacnOutput(t *testing.T) {
+	listener := listenUdp(t)
+	lights := NewDmxLights()
+	lights.SetSettings(model.SacnLighting, listener.LocalAddr().String(), 258, map[string]string{"auto": "1=255 3=7"})
+	lights.SetCue("auto")
+
+	assert.Nil(t, lights.sendFrame())
+	assert.Nil(t, lights.sendFrame())
+	packet := readUdpPacket(t, listener)
+	if assert.Equal(t, 638, len(packet)) {
+		assert.Equal(t, []byte("ASC-E1.17\x00\x00\x00"), packet[4:16])
+		assert.Equal(t, uint16(0x7000|622), binary.BigEndian.Uint16(packet[16:]))
+		assert.Equal(t, uint32(4), binary.BigEndian.Uint32(packet[18:]))
+		assert.Equal(t, lights.sourceId[:], packet[22:38])
+		assert.Equal(t, uint16(0x7000|600), binary.BigEndian.Uint16(packet[38:]))
+		assert.Equal(t, "Cheesy Arena", string(packet[44:56]))
+		assert.Equal(t, byte(100), packet[108])
+		assert.Equal(t, byte(0), packet[111])
+		assert.Equal(t, uint16(258), binary.BigEndian.Uint16(packet[113:]))
+		assert.Equal(t, uint16(0x7000|523), binary.BigEndian.Uint16(packet[115:]))
+		assert.Equal(t, uint16(513), binary.BigEndian.Uint16(packet[123:]))
+		assert.Equal(t, []byte{0, 255, 0, 7, 0}, packet[125:130])
+	}
+	packet = readUdpPacket(t, listener)
+	if assert.Equal(t, 638, len(packet)) {
+		assert.Equal(t, byte(1), packet[111])
+	}
+}
+
+func TestDmxLightsMultipleUniverses(t *testing.T) {
+	listener := listenUdp(t)
+	lights := NewDmxLights()
+	lights.SetSettings(
+		model.SacnLighting,
+		listener.LocalAddr().String(),
+		1,
+		map[string]string{"redWin": "1=255 3:1=128", "blueWin": "1=0 5:2=64", "bogus": "1=1 0:1=1"},
+	)
+	assert.Equal(t, []int{1, 3, 5}, lights.universes)
+	assert.NotContains(t, lights.cues, "bogus")
+
+	// Each universe should get its own packet, with universes not set by the current cue blacked out.
+	lights.SetCue("redWin")
+	assert.Nil(t, lights.sendFrame())
+	for _, expected := range []struct {
+		universe uint16
+		channels []byte
+	}{{1, []byte{255, 0}}, {3, []byte{128, 0}}, {5, []byte{0, 0}}} {
+		packet := readUdpPacket(t, listener)
+		if assert.Equal(t, 638, len(packet)) {
+			assert.Equal(t, expected.universe, binary.BigEndian.Uint16(packet[113:]))
+			assert.Equal(t, byte(0), packet[111])
+			assert.Equal(t, expected.channels, packet[126:128])
+		}
+	}
+
+	// Universes that are no longer used by any cue should stop being output.
+	lights.SetSettings(model.SacnLighting, listener.LocalAddr().String(), 1, map[string]string{"redWin": "3:1=128"})
+	assert.Equal(t, []int{1, 3}, lights.universes)
+	assert.NotContains(t, lights.conns, 5)
+	assert.Nil(t, lights.sendFrame())
+	packet := readUdpPacket(t, listener)
+	assert.Equal(t, uint16(1), binary.BigEndian.Uint16(packet[113:]))
+	assert.Equal(t, byte(1), packet[111])
+	packet = readUdpPacket(t, listener)
+	assert.Equal(t, uint16(3), binary.BigEndian.Uint16(packet[113:]))
+	assert.Equal(t, byte(128), packet[126])
+}
+
+func TestDmxLightsArtNetOutput(t *testing.T) {
+	listener := listenUdp(t)
+	lights := NewDmxLights()
+	lights.SetSettings(model.ArtNetLighting, listener.LocalAddr().String(), 0x123, map[string]string{"tie": "512=9"})
+	lights.SetCue("tie")
+
+	assert.Nil(t, lights.sendFrame())
+	packet := readUdpPacket(t, listener)
+	if assert.Equal(t, 530, len(packet)) {
+		assert.Equal(t, []byte("Art-Net\x00"), packet[0:8])
+		assert.Equal(t, []byte{0x00, 0x50, 0, 14, 0, 0, 0x23, 0x01, 0x02, 0x00}, packet[8:18])
+		assert.Equal(t, byte(9), packet[529])
+	}
+
+	// Art-Net has no multicast fallback, so an address is required.
+	lights.SetSettings(model.ArtNetLighting, "", 0, nil)
+	assert.EqualError(t, lights.sendFrame(), "an address is required for Art-Net output")
+
+	// Nothing should be sent while the output is disabled.
+	lights.SetSettings(model.NoLighting, listener.LocalAddr().String(), 0, nil)
+	assert.Nil(t, lights.sendFrame())
+}
+
+func TestDmxLightsDestinationAddress(t *testing.T) {
+	lights := NewDmxLights()
+	lights.SetSettings(model.SacnLighting, "", 1, nil)
+	address, _ := lights.destinationAddress(1)
+	assert.Equal(t, "239.255.0.1:5568", address)
+	address, _ = lights.destinationAddress(0x1234)
+	assert.Equal(t, "239.255.18.52:5568", address)
+	lights.SetSettings(model.SacnLighting, "10.0.100.80", 1, nil)
+	address, _ = lights.destinationAddress(2)
+	assert.Equal(t, "10.0.100.80:5568", address)
+	lights.SetSettings(model.ArtNetLighting, "10.0.100.80", 1, nil)
+	address, _ = lights.destinationAddress(1)
+	assert.Equal(t, "10.0.100.80:6454", address)
+}
+
+func listenUdp(t *testing.T) *net.UDPConn {
+	listener, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	assert.Nil(t, err)
+	t.Cleanup(func() { listener.Close() })
+	return listener
+}
+
+func readUdpPacket(t *testing.T, listener *net.UDPConn) []byte {
+	buffer := make([]byte, 1024)
+	listener.SetReadDeadline(time.Now().Add(time.Second))
+	n, err := listener.Read(buffer)
+	assert.Nil(t, err)
+	return buffer[:n]
+}
@@ -7,16 +7,33 @@
 package main
 
 import (
+	"flag"
 	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/network"
 	"github.com/Team254/cheesy-arena/web"
 	"log"
+	"net/http"
 )
 
 const eventDbPath = "./event.db"
 const httpPort = 8080
 
+var mockAccessPointAddress = flag.String(
+	"mockAccessPointAddress",
+	"",
+	"Address (e.g. 127.0.0.1:8081) at which to serve a simulated access point API, for demos without hardware",
+)
+
 // Main entry point for the application.
 func main() {
+	flag.Parse()
+	if *mockAccessPointAddress != "" {
+		log.Printf("Serving simulated access point API at %s.", *mockAccessPointAddress)
+		go func() {
+			log.Println(http.ListenAndServe(*mockAccessPointAddress, network.NewMockAccessPointServer("")))
+		}()
+	}
+
 	arena, err := field.NewArena(eventDbPath)
 	if err != nil {
 		log.Fatalln("Error during startup: ", err)
@@ -21,21 +21,24 @@ const backupsDir = "db/backups"
 var BaseDir = "." // Mutable for testing
 
 type Database struct {
-	Path                string
-	bolt                *bbolt.DB
-	allianceTable       *table[Alliance]
-	awardTable          *table[Award]
-	eventSettingsTable  *table[EventSettings]
-	judgingSlotTable    *table[JudgingSlot]
-	lowerThirdTable     *table[LowerThird]
-	matchTable          *table[Match]
-	matchResultTable    *table[MatchResult]
-	rankingTable        *table[game.Ranking]
-	scheduleBlockTable  *table[ScheduleBlock]
-	scheduledBreakTable *table[ScheduledBreak]
-	sponsorSlideTable   *table[SponsorSlide]
-	teamTable           *table[Team]
-	userSessionTable    *table[UserSession]
+	Path                     string
+	bolt                     *bbolt.DB
+	allianceTable            *table[Alliance]
+	awardTable               *table[Award]
+	eventSettingsTable       *table[EventSettings]
+	judgingSlotTable         *table[JudgingSlot]
+	lowerThirdTable          *table[LowerThird]
+	matchTable               *table[Match]
+	matchResultTable         *table[MatchResult]
+	matchVideoTable          *table[MatchVideo]
+	rankingTable             *table[game.Ranking]
+	scheduleBlockTable       *table[ScheduleBlock]
+	scheduledBreakTable      *table[ScheduledBreak]
+	sponsorSlideTable        *table[SponsorSlide]
+	teamTable                *table[Team]
+	userSessionTable         *table[UserSession]
+	webhookSubscriptionTable *table[WebhookSubscription]
+	wifiHistoryTable         *table[WifiHistory]
 }
 
 // Opens the Bolt database at the given path, creating it if it doesn't exist.
@@ -69,6 +72,9 @@ func OpenDatabase(filename string) (*Database, error) {
 	if database.matchResultTable, err = newTable[MatchResult](&database); err != nil {
 		return nil, err
 	}
+	if database.matchVideoTable, err = newTable[MatchVideo](&database); err != nil {
+		return nil, err
+	}
 	if database.rankingTable, err = newTable[game.Ranking](&database); err != nil {
 		return nil, err
 	}
@@ -87,6 +93,12 @@ func OpenDatabase(filename string) (*Database, error) {
 	if database.userSessionTable, err = newTable[UserSession](&database); err != nil {
 		return nil, err
 	}
+	if database.webhookSubscriptionTable, err = newTable[WebhookSubscription](&database); err != nil {
+		return nil, err
+	}
+	if database.wifiHistoryTable, err = newTable[WifiHistory](&database); err != nil {
+		return nil, err
+	}
 
 	return &database, nil
 }
@@ -14,6 +14,21 @@ const (
 	SingleEliminationPlayoff
 )
 
+type SwitchType int
+
+const (
+	CiscoSwitch SwitchType = iota
+	OpenWrtSwitch
+)
+
+type LightingProtocol int
+
+const (
+	NoLighting LightingProtocol = iota
+	SacnLighting
+	ArtNetLighting
+)
+
 type EventSettings struct {
 	Id                          int `db:"id"`
 	Name                        string
@@ -24,17 +39,22 @@ type EventSettings struct {
 	SelectionShowUnpickedTeams  bool
 	TwoVsTwoMode                bool
 	TbaDownloadEnabled          bool
+	TbaApiKey                   string
 	TbaPublishingEnabled        bool
 	TbaEventCode                string
 	TbaSecretId                 string
 	TbaSecret                   string
 	NexusEnabled                bool
+	NexusApiKey                 string
 	NetworkSecurityEnabled      bool
 	ApAddress                   string
 	ApPassword                  string
 	ApChannel                   int
+	SwitchType                  SwitchType
 	SwitchAddress               string
 	SwitchPassword              string
+	RadioKioskAddress           string
+	RadioKioskPassword          string
 	PlcAddress                  string
 	AdminPassword               string
 	TeamSignRed1Id              int
@@ -47,6 +67,34 @@ type EventSettings struct {
 	TeamSignBlueTimerId         int
 	UseLiteUdpPort              bool
 	BlackmagicAddresses         string
+	MatchVideoDirectory         string
+	ObsAddress                  string
+	ObsPassword                 string
+	ObsRecordingEnabled         bool
+	ObsMatchLoadActions         string
+	ObsMatchStartActions        string
+	ObsMatchEndActions          string
+	ObsScorePostedActions       string
+	ObsTimeoutActions           string
+	ObsAllianceSelectionActions string
+	LightingProtocol            LightingProtocol
+	LightingAddress             string
+	LightingUniverse            int
+	LightingIdleCue             string
+	LightingReadyCue            string
+	LightingAutoCue             string
+	LightingTeleopCue           string
+	LightingEndgameCue          string
+	LightingRedWinCue           string
+	LightingBlueWinCue          string
+	LightingTieCue              string
+	OscTargets                  string
+	OscMatchStartCues           string
+	OscMatchEndCues             string
+	OscMatchAbortCues           string
+	OscSoundCues                string
+	MqttBrokerAddress           string
+	MqttTopicPrefix             string
 	WarmupDurationSec           int
 	AutoDurationSec             int
 	PauseDurationSec            int
@@ -77,6 +125,7 @@ func (database *Database) GetEventSettings() (*EventSettings, error) {
 		SelectionShowUnpickedTeams:  true,
 		TbaDownloadEnabled:          true,
 		ApChannel:                   36,
+		LightingUniverse:            1,
 		WarmupDurationSec:           game.MatchTiming.WarmupDurationSec,
 		AutoDurationSec:             game.MatchTiming.AutoDurationSec,
 		PauseDurationSec:            game.MatchTiming.PauseDurationSec,
@@ -93,3 +142,11 @@ func (database *Database) GetEventSettings() (*EventSettings, error) {
 func (database *Database) UpdateEventSettings(eventSettings *EventSettings) error {
 	return database.eventSettingsTable.update(eventSettings)
 }
+
+// Returns the number of teams that make up each alliance in practice and qualification matches.
+func (eventSettings *EventSettings) TeamsPerAlliance() int {
+	if eventSettings.TwoVsTwoMode {
+		return 2
+	}
+	return 3
+}
@@ -27,6 +27,7 @@ func TestEventSettingsReadWrite(t *testing.T) {
 			SelectionShowUnpickedTeams:  true,
 			TbaDownloadEnabled:          true,
 			ApChannel:                   36,
+			LightingUniverse:            1,
 			WarmupDurationSec:           0,
 			AutoDurationSec:             15,
 			PauseDurationSec:            3,
@@ -0,0 +1,67 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Model and datastore CRUD methods for the index of video clips recorded of each match play.
+
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+type MatchVideo struct {
+	Id            int `db:"id"`
+	MatchId       int
+	DeviceAddress string
+	ClipName      string
+	Timecode      string
+	StartedAt     time.Time
+}
+
+func (database *Database) CreateMatchVideo(matchVideo *MatchVideo) error {
+	return database.matchVideoTable.create(matchVideo)
+}
+
+func (database *Database) GetMatchVideoById(id int) (*MatchVideo, error) {
+	return database.matchVideoTable.getById(id)
+}
+
+// Returns all the video clips recorded for the given match, ordered by start time and then device.
+func (database *Database) GetMatchVideosForMatch(matchId int) ([]MatchVideo, error) {
+	matchVideos, err := database.matchVideoTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var matchingMatchVideos []MatchVideo
+	for _, matchVideo := range matchVideos {
+		if matchVideo.MatchId == matchId {
+			matchingMatchVideos = append(matchingMatchVideos, matchVideo)
+		}
+	}
+	sort.SliceStable(
+		matchingMatchVideos,
+		func(i, j int) bool {
+			if !matchingMatchVideos[i].StartedAt.Equal(matchingMatchVideos[j].StartedAt) {
+				return matchingMatchVideos[i].StartedAt.Before(matchingMatchVideos[j].StartedAt)
+			}
+			return matchingMatchVideos[i].DeviceAddress < matchingMatchVideos[j].DeviceAddress
+		},
+	)
+	return matchingMatchVideos, nil
+}
+
+func (database *Database) DeleteMatchVideosForMatch(matchId int) error {
+	matchVideos, err := database.GetMatchVideosForMatch(matchId)
+	if err != nil {
+		return err
+	}
+
+	for _, matchVideo := range matchVideos {
+		if err = database.matchVideoTable.delete(matchVideo.Id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
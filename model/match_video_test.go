@@ -0,0 +1,46 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchVideoCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	startTime := time.Unix(1000, 0).UTC()
+	matchVideo1 := MatchVideo{
+		MatchId: 12, DeviceAddress: "10.0.100.60", ClipName: "Q12", Timecode: "00:01:02:03", StartedAt: startTime,
+	}
+	matchVideo2 := MatchVideo{MatchId: 12, DeviceAddress: "10.0.100.50", ClipName: "Q12", StartedAt: startTime}
+	matchVideo3 := MatchVideo{
+		MatchId: 12, DeviceAddress: "10.0.100.50", ClipName: "Q12_Play2", StartedAt: startTime.Add(time.Minute),
+	}
+	matchVideo4 := MatchVideo{MatchId: 13, DeviceAddress: "10.0.100.50", ClipName: "Q13", StartedAt: startTime}
+	assert.Nil(t, db.CreateMatchVideo(&matchVideo1))
+	assert.Nil(t, db.CreateMatchVideo(&matchVideo2))
+	assert.Nil(t, db.CreateMatchVideo(&matchVideo3))
+	assert.Nil(t, db.CreateMatchVideo(&matchVideo4))
+
+	matchVideo, err := db.GetMatchVideoById(1)
+	assert.Nil(t, err)
+	assert.Equal(t, matchVideo1, *matchVideo)
+
+	matchVideos, err := db.GetMatchVideosForMatch(12)
+	assert.Nil(t, err)
+	assert.Equal(t, []MatchVideo{matchVideo2, matchVideo1, matchVideo3}, matchVideos)
+
+	assert.Nil(t, db.DeleteMatchVideosForMatch(12))
+	matchVideos, err = db.GetMatchVideosForMatch(12)
+	assert.Nil(t, err)
+	assert.Empty(t, matchVideos)
+	matchVideos, err = db.GetMatchVideosForMatch(13)
+	assert.Nil(t, err)
+	assert.Equal(t, []MatchVideo{matchVideo4}, matchVideos)
+}
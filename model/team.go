@@ -5,23 +5,29 @@
 
 package model
 
-import "sort"
+import (
+	"sort"
+	"time"
+)
 
 type Team struct {
-	Id              int `db:"id,manual"`
-	Name            string
-	Nickname        string
-	City            string
-	StateProv       string
-	Country         string
-	SchoolName      string
-	RookieYear      int
-	RobotName       string
-	Accomplishments string
-	WpaKey          string
-	YellowCard      bool
-	HasConnected    bool
-	FtaNotes        string
+	Id                int `db:"id,manual"`
+	Name              string
+	Nickname          string
+	City              string
+	StateProv         string
+	Country           string
+	SchoolName        string
+	RookieYear        int
+	RobotName         string
+	Accomplishments   string
+	WpaKey            string
+	YellowCard        bool
+	HasConnected      bool
+	FtaNotes          string
+	RadioConfiguredAt time.Time
+	AvailableFrom     time.Time
+	AvailableUntil    time.Time
 }
 
 func (database *Database) CreateTeam(team *Team) error {
@@ -56,3 +62,15 @@ func (database *Database) GetAllTeams() ([]Team, error) {
 	)
 	return teams, nil
 }
+
+// Returns true if the given match time falls within the team's availability window. A zero start or end time means that
+// the team's availability isn't limited in that direction.
+func (team *Team) IsAvailableAt(matchTime time.Time) bool {
+	if !team.AvailableFrom.IsZero() && matchTime.Before(team.AvailableFrom) {
+		return false
+	}
+	if !team.AvailableUntil.IsZero() && matchTime.After(team.AvailableUntil) {
+		return false
+	}
+	return true
+}
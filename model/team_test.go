@@ -6,6 +6,7 @@ package model
 import (
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestGetNonexistentTeam(t *testing.T) {
@@ -88,3 +89,20 @@ func TestGetAllTeams(t *testing.T) {
 		assert.Equal(t, i+1, teams[i].Id)
 	}
 }
+
+func TestTeamIsAvailableAt(t *testing.T) {
+	team := Team{Id: 254}
+	assert.True(t, team.IsAvailableAt(time.Unix(0, 0)))
+
+	team.AvailableFrom = time.Unix(1000, 0)
+	assert.False(t, team.IsAvailableAt(time.Unix(999, 0)))
+	assert.True(t, team.IsAvailableAt(time.Unix(1000, 0)))
+	assert.True(t, team.IsAvailableAt(time.Unix(100000, 0)))
+
+	team.AvailableUntil = time.Unix(2000, 0)
+	assert.True(t, team.IsAvailableAt(time.Unix(2000, 0)))
+	assert.False(t, team.IsAvailableAt(time.Unix(2001, 0)))
+
+	team.AvailableFrom = time.Time{}
+	assert.True(t, team.IsAvailableAt(time.Unix(0, 0)))
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Model and datastore CRUD methods for an outbound webhook subscription.
+
+package model
+
+import (
+	"slices"
+	"sort"
+)
+
+// Names of the events that webhooks can be subscribed to.
+const (
+	WebhookMatchLoaded        = "match_loaded"
+	WebhookMatchStarted       = "match_started"
+	WebhookMatchEnded         = "match_ended"
+	WebhookScoreCommitted     = "score_committed"
+	WebhookRankingsUpdated    = "rankings_updated"
+	WebhookAlliancesFinalized = "alliances_finalized"
+	WebhookAwardCreated       = "award_created"
+)
+
+var WebhookEvents = []string{
+	WebhookMatchLoaded,
+	WebhookMatchStarted,
+	WebhookMatchEnded,
+	WebhookScoreCommitted,
+	WebhookRankingsUpdated,
+	WebhookAlliancesFinalized,
+	WebhookAwardCreated,
+}
+
+type WebhookSubscription struct {
+	Id     int `db:"id"`
+	Url    string
+	Secret string
+	Events []string
+}
+
+func (database *Database) CreateWebhookSubscription(subscription *WebhookSubscription) error {
+	return database.webhookSubscriptionTable.create(subscription)
+}
+
+func (database *Database) UpdateWebhookSubscription(subscription *WebhookSubscription) error {
+	return database.webhookSubscriptionTable.update(subscription)
+}
+
+func (database *Database) DeleteWebhookSubscription(id int) error {
+	return database.webhookSubscriptionTable.delete(id)
+}
+
+func (database *Database) GetAllWebhookSubscriptions() ([]WebhookSubscription, error) {
+	subscriptions, err := database.webhookSubscriptionTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(
+		subscriptions,
+		func(i, j int) bool {
+			return subscriptions[i].Id < subscriptions[j].Id
+		},
+	)
+	return subscriptions, nil
+}
+
+// Returns all subscriptions that should receive the given event.
+func (database *Database) GetWebhookSubscriptionsForEvent(event string) ([]WebhookSubscription, error) {
+	subscriptions, err := database.GetAllWebhookSubscriptions()
+	if err != nil {
+		return nil, err
+	}
+
+	var matchingSubscriptions []WebhookSubscription
+	for _, subscription := range subscriptions {
+		if subscription.IsSubscribedTo(event) {
+			matchingSubscriptions = append(matchingSubscriptions, subscription)
+		}
+	}
+	return matchingSubscriptions, nil
+}
+
+// Returns true if the subscription should receive the given event.
+func (subscription WebhookSubscription) IsSubscribedTo(event string) bool {
+	return slices.Contains(subscription.Events, event)
+}
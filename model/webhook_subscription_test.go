@@ -0,0 +1,52 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookSubscriptionCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	subscription := WebhookSubscription{0, "http://example.com/hook", "secret", []string{WebhookMatchLoaded}}
+	assert.Nil(t, db.CreateWebhookSubscription(&subscription))
+	subscriptions, err := db.GetAllWebhookSubscriptions()
+	assert.Nil(t, err)
+	assert.Equal(t, []WebhookSubscription{subscription}, subscriptions)
+
+	subscription.Events = append(subscription.Events, WebhookAwardCreated)
+	assert.Nil(t, db.UpdateWebhookSubscription(&subscription))
+	subscriptions, err = db.GetAllWebhookSubscriptions()
+	assert.Nil(t, err)
+	assert.Equal(t, []WebhookSubscription{subscription}, subscriptions)
+
+	assert.Nil(t, db.DeleteWebhookSubscription(subscription.Id))
+	subscriptions, err = db.GetAllWebhookSubscriptions()
+	assert.Nil(t, err)
+	assert.Empty(t, subscriptions)
+}
+
+func TestGetWebhookSubscriptionsForEvent(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	subscription1 := WebhookSubscription{Url: "http://a", Events: []string{WebhookMatchLoaded, WebhookMatchEnded}}
+	subscription2 := WebhookSubscription{Url: "http://b", Events: []string{WebhookMatchEnded}}
+	assert.Nil(t, db.CreateWebhookSubscription(&subscription1))
+	assert.Nil(t, db.CreateWebhookSubscription(&subscription2))
+
+	subscriptions, err := db.GetWebhookSubscriptionsForEvent(WebhookMatchEnded)
+	assert.Nil(t, err)
+	assert.Equal(t, []WebhookSubscription{subscription1, subscription2}, subscriptions)
+	subscriptions, err = db.GetWebhookSubscriptionsForEvent(WebhookMatchLoaded)
+	assert.Nil(t, err)
+	assert.Equal(t, []WebhookSubscription{subscription1}, subscriptions)
+	subscriptions, err = db.GetWebhookSubscriptionsForEvent(WebhookAwardCreated)
+	assert.Nil(t, err)
+	assert.Empty(t, subscriptions)
+}
@@ -0,0 +1,168 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Model and datastore CRUD methods for the time series of Wi-Fi link statistics recorded for a team during a match.
+
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+type WifiHistory struct {
+	Id        int `db:"id"`
+	MatchId   int
+	TeamId    int
+	Station   string
+	StartedAt time.Time
+	Samples   []WifiSample
+}
+
+type WifiSample struct {
+	MatchTimeSec      float64
+	RadioLinked       bool
+	MBits             float64
+	RxRate            float64
+	TxRate            float64
+	SignalNoiseRatio  int
+	ConnectionQuality int
+}
+
+// Aggregate Wi-Fi statistics for a single team across all of its recorded matches.
+type TeamWifiSummary struct {
+	TeamId                   int
+	NumMatches               int
+	NumSamples               int
+	UnlinkedPercent          float64
+	AverageSignalNoiseRatio  float64
+	MinSignalNoiseRatio      int
+	AverageConnectionQuality float64
+	AverageRxRate            float64
+	AverageTxRate            float64
+}
+
+func (database *Database) CreateWifiHistory(wifiHistory *WifiHistory) error {
+	return database.wifiHistoryTable.create(wifiHistory)
+}
+
+// Returns all the Wi-Fi histories recorded for the given match, ordered by start time and then station.
+func (database *Database) GetWifiHistoriesForMatch(matchId int) ([]WifiHistory, error) {
+	wifiHistories, err := database.wifiHistoryTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var matchingWifiHistories []WifiHistory
+	for _, wifiHistory := range wifiHistories {
+		if wifiHistory.MatchId == matchId {
+			matchingWifiHistories = append(matchingWifiHistories, wifiHistory)
+		}
+	}
+	sortWifiHistories(matchingWifiHistories)
+	return matchingWifiHistories, nil
+}
+
+func (database *Database) GetAllWifiHistories() ([]WifiHistory, error) {
+	wifiHistories, err := database.wifiHistoryTable.getAll()
+	if err != nil {
+		return nil, err
+	}
+	sortWifiHistories(wifiHistories)
+	return wifiHistories, nil
+}
+
+func (database *Database) DeleteWifiHistoriesForMatch(matchId int) error {
+	wifiHistories, err := database.GetWifiHistoriesForMatch(matchId)
+	if err != nil {
+		return err
+	}
+
+	for _, wifiHistory := range wifiHistories {
+		if err = database.wifiHistoryTable.delete(wifiHistory.Id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Aggregates the given Wi-Fi histories by team and returns the per-team summaries, ordered from the worst RF behavior
+// (the largest fraction of time without a radio link, then the lowest connection quality and SNR) to the best, with
+// any teams lacking samples at the end.
+func SummarizeWifiHistories(wifiHistories []WifiHistory) []TeamWifiSummary {
+	summariesByTeam := make(map[int]*TeamWifiSummary)
+	linkedSampleCounts := make(map[int]int)
+	for _, wifiHistory := range wifiHistories {
+		summary, ok := summariesByTeam[wifiHistory.TeamId]
+		if !ok {
+			summary = &TeamWifiSummary{TeamId: wifiHistory.TeamId}
+			summariesByTeam[wifiHistory.TeamId] = summary
+		}
+		summary.NumMatches++
+		for _, sample := range wifiHistory.Samples {
+			summary.NumSamples++
+			if !sample.RadioLinked {
+				summary.UnlinkedPercent++
+				continue
+			}
+
+			// Only consider link statistics from the samples during which the radio was actually linked.
+			if linkedSampleCounts[summary.TeamId] == 0 || sample.SignalNoiseRatio < summary.MinSignalNoiseRatio {
+				summary.MinSignalNoiseRatio = sample.SignalNoiseRatio
+			}
+			linkedSampleCounts[summary.TeamId]++
+			summary.AverageSignalNoiseRatio += float64(sample.SignalNoiseRatio)
+			summary.AverageConnectionQuality += float64(sample.ConnectionQuality)
+			summary.AverageRxRate += sample.RxRate
+			summary.AverageTxRate += sample.TxRate
+		}
+	}
+
+	summaries := make([]TeamWifiSummary, 0, len(summariesByTeam))
+	for _, summary := range summariesByTeam {
+		if summary.NumSamples > 0 {
+			summary.UnlinkedPercent = summary.UnlinkedPercent * 100 / float64(summary.NumSamples)
+		}
+		if linkedSampleCount := linkedSampleCounts[summary.TeamId]; linkedSampleCount > 0 {
+			summary.AverageSignalNoiseRatio /= float64(linkedSampleCount)
+			summary.AverageConnectionQuality /= float64(linkedSampleCount)
+			summary.AverageRxRate /= float64(linkedSampleCount)
+			summary.AverageTxRate /= float64(linkedSampleCount)
+		}
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(
+		summaries,
+		func(i, j int) bool {
+			a, b := summaries[i], summaries[j]
+			if (a.NumSamples == 0) != (b.NumSamples == 0) {
+				// Teams without any data go at the end.
+				return b.NumSamples == 0
+			}
+			if a.UnlinkedPercent != b.UnlinkedPercent {
+				return a.UnlinkedPercent > b.UnlinkedPercent
+			}
+			if a.AverageConnectionQuality != b.AverageConnectionQuality {
+				return a.AverageConnectionQuality < b.AverageConnectionQuality
+			}
+			if a.AverageSignalNoiseRatio != b.AverageSignalNoiseRatio {
+				return a.AverageSignalNoiseRatio < b.AverageSignalNoiseRatio
+			}
+			return a.TeamId < b.TeamId
+		},
+	)
+	return summaries
+}
+
+func sortWifiHistories(wifiHistories []WifiHistory) {
+	stationOrder := map[string]int{"R1": 0, "R2": 1, "R3": 2, "B1": 3, "B2": 4, "B3": 5}
+	sort.SliceStable(
+		wifiHistories,
+		func(i, j int) bool {
+			if !wifiHistories[i].StartedAt.Equal(wifiHistories[j].StartedAt) {
+				return wifiHistories[i].StartedAt.Before(wifiHistories[j].StartedAt)
+			}
+			return stationOrder[wifiHistories[i].Station] < stationOrder[wifiHistories[j].Station]
+		},
+	)
+}
@@ -0,0 +1,116 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWifiHistoryCrud(t *testing.T) {
+	db := setupTestDb(t)
+	defer db.Close()
+
+	startTime := time.Unix(1000, 0).UTC()
+	wifiHistory1 := WifiHistory{
+		MatchId:   12,
+		TeamId:    254,
+		Station:   "B1",
+		StartedAt: startTime,
+		Samples:   []WifiSample{{1, true, 2.5, 860, 720, 42, 4}, {2, false, 0, 0, 0, 0, 0}},
+	}
+	wifiHistory2 := WifiHistory{MatchId: 12, TeamId: 1114, Station: "R2", StartedAt: startTime}
+	wifiHistory3 := WifiHistory{MatchId: 12, TeamId: 1114, Station: "R1", StartedAt: startTime.Add(time.Minute)}
+	wifiHistory4 := WifiHistory{MatchId: 13, TeamId: 469, Station: "R3", StartedAt: startTime}
+	assert.Nil(t, db.CreateWifiHistory(&wifiHistory1))
+	assert.Nil(t, db.CreateWifiHistory(&wifiHistory2))
+	assert.Nil(t, db.CreateWifiHistory(&wifiHistory3))
+	assert.Nil(t, db.CreateWifiHistory(&wifiHistory4))
+
+	wifiHistories, err := db.GetWifiHistoriesForMatch(12)
+	assert.Nil(t, err)
+	assert.Equal(t, []WifiHistory{wifiHistory2, wifiHistory1, wifiHistory3}, wifiHistories)
+	wifiHistories, err = db.GetAllWifiHistories()
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(wifiHistories))
+
+	assert.Nil(t, db.DeleteWifiHistoriesForMatch(12))
+	wifiHistories, err = db.GetWifiHistoriesForMatch(12)
+	assert.Nil(t, err)
+	assert.Empty(t, wifiHistories)
+	wifiHistories, err = db.GetAllWifiHistories()
+	assert.Nil(t, err)
+	assert.Equal(t, []WifiHistory{wifiHistory4}, wifiHistories)
+}
+
+func TestSummarizeWifiHistories(t *testing.T) {
+	assert.Empty(t, SummarizeWifiHistories(nil))
+
+	wifiHistories := []WifiHistory{
+		{
+			TeamId: 254,
+			Samples: []WifiSample{
+				{RadioLinked: true, RxRate: 800, TxRate: 600, SignalNoiseRatio: 40, ConnectionQuality: 4},
+				{RadioLinked: true, RxRate: 600, TxRate: 400, SignalNoiseRatio: 30, ConnectionQuality: 3},
+			},
+		},
+		{
+			TeamId: 1114,
+			Samples: []WifiSample{
+				{RadioLinked: true, SignalNoiseRatio: 20, ConnectionQuality: 2},
+				{RadioLinked: false},
+			},
+		},
+		{
+			TeamId: 254,
+			Samples: []WifiSample{
+				{RadioLinked: true, RxRate: 400, TxRate: 200, SignalNoiseRatio: 20, ConnectionQuality: 2},
+				{RadioLinked: false},
+			},
+		},
+		{
+			TeamId: 469,
+			Samples: []WifiSample{
+				{RadioLinked: true, SignalNoiseRatio: 10, ConnectionQuality: 1},
+			},
+		},
+		{TeamId: 1678},
+	}
+	summaries := SummarizeWifiHistories(wifiHistories)
+	if assert.Equal(t, 4, len(summaries)) {
+		assert.Equal(
+			t,
+			TeamWifiSummary{
+				TeamId:                   1114,
+				NumMatches:               1,
+				NumSamples:               2,
+				UnlinkedPercent:          50,
+				AverageSignalNoiseRatio:  20,
+				MinSignalNoiseRatio:      20,
+				AverageConnectionQuality: 2,
+			},
+			summaries[0],
+		)
+		assert.Equal(
+			t,
+			TeamWifiSummary{
+				TeamId:                   254,
+				NumMatches:               2,
+				NumSamples:               4,
+				UnlinkedPercent:          25,
+				AverageSignalNoiseRatio:  30,
+				MinSignalNoiseRatio:      20,
+				AverageConnectionQuality: 3,
+				AverageRxRate:            600,
+				AverageTxRate:            400,
+			},
+			summaries[1],
+		)
+		assert.Equal(t, 469, summaries[2].TeamId)
+		assert.Equal(t, 1.0, summaries[2].AverageConnectionQuality)
+		assert.Equal(t, TeamWifiSummary{TeamId: 1678, NumMatches: 1}, summaries[3])
+	}
+}
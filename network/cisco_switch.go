@@ -0,0 +1,183 @@
+// Copyright 2014 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Methods for configuring a Cisco Switch 3500-series switch for team VLANs.
+
+package network
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"net"
+	"regexp"
+	"sync"
+	"time"
+)
+
+const (
+	switchTelnetPort = 23
+)
+
+var ciscoArpEntryRe = regexp.MustCompile(
+	`(?m)^Internet\s+(\d+\.\d+\.\d+\.\d+)\s+\S+\s+([0-9a-fA-F]{4}\.[0-9a-fA-F]{4}\.[0-9a-fA-F]{4})\s`,
+)
+var ciscoDhcpBindingRe = regexp.MustCompile(`(?m)^(\d+\.\d+\.\d+\.\d+)\s+([0-9a-fA-F.]+)\s`)
+
+type CiscoSwitch struct {
+	address               string
+	port                  int
+	password              string
+	mutex                 sync.Mutex
+	configBackoffDuration time.Duration
+	configPauseDuration   time.Duration
+	status                string
+}
+
+func NewCiscoSwitch(address, password string) *CiscoSwitch {
+	return &CiscoSwitch{
+		address:               address,
+		port:                  switchTelnetPort,
+		password:              password,
+		configBackoffDuration: switchConfigBackoffDurationSec * time.Second,
+		configPauseDuration:   switchConfigPauseDurationSec * time.Second,
+		status:                "UNKNOWN",
+	}
+}
+
+func (sw *CiscoSwitch) GetStatus() string {
+	return sw.status
+}
+
+// Sets up wired networks for the given set of teams.
+func (sw *CiscoSwitch) ConfigureTeamEthernet(teams [6]*model.Team) error {
+	// Make sure multiple configurations aren't being set at the same time.
+	sw.mutex.Lock()
+	defer sw.mutex.Unlock()
+	sw.status = "CONFIGURING"
+
+	// Remove old team VLANs to reset the switch state.
+	removeTeamVlansCommand := ""
+	for _, vlan := range teamVlans {
+		removeTeamVlansCommand += fmt.Sprintf(
+			"interface Vlan%d\nno ip address\nno ip dhcp pool dhcp%d\n", vlan, vlan,
+		)
+	}
+	_, err := sw.runConfigCommand(removeTeamVlansCommand)
+	if err != nil {
+		sw.status = "ERROR"
+		return err
+	}
+	time.Sleep(sw.configPauseDuration)
+
+	// Create the new team VLANs.
+	addTeamVlansCommand := ""
+	addTeamVlan := func(team *model.Team, vlan int) {
+		if team == nil {
+			return
+		}
+		partialIp := teamPartialIp(team)
+		addTeamVlansCommand += fmt.Sprintf(
+			"ip dhcp excluded-address 10.%s.1 10.%s.19\n"+
+				"ip dhcp excluded-address 10.%s.200 10.%s.254\n"+
+				"ip dhcp pool dhcp%d\n"+
+				"network 10.%s.0 255.255.255.0\n"+
+				"default-router 10.%s.%d\n"+
+				"lease 7\n"+
+				"interface Vlan%d\nip address 10.%s.%d 255.255.255.0\n",
+			partialIp,
+			partialIp,
+			partialIp,
+			partialIp,
+			vlan,
+			partialIp,
+			partialIp,
+			switchTeamGatewayAddress,
+			vlan,
+			partialIp,
+			switchTeamGatewayAddress,
+		)
+	}
+	for i, vlan := range teamVlans {
+		addTeamVlan(teams[i], vlan)
+	}
+	if len(addTeamVlansCommand) > 0 {
+		_, err = sw.runConfigCommand(addTeamVlansCommand)
+		if err != nil {
+			sw.status = "ERROR"
+			return err
+		}
+	}
+
+	// Give some time for the configuration to take before another one can be attempted.
+	time.Sleep(sw.configBackoffDuration)
+
+	sw.status = "ACTIVE"
+	return nil
+}
+
+// Returns the DHCP bindings and ARP entries currently known to the switch.
+func (sw *CiscoSwitch) GetNeighbors() ([]NetworkNeighbor, error) {
+	// Wait for any configuration in progress so as not to open a second Telnet session alongside it.
+	sw.mutex.Lock()
+	output, err := sw.runCommand("show ip dhcp binding\nshow ip arp\n")
+	sw.mutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	var neighbors []NetworkNeighbor
+	for _, match := range ciscoDhcpBindingRe.FindAllStringSubmatch(output, -1) {
+		neighbors = append(
+			neighbors, NetworkNeighbor{IpAddress: match[1], MacAddress: normalizeMacAddress(match[2]), Source: "DHCP"},
+		)
+	}
+	for _, match := range ciscoArpEntryRe.FindAllStringSubmatch(output, -1) {
+		neighbors = append(
+			neighbors, NetworkNeighbor{IpAddress: match[1], MacAddress: normalizeMacAddress(match[2]), Source: "ARP"},
+		)
+	}
+	return neighbors, nil
+}
+
+// Logs into the switch via Telnet and runs the given command in user exec mode. Reads the output and
+// returns it as a string.
+func (sw *CiscoSwitch) runCommand(command string) (string, error) {
+	// Open a Telnet connection to the switch.
+	conn, err := net.Dial("tcp", fmt.Sprintf("%s:%d", sw.address, sw.port))
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	// Login to the AP, send the command, and log out all at once.
+	writer := bufio.NewWriter(conn)
+	_, err = writer.WriteString(
+		fmt.Sprintf(
+			"%s\nenable\n%s\nterminal length 0\n%sexit\n", sw.password, sw.password,
+			command,
+		),
+	)
+	if err != nil {
+		return "", err
+	}
+	err = writer.Flush()
+	if err != nil {
+		return "", err
+	}
+
+	// Read the response.
+	var reader bytes.Buffer
+	_, err = reader.ReadFrom(conn)
+	if err != nil {
+		return "", err
+	}
+	return reader.String(), nil
+}
+
+// Logs into the switch via Telnet and runs the given command in global configuration mode. Reads the output
+// and returns it as a string.
+func (sw *CiscoSwitch) runConfigCommand(command string) (string, error) {
+	return sw.runCommand(fmt.Sprintf("config terminal\n%send\n", command))
+}
@@ -0,0 +1,160 @@
+// Copyright 2014 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package network
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestCiscoSwitch_ConfigureTeamEthernet(t *testing.T) {
+	sw := NewCiscoSwitch("127.0.0.1", "password")
+	assert.Equal(t, "UNKNOWN", sw.GetStatus())
+	sw.port = 9050
+	sw.configBackoffDuration = time.Millisecond
+	sw.configPauseDuration = time.Millisecond
+	var command1, command2 string
+	expectedResetCommand := "password\nenable\npassword\nterminal length 0\nconfig terminal\n" +
+		"interface Vlan10\nno ip address\nno ip dhcp pool dhcp10\n" +
+		"interface Vlan20\nno ip address\nno ip dhcp pool dhcp20\n" +
+		"interface Vlan30\nno ip address\nno ip dhcp pool dhcp30\n" +
+		"interface Vlan40\nno ip address\nno ip dhcp pool dhcp40\n" +
+		"interface Vlan50\nno ip address\nno ip dhcp pool dhcp50\n" +
+		"interface Vlan60\nno ip address\nno ip dhcp pool dhcp60\n" +
+		"end\nexit\n"
+
+	// Should remove all previous VLANs and do nothing else if current configuration is blank.
+	mockTelnet(t, sw.port, &command1, &command2)
+	assert.Nil(t, sw.ConfigureTeamEthernet([6]*model.Team{nil, nil, nil, nil, nil, nil}))
+	assert.Equal(t, expectedResetCommand, command1)
+	assert.Equal(t, "", command2)
+	assert.Equal(t, "ACTIVE", sw.GetStatus())
+
+	// Should configure one team if only one is present.
+	sw.port += 1
+	mockTelnet(t, sw.port, &command1, &command2)
+	assert.Nil(t, sw.ConfigureTeamEthernet([6]*model.Team{nil, nil, nil, nil, {Id: 254}, nil}))
+	assert.Equal(t, expectedResetCommand, command1)
+	assert.Equal(
+		t,
+		"password\nenable\npassword\nterminal length 0\nconfig terminal\n"+
+			"ip dhcp excluded-address 10.2.54.1 10.2.54.19\nip dhcp excluded-address 10.2.54.200 10.2.54.254\nip dhcp pool dhcp50\n"+
+			"network 10.2.54.0 255.255.255.0\ndefault-router 10.2.54.4\nlease 7\n"+
+			"interface Vlan50\nip address 10.2.54.4 255.255.255.0\n"+
+			"end\nexit\n",
+		command2,
+	)
+
+	// Should configure all teams if all are present.
+	sw.port += 1
+	mockTelnet(t, sw.port, &command1, &command2)
+	assert.Nil(
+		t,
+		sw.ConfigureTeamEthernet([6]*model.Team{{Id: 1114}, {Id: 254}, {Id: 296}, {Id: 1503}, {Id: 1678}, {Id: 1538}}),
+	)
+	assert.Equal(t, expectedResetCommand, command1)
+	assert.Equal(
+		t,
+		"password\nenable\npassword\nterminal length 0\nconfig terminal\n"+
+			"ip dhcp excluded-address 10.11.14.1 10.11.14.19\nip dhcp excluded-address 10.11.14.200 10.11.14.254\nip dhcp pool dhcp10\n"+
+			"network 10.11.14.0 255.255.255.0\ndefault-router 10.11.14.4\nlease 7\n"+
+			"interface Vlan10\nip address 10.11.14.4 255.255.255.0\n"+
+			"ip dhcp excluded-address 10.2.54.1 10.2.54.19\nip dhcp excluded-address 10.2.54.200 10.2.54.254\nip dhcp pool dhcp20\n"+
+			"network 10.2.54.0 255.255.255.0\ndefault-router 10.2.54.4\nlease 7\n"+
+			"interface Vlan20\nip address 10.2.54.4 255.255.255.0\n"+
+			"ip dhcp excluded-address 10.2.96.1 10.2.96.19\nip dhcp excluded-address 10.2.96.200 10.2.96.254\nip dhcp pool dhcp30\n"+
+			"network 10.2.96.0 255.255.255.0\ndefault-router 10.2.96.4\nlease 7\n"+
+			"interface Vlan30\nip address 10.2.96.4 255.255.255.0\n"+
+			"ip dhcp excluded-address 10.15.3.1 10.15.3.19\nip dhcp excluded-address 10.15.3.200 10.15.3.254\nip dhcp pool dhcp40\n"+
+			"network 10.15.3.0 255.255.255.0\ndefault-router 10.15.3.4\nlease 7\n"+
+			"interface Vlan40\nip address 10.15.3.4 255.255.255.0\n"+
+			"ip dhcp excluded-address 10.16.78.1 10.16.78.19\nip dhcp excluded-address 10.16.78.200 10.16.78.254\nip dhcp pool dhcp50\n"+
+			"network 10.16.78.0 255.255.255.0\ndefault-router 10.16.78.4\nlease 7\n"+
+			"interface Vlan50\nip address 10.16.78.4 255.255.255.0\n"+
+			"ip dhcp excluded-address 10.15.38.1 10.15.38.19\nip dhcp excluded-address 10.15.38.200 10.15.38.254\nip dhcp pool dhcp60\n"+
+			"network 10.15.38.0 255.255.255.0\ndefault-router 10.15.38.4\nlease 7\n"+
+			"interface Vlan60\nip address 10.15.38.4 255.255.255.0\n"+
+			"end\nexit\n",
+		command2,
+	)
+}
+
+func TestCiscoSwitch_GetNeighbors(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer ln.Close()
+	var command string
+	go func() {
+		conn, err := ln.Accept()
+		assert.Nil(t, err)
+		conn.SetReadDeadline(time.Now().Add(10 * time.Millisecond))
+		var reader bytes.Buffer
+		reader.ReadFrom(conn)
+		command = reader.String()
+		conn.Write(
+			[]byte(
+				"Switch#show ip dhcp binding\n" +
+					"IP address       Client-ID/              Lease expiration        Type\n" +
+					"                 Hardware address/\n" +
+					"                 User name\n" +
+					"10.2.54.20       0100.1122.3344.55       Mar 02 1993 12:07 AM    Automatic\n" +
+					"Switch#show ip arp\n" +
+					"Protocol  Address          Age (min)  Hardware Addr   Type   Interface\n" +
+					"Internet  10.2.54.1               0   000a.0b0c.0d0e  ARPA   Vlan50\n" +
+					"Internet  10.2.54.4               -   aabb.ccdd.eeff  ARPA   Vlan50\n" +
+					"Switch#exit\n",
+			),
+		)
+		conn.Close()
+	}()
+
+	sw := NewCiscoSwitch("127.0.0.1", "password")
+	sw.port = ln.Addr().(*net.TCPAddr).Port
+	neighbors, err := sw.GetNeighbors()
+	assert.Nil(t, err)
+	assert.Equal(t, "password\nenable\npassword\nterminal length 0\nshow ip dhcp binding\nshow ip arp\nexit\n", command)
+	assert.Equal(
+		t,
+		[]NetworkNeighbor{
+			{IpAddress: "10.2.54.20", MacAddress: "00:11:22:33:44:55", Source: "DHCP"},
+			{IpAddress: "10.2.54.1", MacAddress: "00:0a:0b:0c:0d:0e", Source: "ARP"},
+			{IpAddress: "10.2.54.4", MacAddress: "aa:bb:cc:dd:ee:ff", Source: "ARP"},
+		},
+		neighbors,
+	)
+}
+
+func mockTelnet(t *testing.T, port int, command1 *string, command2 *string) {
+	go func() {
+		ln, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+		assert.Nil(t, err)
+		defer ln.Close()
+		*command1 = ""
+		*command2 = ""
+
+		// Fake the first connection.
+		conn1, err := ln.Accept()
+		assert.Nil(t, err)
+		conn1.SetReadDeadline(time.Now().Add(10 * time.Millisecond))
+		var reader bytes.Buffer
+		reader.ReadFrom(conn1)
+		*command1 = reader.String()
+		conn1.Close()
+
+		// Fake the second connection.
+		conn2, err := ln.Accept()
+		assert.Nil(t, err)
+		conn2.SetReadDeadline(time.Now().Add(10 * time.Millisecond))
+		reader.Reset()
+		reader.ReadFrom(conn2)
+		*command2 = reader.String()
+		conn2.Close()
+	}()
+	time.Sleep(100 * time.Millisecond) // Give it some time to open the socket.
+}
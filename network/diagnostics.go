@@ -0,0 +1,199 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Methods for periodically checking whether each team's driver station, robot radio, and roboRIO are reachable at
+// their expected addresses on the team's VLAN.
+
+package network
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Team254/cheesy-arena/model"
+)
+
+const (
+	diagnosticsPeriodSec       = 5
+	diagnosticsProbeTimeoutSec = 1
+	radioHostAddress           = 1
+	roboRioHostAddress         = 2
+)
+
+// Device (e.g. the switch) that can report the ARP and DHCP lease entries it knows about.
+type NeighborSource interface {
+	GetNeighbors() ([]NetworkNeighbor, error)
+}
+
+type NetworkNeighbor struct {
+	IpAddress  string
+	MacAddress string
+	Source     string // "ARP" or "DHCP"
+}
+
+type NetworkDiagnostics struct {
+	prober             Prober
+	enabled            bool
+	neighborSource     NeighborSource
+	teams              [6]*model.Team
+	StationDiagnostics [6]*StationDiagnostics
+	mutex              sync.Mutex
+}
+
+type StationDiagnostics struct {
+	TeamId        int
+	DriverStation HostDiagnostics
+	Radio         HostDiagnostics
+	RoboRio       HostDiagnostics
+	NeighborError string
+	CheckedAt     time.Time
+}
+
+type HostDiagnostics struct {
+	IpAddress   string
+	MacAddress  string
+	LeaseSource string
+	Reachable   bool
+}
+
+func NewNetworkDiagnostics() *NetworkDiagnostics {
+	return &NetworkDiagnostics{prober: NewDefaultProber(diagnosticsProbeTimeoutSec * time.Second)}
+}
+
+func (diagnostics *NetworkDiagnostics) SetSettings(
+	enabled bool, neighborSource NeighborSource, stationDiagnostics [6]*StationDiagnostics,
+) {
+	diagnostics.mutex.Lock()
+	defer diagnostics.mutex.Unlock()
+	diagnostics.enabled = enabled
+	diagnostics.neighborSource = neighborSource
+	diagnostics.StationDiagnostics = stationDiagnostics
+}
+
+// Sets the teams whose network connectivity should be checked, in the same order as the alliance station VLANs.
+func (diagnostics *NetworkDiagnostics) SetTeams(teams [6]*model.Team) {
+	diagnostics.mutex.Lock()
+	defer diagnostics.mutex.Unlock()
+	diagnostics.teams = teams
+}
+
+// Loops indefinitely to check the reachability of each team's devices.
+func (diagnostics *NetworkDiagnostics) Run() {
+	for {
+		time.Sleep(time.Second * diagnosticsPeriodSec)
+		diagnostics.update()
+	}
+}
+
+// Performs a single iteration of looking up each team's devices on the switch and probing them.
+func (diagnostics *NetworkDiagnostics) update() {
+	diagnostics.mutex.Lock()
+	enabled := diagnostics.enabled
+	neighborSource := diagnostics.neighborSource
+	teams := diagnostics.teams
+	stationDiagnostics := diagnostics.StationDiagnostics
+	diagnostics.mutex.Unlock()
+
+	if !enabled {
+		for _, station := range stationDiagnostics {
+			if station != nil {
+				*station = StationDiagnostics{}
+			}
+		}
+		return
+	}
+
+	var neighbors []NetworkNeighbor
+	var neighborErr error
+	if neighborSource != nil {
+		neighbors, neighborErr = neighborSource.GetNeighbors()
+	}
+
+	// Check all the stations in parallel since each probe may need to wait for a timeout.
+	var waitGroup sync.WaitGroup
+	for i, station := range stationDiagnostics {
+		if station == nil {
+			continue
+		}
+		waitGroup.Add(1)
+		go func(team *model.Team, station *StationDiagnostics) {
+			defer waitGroup.Done()
+			*station = diagnostics.checkStation(team, neighbors, neighborErr)
+		}(teams[i], station)
+	}
+	waitGroup.Wait()
+}
+
+// Looks up and probes the driver station, radio, and roboRIO addresses for the given team.
+func (diagnostics *NetworkDiagnostics) checkStation(
+	team *model.Team, neighbors []NetworkNeighbor, neighborErr error,
+) StationDiagnostics {
+	if team == nil {
+		return StationDiagnostics{}
+	}
+
+	subnet := fmt.Sprintf("10.%s.", teamPartialIp(team))
+	result := StationDiagnostics{
+		TeamId:        team.Id,
+		DriverStation: findDriverStation(subnet, neighbors),
+		Radio:         findHost(fmt.Sprintf("%s%d", subnet, radioHostAddress), neighbors),
+		RoboRio:       findHost(fmt.Sprintf("%s%d", subnet, roboRioHostAddress), neighbors),
+		CheckedAt:     time.Now(),
+	}
+	if neighborErr != nil {
+		result.NeighborError = neighborErr.Error()
+	}
+
+	var waitGroup sync.WaitGroup
+	for _, host := range []*HostDiagnostics{&result.DriverStation, &result.Radio, &result.RoboRio} {
+		if host.IpAddress == "" {
+			continue
+		}
+		waitGroup.Add(1)
+		go func(host *HostDiagnostics) {
+			defer waitGroup.Done()
+			host.Reachable = diagnostics.prober.Probe(host.IpAddress)
+		}(host)
+	}
+	waitGroup.Wait()
+	return result
+}
+
+// Returns the diagnostics for the host at the given static address, populated with its MAC address if known.
+func findHost(ipAddress string, neighbors []NetworkNeighbor) HostDiagnostics {
+	host := HostDiagnostics{IpAddress: ipAddress}
+	for _, neighbor := range neighbors {
+		if neighbor.IpAddress == ipAddress {
+			host.MacAddress = neighbor.MacAddress
+			host.LeaseSource = neighbor.Source
+			if neighbor.Source == "DHCP" {
+				break
+			}
+		}
+	}
+	return host
+}
+
+// Returns the diagnostics for the driver station, whose address is assigned dynamically and so must be found among the
+// switch's DHCP leases or ARP entries for the team subnet. Leases are preferred since they are authoritative.
+func findDriverStation(subnet string, neighbors []NetworkNeighbor) HostDiagnostics {
+	reservedAddresses := map[string]bool{
+		fmt.Sprintf("%s%d", subnet, radioHostAddress):         true,
+		fmt.Sprintf("%s%d", subnet, roboRioHostAddress):       true,
+		fmt.Sprintf("%s%d", subnet, switchTeamGatewayAddress): true,
+	}
+	var host HostDiagnostics
+	for _, neighbor := range neighbors {
+		if !strings.HasPrefix(neighbor.IpAddress, subnet) || reservedAddresses[neighbor.IpAddress] {
+			continue
+		}
+		if host.IpAddress == "" || neighbor.Source == "DHCP" && host.LeaseSource != "DHCP" {
+			host = HostDiagnostics{
+				IpAddress: neighbor.IpAddress, MacAddress: neighbor.MacAddress, LeaseSource: neighbor.Source,
+			}
+		}
+	}
+	return host
+}
@@ -0,0 +1,137 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkDiagnostics_Update(t *testing.T) {
+	prober := &fakeProber{reachable: map[string]bool{"10.2.54.21": true, "10.2.54.1": true, "10.11.14.2": true}}
+	neighborSource := &fakeNeighborSource{
+		neighbors: []NetworkNeighbor{
+			{IpAddress: "10.2.54.4", MacAddress: "00:00:00:00:00:04", Source: "ARP"},
+			{IpAddress: "10.2.54.1", MacAddress: "00:00:00:00:00:01", Source: "ARP"},
+			{IpAddress: "10.2.54.20", MacAddress: "00:00:00:00:00:20", Source: "ARP"},
+			{IpAddress: "10.2.54.21", MacAddress: "00:00:00:00:00:21", Source: "DHCP"},
+			{IpAddress: "10.11.14.2", MacAddress: "00:00:00:00:11:02", Source: "ARP"},
+		},
+	}
+	diagnostics := NewNetworkDiagnostics()
+	diagnostics.prober = prober
+	stationDiagnostics := [6]*StationDiagnostics{{}, {}, {}, {}, {}, {}}
+	diagnostics.SetSettings(true, neighborSource, stationDiagnostics)
+	diagnostics.SetTeams([6]*model.Team{{Id: 254}, nil, nil, nil, nil, {Id: 1114}})
+	diagnostics.update()
+
+	// The driver station should be found by its DHCP lease in preference to a stale ARP entry.
+	station := stationDiagnostics[0]
+	assert.Equal(t, 254, station.TeamId)
+	assert.Equal(t, HostDiagnostics{"10.2.54.21", "00:00:00:00:00:21", "DHCP", true}, station.DriverStation)
+	assert.Equal(t, HostDiagnostics{"10.2.54.1", "00:00:00:00:00:01", "ARP", true}, station.Radio)
+	assert.Equal(t, HostDiagnostics{IpAddress: "10.2.54.2"}, station.RoboRio)
+	assert.Equal(t, "", station.NeighborError)
+	assert.False(t, station.CheckedAt.IsZero())
+
+	assert.Equal(t, StationDiagnostics{}, *stationDiagnostics[1])
+	station = stationDiagnostics[5]
+	assert.Equal(t, 1114, station.TeamId)
+	assert.Equal(t, HostDiagnostics{}, station.DriverStation)
+	assert.Equal(t, HostDiagnostics{IpAddress: "10.11.14.1"}, station.Radio)
+	assert.Equal(t, HostDiagnostics{"10.11.14.2", "00:00:00:00:11:02", "ARP", true}, station.RoboRio)
+	assert.ElementsMatch(
+		t, []string{"10.2.54.21", "10.2.54.1", "10.2.54.2", "10.11.14.1", "10.11.14.2"}, prober.probedAddresses(),
+	)
+
+	// Static addresses should still be probed if the switch can't be queried.
+	neighborSource.err = fmt.Errorf("switch is down")
+	diagnostics.update()
+	assert.Equal(t, "switch is down", stationDiagnostics[5].NeighborError)
+	assert.Equal(t, HostDiagnostics{IpAddress: "10.11.14.2", Reachable: true}, stationDiagnostics[5].RoboRio)
+
+	// Results should be cleared when diagnostics are disabled.
+	diagnostics.SetSettings(false, neighborSource, stationDiagnostics)
+	diagnostics.update()
+	assert.Equal(t, StationDiagnostics{}, *stationDiagnostics[0])
+	assert.Equal(t, StationDiagnostics{}, *stationDiagnostics[5])
+}
+
+func TestTcpProber(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	openPort := ln.Addr().(*net.TCPAddr).Port
+	defer ln.Close()
+
+	prober := TcpProber{Ports: []int{openPort}, Timeout: 100 * time.Millisecond}
+	assert.True(t, prober.Probe("127.0.0.1"))
+
+	// A refused connection means that the host is up, just not listening on that port.
+	ln2, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	closedPort := ln2.Addr().(*net.TCPAddr).Port
+	ln2.Close()
+	prober.Ports = []int{closedPort}
+	assert.True(t, prober.Probe("127.0.0.1"))
+
+	prober.Ports = []int{}
+	assert.False(t, prober.Probe("127.0.0.1"))
+}
+
+func TestAnyProber(t *testing.T) {
+	prober1 := &fakeProber{reachable: map[string]bool{"10.0.0.1": true}}
+	prober2 := &fakeProber{reachable: map[string]bool{"10.0.0.2": true}}
+	prober := AnyProber{prober1, prober2}
+	assert.True(t, prober.Probe("10.0.0.1"))
+	assert.Equal(t, []string{"10.0.0.1"}, prober1.probedAddresses())
+	assert.Empty(t, prober2.probedAddresses())
+	assert.True(t, prober.Probe("10.0.0.2"))
+	assert.False(t, prober.Probe("10.0.0.3"))
+}
+
+func TestNormalizeMacAddress(t *testing.T) {
+	assert.Equal(t, "00:11:22:33:44:55", normalizeMacAddress("0011.2233.4455"))
+	assert.Equal(t, "00:11:22:33:44:55", normalizeMacAddress("0100.1122.3344.55"))
+	assert.Equal(t, "aa:bb:cc:dd:ee:ff", normalizeMacAddress("AA-BB-CC-DD-EE-FF"))
+	assert.Equal(t, "aa:bb:cc:dd:ee:ff", normalizeMacAddress("aa:bb:cc:dd:ee:ff"))
+	assert.Equal(t, "garbage", normalizeMacAddress("garbage"))
+}
+
+// Stand-in for a real prober that reports a fixed set of addresses as reachable.
+type fakeProber struct {
+	reachable map[string]bool
+	probed    []string
+	mutex     sync.Mutex
+}
+
+func (prober *fakeProber) Probe(address string) bool {
+	prober.mutex.Lock()
+	defer prober.mutex.Unlock()
+	prober.probed = append(prober.probed, address)
+	return prober.reachable[address]
+}
+
+func (prober *fakeProber) probedAddresses() []string {
+	prober.mutex.Lock()
+	defer prober.mutex.Unlock()
+	return prober.probed
+}
+
+type fakeNeighborSource struct {
+	neighbors []NetworkNeighbor
+	err       error
+}
+
+func (source *fakeNeighborSource) GetNeighbors() ([]NetworkNeighbor, error) {
+	if source.err != nil {
+		return nil, source.err
+	}
+	return source.neighbors, nil
+}
@@ -0,0 +1,225 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Simulated implementation of the Vivid-Hosting VH-113 access point API, for exercising the access point driver in
+// tests and for running demos without access point hardware.
+
+package network
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	mockAccessPointBaseSignalNoiseRatio = 40
+	mockAccessPointSnrJitter            = 6
+	mockAccessPointMaxRateMbps          = 1200
+)
+
+type MockAccessPointServer struct {
+	// Time between a configuration being accepted and the access point reporting it as active.
+	ConfigurationDelay time.Duration
+
+	password               string
+	mutex                  sync.Mutex
+	random                 *rand.Rand
+	channel                int
+	status                 string
+	stationConfigurations  map[string]stationConfiguration
+	pendingConfiguration   *configurationRequest
+	pendingConfigurationAt time.Time
+	signalNoiseRatios      map[string]int
+	unlinkedStations       map[string]bool
+	failedConfigurations   int
+	failedStatuses         int
+	droppedConfigurations  int
+	configurationRequests  []configurationRequest
+}
+
+// Creates a new simulated access point that requires the given password as its API bearer token (or none if blank).
+func NewMockAccessPointServer(password string) *MockAccessPointServer {
+	return &MockAccessPointServer{
+		password:              password,
+		random:                rand.New(rand.NewSource(time.Now().UnixNano())),
+		status:                "ACTIVE",
+		stationConfigurations: make(map[string]stationConfiguration),
+		signalNoiseRatios:     make(map[string]int),
+		unlinkedStations:      make(map[string]bool),
+	}
+}
+
+// Causes the next given number of configuration requests to be rejected with an HTTP error.
+func (server *MockAccessPointServer) FailNextConfigurations(count int) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.failedConfigurations = count
+}
+
+// Causes the next given number of status requests to be rejected with an HTTP error.
+func (server *MockAccessPointServer) FailNextStatuses(count int) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.failedStatuses = count
+}
+
+// Causes the next given number of configuration requests to be accepted but never applied, as if the access point
+// had silently lost them.
+func (server *MockAccessPointServer) DropNextConfigurations(count int) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.droppedConfigurations = count
+}
+
+// Pins the signal-to-noise ratio reported for the given station (e.g. "red1"), disabling the simulated jitter.
+func (server *MockAccessPointServer) SetStationSignalNoiseRatio(station string, signalNoiseRatio int) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.signalNoiseRatios[station] = signalNoiseRatio
+}
+
+// Returns a copy of all the configuration requests that the access point has received so far.
+func (server *MockAccessPointServer) getConfigurationRequests() []configurationRequest {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return append([]configurationRequest(nil), server.configurationRequests...)
+}
+
+// Removes any pinned signal-to-noise ratios, so that all stations go back to reporting simulated jitter.
+func (server *MockAccessPointServer) ClearStationSignalNoiseRatios() {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.signalNoiseRatios = make(map[string]int)
+}
+
+// Reseeds the random number generator behind the simulated jitter, so that the reported link statistics are
+// repeatable.
+func (server *MockAccessPointServer) SetRandomSeed(seed int64) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.random = rand.New(rand.NewSource(seed))
+}
+
+// Sets whether a radio is simulated as being linked to the given station's SSID once it is configured.
+func (server *MockAccessPointServer) SetStationLinked(station string, linked bool) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.unlinkedStations[station] = !linked
+}
+
+func (server *MockAccessPointServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if server.password != "" && r.Header.Get("Authorization") != fmt.Sprintf("Bearer %s", server.password) {
+		http.Error(w, "invalid or missing bearer token", http.StatusUnauthorized)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/configuration":
+		server.handleConfiguration(w, r)
+	case r.Method == http.MethodGet && r.URL.Path == "/status":
+		server.handleStatus(w)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (server *MockAccessPointServer) handleConfiguration(w http.ResponseWriter, r *http.Request) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	if server.failedConfigurations > 0 {
+		server.failedConfigurations--
+		http.Error(w, "simulated configuration failure", http.StatusInternalServerError)
+		return
+	}
+
+	var request configurationRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	server.configurationRequests = append(server.configurationRequests, request)
+
+	if server.droppedConfigurations > 0 {
+		server.droppedConfigurations--
+	} else {
+		server.pendingConfiguration = &request
+		server.pendingConfigurationAt = time.Now()
+		server.status = "CONFIGURING"
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (server *MockAccessPointServer) handleStatus(w http.ResponseWriter) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	if server.failedStatuses > 0 {
+		server.failedStatuses--
+		http.Error(w, "simulated status failure", http.StatusInternalServerError)
+		return
+	}
+
+	// Apply the pending configuration once it has had time to "take".
+	if server.pendingConfiguration != nil &&
+		time.Since(server.pendingConfigurationAt) >= server.ConfigurationDelay {
+		server.channel = server.pendingConfiguration.Channel
+		server.stationConfigurations = server.pendingConfiguration.StationConfigurations
+		server.pendingConfiguration = nil
+		server.status = "ACTIVE"
+	}
+
+	apStatus := accessPointStatus{
+		Channel:         server.channel,
+		Status:          server.status,
+		StationStatuses: make(map[string]*stationStatus),
+	}
+	for _, station := range []string{"red1", "red2", "red3", "blue1", "blue2", "blue3"} {
+		if configuration, ok := server.stationConfigurations[station]; ok {
+			apStatus.StationStatuses[station] = server.simulateStationStatus(station, configuration)
+		} else {
+			apStatus.StationStatuses[station] = nil
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(apStatus)
+}
+
+// Generates a plausible set of link statistics for the given configured station.
+func (server *MockAccessPointServer) simulateStationStatus(
+	station string, configuration stationConfiguration,
+) *stationStatus {
+	status := stationStatus{Ssid: configuration.Ssid, WpaKeySalt: "mock"}
+	if server.unlinkedStations[station] {
+		return &status
+	}
+
+	signalNoiseRatio, ok := server.signalNoiseRatios[station]
+	if !ok {
+		signalNoiseRatio = mockAccessPointBaseSignalNoiseRatio +
+			server.random.Intn(2*mockAccessPointSnrJitter+1) - mockAccessPointSnrJitter
+	}
+	rateMbps := float64(min(signalNoiseRatio*30, mockAccessPointMaxRateMbps))
+
+	status.IsLinked = true
+	status.SignalNoiseRatio = signalNoiseRatio
+	status.RxRateMbps = rateMbps
+	status.TxRateMbps = rateMbps
+	status.BandwidthUsedMbps = float64(server.random.Intn(400)) / 100
+	switch {
+	case signalNoiseRatio >= 35:
+		status.ConnectionQuality = "excellent"
+	case signalNoiseRatio >= 25:
+		status.ConnectionQuality = "good"
+	case signalNoiseRatio >= 15:
+		status.ConnectionQuality = "warning"
+	default:
+		status.ConnectionQuality = "caution"
+	}
+	return &status
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package network
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMockAccessPointServer_ConfigureAndMonitor(t *testing.T) {
+	mockAp := NewMockAccessPointServer("password1")
+	mockAp.SetRandomSeed(254)
+	server := httptest.NewServer(mockAp)
+	defer server.Close()
+	ap, wifiStatuses := setupMockedVh113AccessPoint(server.URL, "password1")
+
+	team1 := &model.Team{Id: 254, WpaKey: "11111111"}
+	team2 := &model.Team{Id: 1114, WpaKey: "22222222"}
+	mockAp.SetStationSignalNoiseRatio("red1", 50)
+	mockAp.SetStationSignalNoiseRatio("blue3", 10)
+	mockAp.SetStationLinked("blue3", false)
+	assert.Nil(t, ap.ConfigureTeamWifi([6]*model.Team{team1, nil, nil, nil, nil, team2}))
+	assert.Equal(t, "CONFIGURING", ap.GetStatus())
+	if assert.Equal(t, 1, len(mockAp.getConfigurationRequests())) {
+		assert.Equal(t, 123, mockAp.getConfigurationRequests()[0].Channel)
+	}
+
+	ap.update()
+	assert.Equal(t, "ACTIVE", ap.GetStatus())
+	assert.True(t, ap.statusMatchesLastConfiguration())
+	assert.Equal(t, 254, wifiStatuses[0].TeamId)
+	assert.True(t, wifiStatuses[0].RadioLinked)
+	assert.Equal(t, 50, wifiStatuses[0].SignalNoiseRatio)
+	assert.Equal(t, 4, wifiStatuses[0].ConnectionQuality)
+	assert.Equal(t, 1200.0, wifiStatuses[0].TxRate)
+	assert.Equal(t, TeamWifiStatus{}, *wifiStatuses[1])
+	assert.Equal(t, TeamWifiStatus{TeamId: 1114}, *wifiStatuses[5])
+
+	// Once the radio links, the pinned SNR should be reflected as a poor connection.
+	mockAp.SetStationLinked("blue3", true)
+	ap.update()
+	assert.True(t, wifiStatuses[5].RadioLinked)
+	assert.Equal(t, 10, wifiStatuses[5].SignalNoiseRatio)
+	assert.Equal(t, 1, wifiStatuses[5].ConnectionQuality)
+
+	// With the random seed fixed, the simulated jitter should be repeatable once no SNR is pinned.
+	mockAp.ClearStationSignalNoiseRatios()
+	ap.update()
+	assert.Equal(t, 34, wifiStatuses[0].SignalNoiseRatio)
+	assert.Equal(t, 3, wifiStatuses[0].ConnectionQuality)
+
+	// A wrong password should be rejected.
+	badAp, _ := setupMockedVh113AccessPoint(server.URL, "wrongpassword")
+	err := badAp.ConfigureTeamWifi([6]*model.Team{team1, nil, nil, nil, nil, nil})
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "returned status 401")
+	}
+}
+
+func TestMockAccessPointServer_RetryLostConfiguration(t *testing.T) {
+	mockAp := NewMockAccessPointServer("")
+	server := httptest.NewServer(mockAp)
+	defer server.Close()
+	ap, wifiStatuses := setupMockedVh113AccessPoint(server.URL, "")
+
+	team1 := &model.Team{Id: 254, WpaKey: "11111111"}
+	team2 := &model.Team{Id: 1114, WpaKey: "22222222"}
+	assert.Nil(t, ap.ConfigureTeamWifi([6]*model.Team{team1, nil, nil, nil, nil, nil}))
+	ap.update()
+	assert.Equal(t, "ACTIVE", ap.GetStatus())
+	assert.Equal(t, 254, wifiStatuses[0].TeamId)
+
+	// Simulate the access point accepting a configuration but never applying it.
+	mockAp.DropNextConfigurations(1)
+	assert.Nil(t, ap.ConfigureTeamWifi([6]*model.Team{nil, team2, nil, nil, nil, nil}))
+	assert.Equal(t, 2, len(mockAp.getConfigurationRequests()))
+
+	// The next poll should notice the mismatch and resend the configuration.
+	ap.update()
+	assert.Equal(t, "CONFIGURING", ap.GetStatus())
+	assert.Equal(t, 254, wifiStatuses[0].TeamId)
+	if assert.Equal(t, 3, len(mockAp.getConfigurationRequests())) {
+		assert.Equal(t, mockAp.getConfigurationRequests()[1], mockAp.getConfigurationRequests()[2])
+	}
+
+	// The poll after that should find the access point in the expected state and not retry again.
+	ap.update()
+	assert.Equal(t, "ACTIVE", ap.GetStatus())
+	assert.True(t, ap.statusMatchesLastConfiguration())
+	assert.Equal(t, 0, wifiStatuses[0].TeamId)
+	assert.Equal(t, 1114, wifiStatuses[1].TeamId)
+	assert.Equal(t, 3, len(mockAp.getConfigurationRequests()))
+}
+
+func TestMockAccessPointServer_InjectedFailures(t *testing.T) {
+	mockAp := NewMockAccessPointServer("")
+	mockAp.ConfigurationDelay = time.Hour
+	server := httptest.NewServer(mockAp)
+	defer server.Close()
+	ap, _ := setupMockedVh113AccessPoint(server.URL, "")
+	team1 := &model.Team{Id: 254, WpaKey: "11111111"}
+
+	// Configuration request failure.
+	mockAp.FailNextConfigurations(1)
+	err := ap.ConfigureTeamWifi([6]*model.Team{team1, nil, nil, nil, nil, nil})
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "returned status 500: simulated configuration failure")
+	}
+	assert.Equal(t, 0, len(mockAp.getConfigurationRequests()))
+	assert.Nil(t, ap.ConfigureTeamWifi([6]*model.Team{team1, nil, nil, nil, nil, nil}))
+
+	// Status request failure.
+	mockAp.FailNextStatuses(1)
+	ap.update()
+	assert.Equal(t, "ERROR", ap.GetStatus())
+
+	// The access point should remain in the configuring state until the configuration delay has elapsed, without
+	// the driver retrying in the meantime.
+	ap.update()
+	assert.Equal(t, "CONFIGURING", ap.GetStatus())
+	assert.False(t, ap.statusMatchesLastConfiguration())
+	assert.Equal(t, 1, len(mockAp.getConfigurationRequests()))
+	mockAp.ConfigurationDelay = 0
+	ap.update()
+	assert.Equal(t, "ACTIVE", ap.GetStatus())
+	assert.True(t, ap.statusMatchesLastConfiguration())
+}
+
+func setupMockedVh113AccessPoint(url, password string) (*Vh113AccessPoint, [6]*TeamWifiStatus) {
+	ap := new(Vh113AccessPoint)
+	wifiStatuses := [6]*TeamWifiStatus{{}, {}, {}, {}, {}, {}}
+	ap.SetSettings("dummy", password, 123, true, wifiStatuses)
+	ap.apiUrl = url
+	return ap, wifiStatuses
+}
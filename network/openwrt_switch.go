@@ -0,0 +1,326 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Methods for configuring an OpenWRT-based switch using DSA bridge VLANs for team VLANs, via the ubus JSON-RPC API
+// exposed over HTTP by uhttpd/rpcd. The switch is expected to have the bridge VLAN port memberships (trunk to the
+// access point and access ports for each alliance station) provisioned ahead of time; this driver only manages the
+// routed team interfaces and their DHCP pools, analogous to what the Cisco driver does.
+
+package network
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Team254/cheesy-arena/model"
+)
+
+const (
+	openWrtUsername          = "root"
+	openWrtBridgeDevice      = "br-lan"
+	openWrtNullSession       = "00000000000000000000000000000000"
+	openWrtDhcpPoolStart     = 20
+	openWrtDhcpPoolLimit     = 180
+	openWrtDhcpLeaseTime     = "7d"
+	openWrtStatusNotFound    = 4
+	openWrtHttpTimeoutSec    = 5
+	openWrtSessionTimeoutSec = 300
+	openWrtDhcpLeasesPath    = "/tmp/dhcp.leases"
+	openWrtArpTablePath      = "/proc/net/arp"
+	openWrtArpFlagComplete   = "0x2"
+)
+
+type OpenWrtSwitch struct {
+	apiUrl                string
+	password              string
+	mutex                 sync.Mutex
+	configBackoffDuration time.Duration
+	configPauseDuration   time.Duration
+	status                string
+}
+
+type ubusRequest struct {
+	JsonRpc string `json:"jsonrpc"`
+	Id      int    `json:"id"`
+	Method  string `json:"method"`
+	Params  []any  `json:"params"`
+}
+
+type ubusResponse struct {
+	Result []json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func NewOpenWrtSwitch(address, password string) *OpenWrtSwitch {
+	return &OpenWrtSwitch{
+		apiUrl:                fmt.Sprintf("http://%s/ubus", address),
+		password:              password,
+		configBackoffDuration: switchConfigBackoffDurationSec * time.Second,
+		configPauseDuration:   switchConfigPauseDurationSec * time.Second,
+		status:                "UNKNOWN",
+	}
+}
+
+func (sw *OpenWrtSwitch) GetStatus() string {
+	return sw.status
+}
+
+// Sets up wired networks for the given set of teams.
+func (sw *OpenWrtSwitch) ConfigureTeamEthernet(teams [6]*model.Team) error {
+	// Make sure multiple configurations aren't being set at the same time.
+	sw.mutex.Lock()
+	defer sw.mutex.Unlock()
+	sw.status = "CONFIGURING"
+
+	session, err := sw.login()
+	if err != nil {
+		sw.status = "ERROR"
+		return err
+	}
+
+	// Remove old team VLANs to reset the switch state.
+	for _, vlan := range teamVlans {
+		section := fmt.Sprintf("vlan%d", vlan)
+		if err = sw.deleteUciSection(session, "dhcp", section); err != nil {
+			sw.status = "ERROR"
+			return err
+		}
+		if err = sw.deleteUciSection(session, "network", section); err != nil {
+			sw.status = "ERROR"
+			return err
+		}
+	}
+	if err = sw.commitUciConfigs(session); err != nil {
+		sw.status = "ERROR"
+		return err
+	}
+	time.Sleep(sw.configPauseDuration)
+
+	// Create the new team VLANs.
+	addedTeamVlans := false
+	for i, vlan := range teamVlans {
+		team := teams[i]
+		if team == nil {
+			continue
+		}
+		section := fmt.Sprintf("vlan%d", vlan)
+		err = sw.addUciSection(
+			session,
+			"network",
+			"interface",
+			section,
+			map[string]string{
+				"proto":   "static",
+				"device":  fmt.Sprintf("%s.%d", openWrtBridgeDevice, vlan),
+				"ipaddr":  fmt.Sprintf("10.%s.%d", teamPartialIp(team), switchTeamGatewayAddress),
+				"netmask": "255.255.255.0",
+			},
+		)
+		if err != nil {
+			sw.status = "ERROR"
+			return err
+		}
+		err = sw.addUciSection(
+			session,
+			"dhcp",
+			"dhcp",
+			section,
+			map[string]string{
+				"interface": section,
+				"start":     strconv.Itoa(openWrtDhcpPoolStart),
+				"limit":     strconv.Itoa(openWrtDhcpPoolLimit),
+				"leasetime": openWrtDhcpLeaseTime,
+			},
+		)
+		if err != nil {
+			sw.status = "ERROR"
+			return err
+		}
+		addedTeamVlans = true
+	}
+	if addedTeamVlans {
+		if err = sw.commitUciConfigs(session); err != nil {
+			sw.status = "ERROR"
+			return err
+		}
+	}
+
+	// Give some time for the configuration to take before another one can be attempted.
+	time.Sleep(sw.configBackoffDuration)
+
+	sw.status = "ACTIVE"
+	return nil
+}
+
+// Returns the dnsmasq DHCP leases and kernel ARP entries currently known to the switch.
+func (sw *OpenWrtSwitch) GetNeighbors() ([]NetworkNeighbor, error) {
+	session, err := sw.login()
+	if err != nil {
+		return nil, err
+	}
+
+	var neighbors []NetworkNeighbor
+	leases, err := sw.readFile(session, openWrtDhcpLeasesPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(leases, "\n") {
+		// Each lease is of the form "<expiry> <MAC address> <IP address> <hostname> <client ID>".
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		neighbors = append(
+			neighbors, NetworkNeighbor{IpAddress: fields[2], MacAddress: normalizeMacAddress(fields[1]), Source: "DHCP"},
+		)
+	}
+
+	arpTable, err := sw.readFile(session, openWrtArpTablePath)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(arpTable, "\n") {
+		// Each entry is of the form "<IP address> <HW type> <flags> <MAC address> <mask> <device>", following a header.
+		fields := strings.Fields(line)
+		if len(fields) < 4 || fields[2] != openWrtArpFlagComplete {
+			continue
+		}
+		neighbors = append(
+			neighbors, NetworkNeighbor{IpAddress: fields[0], MacAddress: normalizeMacAddress(fields[3]), Source: "ARP"},
+		)
+	}
+	return neighbors, nil
+}
+
+// Authenticates against the switch's ubus API and returns the resulting session ID.
+func (sw *OpenWrtSwitch) login() (string, error) {
+	var loginResult struct {
+		Session string `json:"ubus_rpc_session"`
+	}
+	err := sw.call(
+		openWrtNullSession,
+		"session",
+		"login",
+		map[string]any{"username": openWrtUsername, "password": sw.password, "timeout": openWrtSessionTimeoutSec},
+		&loginResult,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to log in to switch: %v", err)
+	}
+	if loginResult.Session == "" {
+		return "", fmt.Errorf("switch did not return a session ID")
+	}
+	return loginResult.Session, nil
+}
+
+// Deletes the given UCI section from the given config, ignoring the case where it doesn't exist.
+func (sw *OpenWrtSwitch) deleteUciSection(session, config, section string) error {
+	err := sw.call(session, "uci", "delete", map[string]any{"config": config, "section": section}, nil)
+	if ubusErr, ok := err.(*ubusStatusError); ok && ubusErr.status == openWrtStatusNotFound {
+		return nil
+	}
+	return err
+}
+
+// Creates a named UCI section of the given type in the given config, populated with the given option values.
+func (sw *OpenWrtSwitch) addUciSection(session, config, sectionType, name string, values map[string]string) error {
+	return sw.call(
+		session,
+		"uci",
+		"add",
+		map[string]any{"config": config, "type": sectionType, "name": name, "values": values},
+		nil,
+	)
+}
+
+// Commits the pending changes to the network and DHCP configs, which causes the switch to reload those services.
+func (sw *OpenWrtSwitch) commitUciConfigs(session string) error {
+	for _, config := range []string{"network", "dhcp"} {
+		if err := sw.call(session, "uci", "commit", map[string]any{"config": config}, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the contents of the given file on the switch, or an empty string if it doesn't exist.
+func (sw *OpenWrtSwitch) readFile(session, path string) (string, error) {
+	var fileResult struct {
+		Data string `json:"data"`
+	}
+	err := sw.call(session, "file", "read", map[string]any{"path": path}, &fileResult)
+	if ubusErr, ok := err.(*ubusStatusError); ok && ubusErr.status == openWrtStatusNotFound {
+		return "", nil
+	}
+	return fileResult.Data, err
+}
+
+// Represents a non-zero ubus status code returned as the first element of a call result.
+type ubusStatusError struct {
+	object string
+	method string
+	status int
+}
+
+func (err *ubusStatusError) Error() string {
+	return fmt.Sprintf("switch returned status %d for %s.%s", err.status, err.object, err.method)
+}
+
+// Invokes the given ubus object method on the switch and unmarshals the result data into the given value, if non-nil.
+func (sw *OpenWrtSwitch) call(session, object, method string, args map[string]any, result any) error {
+	request := ubusRequest{
+		JsonRpc: "2.0",
+		Id:      1,
+		Method:  "call",
+		Params:  []any{session, object, method, args},
+	}
+	jsonBody, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	httpClient := http.Client{Timeout: time.Second * openWrtHttpTimeoutSec}
+	httpResponse, err := httpClient.Post(sw.apiUrl, "application/json", bytes.NewReader(jsonBody))
+	if err != nil {
+		return err
+	}
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(httpResponse.Body)
+		return fmt.Errorf("switch returned HTTP status %d: %s", httpResponse.StatusCode, string(body))
+	}
+
+	var response ubusResponse
+	if err = json.NewDecoder(httpResponse.Body).Decode(&response); err != nil {
+		return fmt.Errorf("failed to parse switch response: %v", err)
+	}
+	if response.Error != nil {
+		return fmt.Errorf("switch returned error for %s.%s: %s", object, method, response.Error.Message)
+	}
+	if len(response.Result) == 0 {
+		return fmt.Errorf("switch returned an empty result for %s.%s", object, method)
+	}
+	var status int
+	if err = json.Unmarshal(response.Result[0], &status); err != nil {
+		return fmt.Errorf("failed to parse switch response status: %v", err)
+	}
+	if status != 0 {
+		return &ubusStatusError{object: object, method: method, status: status}
+	}
+	if result != nil && len(response.Result) > 1 {
+		if err = json.Unmarshal(response.Result[1], result); err != nil {
+			return fmt.Errorf("failed to parse switch response data: %v", err)
+		}
+	}
+	return nil
+}
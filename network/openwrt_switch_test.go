@@ -0,0 +1,271 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package network
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenWrtSwitch_ConfigureTeamEthernet(t *testing.T) {
+	server := newFakeUbusServer(t, "password")
+	defer server.Close()
+	sw := newTestOpenWrtSwitch(server, "password")
+	assert.Equal(t, "UNKNOWN", sw.GetStatus())
+
+	// Stale sections left over from a previous configuration should be removed.
+	server.sections["network"]["vlan20"] = map[string]string{".type": "interface", "ipaddr": "10.0.1.4"}
+	server.sections["dhcp"]["vlan20"] = map[string]string{".type": "dhcp", "interface": "vlan20"}
+	server.pending = copySections(server.sections)
+
+	// Should remove all previous VLANs and do nothing else if current configuration is blank.
+	assert.Nil(t, sw.ConfigureTeamEthernet([6]*model.Team{nil, nil, nil, nil, nil, nil}))
+	assert.Equal(t, "ACTIVE", sw.GetStatus())
+	assert.Empty(t, server.sections["network"])
+	assert.Empty(t, server.sections["dhcp"])
+	assert.Equal(t, map[string]int{"network": 1, "dhcp": 1}, server.commitCounts)
+
+	// Should configure one team if only one is present.
+	assert.Nil(t, sw.ConfigureTeamEthernet([6]*model.Team{nil, nil, nil, nil, {Id: 254}, nil}))
+	assert.Equal(t, "ACTIVE", sw.GetStatus())
+	assert.Equal(
+		t,
+		map[string]map[string]string{
+			"vlan50": {
+				".type":   "interface",
+				"proto":   "static",
+				"device":  "br-lan.50",
+				"ipaddr":  "10.2.54.4",
+				"netmask": "255.255.255.0",
+			},
+		},
+		server.sections["network"],
+	)
+	assert.Equal(
+		t,
+		map[string]map[string]string{
+			"vlan50": {".type": "dhcp", "interface": "vlan50", "start": "20", "limit": "180", "leasetime": "7d"},
+		},
+		server.sections["dhcp"],
+	)
+
+	// Should configure all teams if all are present.
+	assert.Nil(
+		t,
+		sw.ConfigureTeamEthernet([6]*model.Team{{Id: 1114}, {Id: 254}, {Id: 296}, {Id: 1503}, {Id: 1678}, {Id: 1538}}),
+	)
+	assert.Equal(t, "ACTIVE", sw.GetStatus())
+	if assert.Equal(t, 6, len(server.sections["network"])) {
+		assert.Equal(t, "10.11.14.4", server.sections["network"]["vlan10"]["ipaddr"])
+		assert.Equal(t, "10.2.54.4", server.sections["network"]["vlan20"]["ipaddr"])
+		assert.Equal(t, "10.2.96.4", server.sections["network"]["vlan30"]["ipaddr"])
+		assert.Equal(t, "10.15.3.4", server.sections["network"]["vlan40"]["ipaddr"])
+		assert.Equal(t, "10.16.78.4", server.sections["network"]["vlan50"]["ipaddr"])
+		assert.Equal(t, "10.15.38.4", server.sections["network"]["vlan60"]["ipaddr"])
+		assert.Equal(t, "br-lan.60", server.sections["network"]["vlan60"]["device"])
+	}
+	assert.Equal(t, 6, len(server.sections["dhcp"]))
+	assert.Equal(t, 3, server.loginCount)
+}
+
+func TestOpenWrtSwitch_ConfigureTeamEthernetErrors(t *testing.T) {
+	server := newFakeUbusServer(t, "password")
+	defer server.Close()
+
+	// Wrong password.
+	sw := newTestOpenWrtSwitch(server, "wrongpassword")
+	err := sw.ConfigureTeamEthernet([6]*model.Team{{Id: 254}, nil, nil, nil, nil, nil})
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "failed to log in to switch")
+	}
+	assert.Equal(t, "ERROR", sw.GetStatus())
+
+	// Failure while adding a section.
+	sw = newTestOpenWrtSwitch(server, "password")
+	server.failMethod = "add"
+	err = sw.ConfigureTeamEthernet([6]*model.Team{{Id: 254}, nil, nil, nil, nil, nil})
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "switch returned status 6 for uci.add", err.Error())
+	}
+	assert.Equal(t, "ERROR", sw.GetStatus())
+
+	// Non-JSON-RPC error from the web server.
+	server.failMethod = ""
+	server.httpError = true
+	err = sw.ConfigureTeamEthernet([6]*model.Team{{Id: 254}, nil, nil, nil, nil, nil})
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "switch returned HTTP status 500")
+	}
+	assert.Equal(t, "ERROR", sw.GetStatus())
+}
+
+func TestOpenWrtSwitch_GetNeighbors(t *testing.T) {
+	server := newFakeUbusServer(t, "password")
+	defer server.Close()
+	sw := newTestOpenWrtSwitch(server, "password")
+
+	// Missing files should be treated as empty.
+	neighbors, err := sw.GetNeighbors()
+	assert.Nil(t, err)
+	assert.Empty(t, neighbors)
+
+	server.files["/tmp/dhcp.leases"] = "1700000000 00:11:22:33:44:55 10.2.54.20 driverstation 01:00:11:22:33:44:55\n" +
+		"1700000100 AA:BB:CC:DD:EE:FF 10.11.14.21 * *\n"
+	server.files["/proc/net/arp"] = "IP address       HW type     Flags       HW address            Mask     Device\n" +
+		"10.2.54.1        0x1         0x2         00:0a:0b:0c:0d:0e     *        br-lan.50\n" +
+		"10.2.54.2        0x1         0x0         00:00:00:00:00:00     *        br-lan.50\n"
+	neighbors, err = sw.GetNeighbors()
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		[]NetworkNeighbor{
+			{IpAddress: "10.2.54.20", MacAddress: "00:11:22:33:44:55", Source: "DHCP"},
+			{IpAddress: "10.11.14.21", MacAddress: "aa:bb:cc:dd:ee:ff", Source: "DHCP"},
+			{IpAddress: "10.2.54.1", MacAddress: "00:0a:0b:0c:0d:0e", Source: "ARP"},
+		},
+		neighbors,
+	)
+
+	server.failMethod = "read"
+	_, err = sw.GetNeighbors()
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "switch returned status 6 for file.read", err.Error())
+	}
+}
+
+func newTestOpenWrtSwitch(server *fakeUbusServer, password string) *OpenWrtSwitch {
+	sw := NewOpenWrtSwitch("dummy", password)
+	sw.apiUrl = server.URL + "/ubus"
+	sw.configBackoffDuration = time.Millisecond
+	sw.configPauseDuration = time.Millisecond
+	return sw
+}
+
+// Fake implementation of the subset of the OpenWRT ubus JSON-RPC API used by the switch driver.
+type fakeUbusServer struct {
+	*httptest.Server
+	t            *testing.T
+	password     string
+	sessionId    string
+	sections     map[string]map[string]map[string]string
+	pending      map[string]map[string]map[string]string
+	commitCounts map[string]int
+	files        map[string]string
+	loginCount   int
+	failMethod   string
+	httpError    bool
+}
+
+func newFakeUbusServer(t *testing.T, password string) *fakeUbusServer {
+	server := &fakeUbusServer{
+		t:            t,
+		password:     password,
+		sessionId:    "0123456789abcdef0123456789abcdef",
+		sections:     map[string]map[string]map[string]string{"network": {}, "dhcp": {}},
+		commitCounts: make(map[string]int),
+		files:        make(map[string]string),
+	}
+	server.pending = copySections(server.sections)
+	server.Server = httptest.NewServer(http.HandlerFunc(server.handle))
+	return server
+}
+
+func (server *fakeUbusServer) handle(w http.ResponseWriter, r *http.Request) {
+	assert.Equal(server.t, "/ubus", r.URL.Path)
+	if server.httpError {
+		http.Error(w, "oh noes", 500)
+		return
+	}
+	var request struct {
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	assert.Nil(server.t, json.NewDecoder(r.Body).Decode(&request))
+	assert.Equal(server.t, "call", request.Method)
+	assert.Equal(server.t, 4, len(request.Params))
+	var session, object, method string
+	var args map[string]any
+	_ = json.Unmarshal(request.Params[0], &session)
+	_ = json.Unmarshal(request.Params[1], &object)
+	_ = json.Unmarshal(request.Params[2], &method)
+	_ = json.Unmarshal(request.Params[3], &args)
+
+	respond := func(result ...any) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": 1, "result": result})
+	}
+
+	if object == "session" && method == "login" {
+		server.loginCount++
+		if args["username"] != "root" || args["password"] != server.password {
+			respond(6)
+			return
+		}
+		respond(0, map[string]any{"ubus_rpc_session": server.sessionId})
+		return
+	}
+	if session != server.sessionId {
+		_ = json.NewEncoder(w).Encode(
+			map[string]any{"jsonrpc": "2.0", "id": 1, "error": map[string]any{"code": -32002, "message": "Access denied"}},
+		)
+		return
+	}
+	if object == "file" && method == "read" && method != server.failMethod {
+		if data, ok := server.files[args["path"].(string)]; ok {
+			respond(0, map[string]any{"data": data})
+		} else {
+			respond(4)
+		}
+		return
+	}
+	if object != "uci" || method == server.failMethod {
+		respond(6)
+		return
+	}
+
+	config, _ := args["config"].(string)
+	switch method {
+	case "delete":
+		section, _ := args["section"].(string)
+		if _, ok := server.pending[config][section]; !ok {
+			respond(4)
+			return
+		}
+		delete(server.pending[config], section)
+	case "add":
+		name, _ := args["name"].(string)
+		values := map[string]string{".type": args["type"].(string)}
+		for key, value := range args["values"].(map[string]any) {
+			values[key] = value.(string)
+		}
+		server.pending[config][name] = values
+	case "commit":
+		server.commitCounts[config]++
+		server.sections[config] = copySections(server.pending)[config]
+	default:
+		respond(3)
+		return
+	}
+	respond(0)
+}
+
+func copySections(configs map[string]map[string]map[string]string) map[string]map[string]map[string]string {
+	sectionsCopy := make(map[string]map[string]map[string]string)
+	for config, sections := range configs {
+		sectionsCopy[config] = make(map[string]map[string]string)
+		for name, values := range sections {
+			valuesCopy := make(map[string]string)
+			for key, value := range values {
+				valuesCopy[key] = value
+			}
+			sectionsCopy[config][name] = valuesCopy
+		}
+	}
+	return sectionsCopy
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Methods for probing whether a given host is reachable on the field network.
+
+package network
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// Ports that are commonly open on a team's robot radio or roboRIO, used by the TCP prober.
+var defaultTcpProbePorts = []int{80, 1740}
+
+type Prober interface {
+	// Returns true if the host at the given IP address responded to the probe.
+	Probe(address string) bool
+}
+
+// Prober that sends a single ICMP echo request using the operating system's ping utility, since opening a raw ICMP
+// socket directly would require elevated privileges.
+type IcmpProber struct {
+	Timeout time.Duration
+}
+
+// Prober that attempts TCP connections to each of the given ports. A refused connection still counts as reachable,
+// since it means that the host itself responded.
+type TcpProber struct {
+	Ports   []int
+	Timeout time.Duration
+}
+
+// Prober that considers a host reachable if any of its constituent probers do.
+type AnyProber []Prober
+
+// Returns the prober used for the field network diagnostics, which tries ICMP first and falls back to TCP for hosts
+// that are configured to not respond to pings.
+func NewDefaultProber(timeout time.Duration) Prober {
+	return AnyProber{&IcmpProber{Timeout: timeout}, &TcpProber{Ports: defaultTcpProbePorts, Timeout: timeout}}
+}
+
+func (prober *IcmpProber) Probe(address string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), prober.Timeout+time.Second)
+	defer cancel()
+	return exec.CommandContext(ctx, "ping", pingArgs(address, prober.Timeout)...).Run() == nil
+}
+
+func (prober *TcpProber) Probe(address string) bool {
+	for _, port := range prober.Ports {
+		conn, err := net.DialTimeout("tcp", net.JoinHostPort(address, strconv.Itoa(port)), prober.Timeout)
+		if err == nil {
+			conn.Close()
+			return true
+		}
+		if errors.Is(err, syscall.ECONNREFUSED) {
+			return true
+		}
+	}
+	return false
+}
+
+func (prober AnyProber) Probe(address string) bool {
+	for _, constituent := range prober {
+		if constituent.Probe(address) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the arguments for sending a single ping with the given timeout, which vary by platform.
+func pingArgs(address string, timeout time.Duration) []string {
+	switch runtime.GOOS {
+	case "windows":
+		return []string{"-n", "1", "-w", strconv.FormatInt(timeout.Milliseconds(), 10), address}
+	case "darwin":
+		return []string{"-c", "1", "-W", strconv.FormatInt(timeout.Milliseconds(), 10), address}
+	default:
+		return []string{"-c", "1", "-W", strconv.Itoa(max(1, int(timeout.Seconds()))), address}
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Methods for programming a team's robot radio that is plugged into the radio configuration kiosk port, so that it
+// will connect to the field access point using the team's SSID and WPA key.
+
+package network
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Team254/cheesy-arena/model"
+)
+
+const radioKioskHttpTimeoutSec = 10
+
+type RadioKiosk interface {
+	// Sets the address and API password of the radio attached to the kiosk port.
+	SetSettings(address, password string)
+
+	// Pushes the given team's number, SSID, and WPA key to the radio attached to the kiosk port.
+	ConfigureRadio(team *model.Team) error
+}
+
+// Radio kiosk driver for Vivid-Hosting VH-109 robot radios, via the HTTP API that they expose on their Ethernet port.
+type Vh109RadioKiosk struct {
+	address  string
+	password string
+}
+
+type radioConfigurationRequest struct {
+	Mode       string `json:"mode"`
+	TeamNumber int    `json:"teamNumber"`
+	Ssid       string `json:"ssid"`
+	WpaKey     string `json:"wpaKey"`
+}
+
+func (kiosk *Vh109RadioKiosk) SetSettings(address, password string) {
+	kiosk.address = address
+	kiosk.password = password
+}
+
+// Calls the radio's API to configure it as a robot radio for the given team.
+func (kiosk *Vh109RadioKiosk) ConfigureRadio(team *model.Team) error {
+	if kiosk.address == "" {
+		return fmt.Errorf("radio kiosk address is not configured")
+	}
+	if team.WpaKey == "" {
+		return fmt.Errorf("team %d does not have a WPA key", team.Id)
+	}
+
+	request := radioConfigurationRequest{
+		Mode:       "ROBOT_RADIO",
+		TeamNumber: team.Id,
+		Ssid:       strconv.Itoa(team.Id),
+		WpaKey:     team.WpaKey,
+	}
+	jsonBody, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	httpRequest, err := http.NewRequest(
+		"POST", fmt.Sprintf("http://%s/configuration", kiosk.address), bytes.NewReader(jsonBody),
+	)
+	if err != nil {
+		return err
+	}
+	httpRequest.Header.Add("Content-Type", "application/json")
+	if kiosk.password != "" {
+		httpRequest.Header.Add("Authorization", fmt.Sprintf("Bearer %s", kiosk.password))
+	}
+
+	httpClient := http.Client{Timeout: time.Second * radioKioskHttpTimeoutSec}
+	httpResponse, err := httpClient.Do(httpRequest)
+	if err != nil {
+		return fmt.Errorf("failed to configure radio: %v", err)
+	}
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(httpResponse.Body)
+		return fmt.Errorf("radio returned status %d: %s", httpResponse.StatusCode, string(body))
+	}
+	return nil
+}
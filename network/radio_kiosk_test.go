@@ -0,0 +1,58 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package network
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVh109RadioKiosk_ConfigureRadio(t *testing.T) {
+	var request radioConfigurationRequest
+	var authorization string
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "POST", r.Method)
+				assert.Equal(t, "/configuration", r.URL.Path)
+				authorization = r.Header.Get("Authorization")
+				if authorization != "Bearer password1" {
+					http.Error(w, "unauthorized", 401)
+					return
+				}
+				assert.Nil(t, json.NewDecoder(r.Body).Decode(&request))
+				w.WriteHeader(202)
+			},
+		),
+	)
+	defer server.Close()
+
+	kiosk := new(Vh109RadioKiosk)
+	team := &model.Team{Id: 254, WpaKey: "11111111"}
+	err := kiosk.ConfigureRadio(team)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "radio kiosk address is not configured", err.Error())
+	}
+
+	kiosk.SetSettings(strings.TrimPrefix(server.URL, "http://"), "password1")
+	assert.Nil(t, kiosk.ConfigureRadio(team))
+	assert.Equal(t, radioConfigurationRequest{"ROBOT_RADIO", 254, "254", "11111111"}, request)
+
+	err = kiosk.ConfigureRadio(&model.Team{Id: 1114})
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "team 1114 does not have a WPA key", err.Error())
+	}
+
+	kiosk.SetSettings(strings.TrimPrefix(server.URL, "http://"), "wrongpassword")
+	err = kiosk.ConfigureRadio(team)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "radio returned status 401: unauthorized\n", err.Error())
+	}
+}
@@ -1,25 +1,21 @@
 // Copyright 2014 Team 254. All Rights Reserved.
 // Author: pat@patfairbank.com (Patrick Fairbank)
 //
-// Methods for configuring a Cisco Switch 3500-series switch for team VLANs.
+// Common interface and helpers for the supported switches that isolate each team to its own VLAN.
 
 package network
 
 import (
-	"bufio"
-	"bytes"
 	"fmt"
+	"strings"
+
 	"github.com/Team254/cheesy-arena/model"
-	"net"
-	"sync"
-	"time"
 )
 
 const (
 	switchConfigBackoffDurationSec = 5
 	switchConfigPauseDurationSec   = 2
 	switchTeamGatewayAddress       = 4
-	switchTelnetPort               = 23
 )
 
 const (
@@ -31,136 +27,53 @@ const (
 	blue3Vlan = 60
 )
 
-type Switch struct {
-	address               string
-	port                  int
-	password              string
-	mutex                 sync.Mutex
-	configBackoffDuration time.Duration
-	configPauseDuration   time.Duration
-	Status                string
-}
+// VLANs corresponding to each of the six alliance stations, in the same order as the team arrays passed to the switch.
+var teamVlans = [6]int{red1Vlan, red2Vlan, red3Vlan, blue1Vlan, blue2Vlan, blue3Vlan}
 
 var ServerIpAddress = "10.0.100.5" // The DS will try to connect to this address only.
 
-func NewSwitch(address, password string) *Switch {
-	return &Switch{
-		address:               address,
-		port:                  switchTelnetPort,
-		password:              password,
-		configBackoffDuration: switchConfigBackoffDurationSec * time.Second,
-		configPauseDuration:   switchConfigPauseDurationSec * time.Second,
-		Status:                "UNKNOWN",
-	}
-}
+type Switch interface {
+	// Sets up wired networks for the given set of teams.
+	ConfigureTeamEthernet(teams [6]*model.Team) error
 
-// Sets up wired networks for the given set of teams.
-func (sw *Switch) ConfigureTeamEthernet(teams [6]*model.Team) error {
-	// Make sure multiple configurations aren't being set at the same time.
-	sw.mutex.Lock()
-	defer sw.mutex.Unlock()
-	sw.Status = "CONFIGURING"
+	// Returns the current status of the switch configuration (e.g. "ACTIVE", "CONFIGURING", "ERROR").
+	GetStatus() string
 
-	// Remove old team VLANs to reset the switch state.
-	removeTeamVlansCommand := ""
-	for vlan := 10; vlan <= 60; vlan += 10 {
-		removeTeamVlansCommand += fmt.Sprintf(
-			"interface Vlan%d\nno ip address\nno ip dhcp pool dhcp%d\n", vlan, vlan,
-		)
-	}
-	_, err := sw.runConfigCommand(removeTeamVlansCommand)
-	if err != nil {
-		sw.Status = "ERROR"
-		return err
-	}
-	time.Sleep(sw.configPauseDuration)
+	// Returns the ARP entries and DHCP leases currently known to the switch across the team VLANs.
+	GetNeighbors() ([]NetworkNeighbor, error)
+}
 
-	// Create the new team VLANs.
-	addTeamVlansCommand := ""
-	addTeamVlan := func(team *model.Team, vlan int) {
-		if team == nil {
-			return
-		}
-		teamPartialIp := fmt.Sprintf("%d.%d", team.Id/100, team.Id%100)
-		addTeamVlansCommand += fmt.Sprintf(
-			"ip dhcp excluded-address 10.%s.1 10.%s.19\n"+
-				"ip dhcp excluded-address 10.%s.200 10.%s.254\n"+
-				"ip dhcp pool dhcp%d\n"+
-				"network 10.%s.0 255.255.255.0\n"+
-				"default-router 10.%s.%d\n"+
-				"lease 7\n"+
-				"interface Vlan%d\nip address 10.%s.%d 255.255.255.0\n",
-			teamPartialIp,
-			teamPartialIp,
-			teamPartialIp,
-			teamPartialIp,
-			vlan,
-			teamPartialIp,
-			teamPartialIp,
-			switchTeamGatewayAddress,
-			vlan,
-			teamPartialIp,
-			switchTeamGatewayAddress,
-		)
-	}
-	addTeamVlan(teams[0], red1Vlan)
-	addTeamVlan(teams[1], red2Vlan)
-	addTeamVlan(teams[2], red3Vlan)
-	addTeamVlan(teams[3], blue1Vlan)
-	addTeamVlan(teams[4], blue2Vlan)
-	addTeamVlan(teams[5], blue3Vlan)
-	if len(addTeamVlansCommand) > 0 {
-		_, err = sw.runConfigCommand(addTeamVlansCommand)
-		if err != nil {
-			sw.Status = "ERROR"
-			return err
-		}
+// Creates a switch driver of the given type, or returns an error if the type is not supported.
+func NewSwitch(switchType model.SwitchType, address, password string) (Switch, error) {
+	switch switchType {
+	case model.CiscoSwitch:
+		return NewCiscoSwitch(address, password), nil
+	case model.OpenWrtSwitch:
+		return NewOpenWrtSwitch(address, password), nil
+	default:
+		return nil, fmt.Errorf("invalid switch type: %v", switchType)
 	}
+}
 
-	// Give some time for the configuration to take before another one can be attempted.
-	time.Sleep(sw.configBackoffDuration)
-
-	sw.Status = "ACTIVE"
-	return nil
+// Returns the first two octets of the team's 10.TE.AM.x subnet as a string (e.g. "2.54" for team 254).
+func teamPartialIp(team *model.Team) string {
+	return fmt.Sprintf("%d.%d", team.Id/100, team.Id%100)
 }
 
-// Logs into the switch via Telnet and runs the given command in user exec mode. Reads the output and
-// returns it as a string.
-func (sw *Switch) runCommand(command string) (string, error) {
-	// Open a Telnet connection to the switch.
-	conn, err := net.Dial("tcp", fmt.Sprintf("%s:%d", sw.address, sw.port))
-	if err != nil {
-		return "", err
+// Converts the given MAC address or Ethernet DHCP client ID from any of the common notations (e.g. "0011.2233.4455" or
+// "01-00-11-22-33-44-55") to colon-separated lowercase form, or returns it unchanged if it can't be parsed.
+func normalizeMacAddress(address string) string {
+	hexDigits := strings.ToLower(strings.NewReplacer(".", "", ":", "", "-", "").Replace(address))
+	if len(hexDigits) == 14 && strings.HasPrefix(hexDigits, "01") {
+		// Strip the hardware type prefix from a DHCP client ID.
+		hexDigits = hexDigits[2:]
 	}
-	defer conn.Close()
-
-	// Login to the AP, send the command, and log out all at once.
-	writer := bufio.NewWriter(conn)
-	_, err = writer.WriteString(
-		fmt.Sprintf(
-			"%s\nenable\n%s\nterminal length 0\n%sexit\n", sw.password, sw.password,
-			command,
-		),
-	)
-	if err != nil {
-		return "", err
+	if len(hexDigits) != 12 {
+		return address
 	}
-	err = writer.Flush()
-	if err != nil {
-		return "", err
+	octets := make([]string, 6)
+	for i := range octets {
+		octets[i] = hexDigits[2*i : 2*i+2]
 	}
-
-	// Read the response.
-	var reader bytes.Buffer
-	_, err = reader.ReadFrom(conn)
-	if err != nil {
-		return "", err
-	}
-	return reader.String(), nil
-}
-
-// Logs into the switch via Telnet and runs the given command in global configuration mode. Reads the output
-// and returns it as a string.
-func (sw *Switch) runConfigCommand(command string) (string, error) {
-	return sw.runCommand(fmt.Sprintf("config terminal\n%send\n", command))
+	return strings.Join(octets, ":")
 }
@@ -12,8 +12,8 @@ import (
 	"testing"
 )
 
-func TestAccessPoint_ConfigureTeamWifi(t *testing.T) {
-	var ap AccessPoint
+func TestVh113AccessPoint_ConfigureTeamWifi(t *testing.T) {
+	var ap Vh113AccessPoint
 	var request configurationRequest
 	wifiStatuses := [6]*TeamWifiStatus{{}, {}, {}, {}, {}, {}}
 	ap.SetSettings("dummy", "password1", 123, true, wifiStatuses)
@@ -84,11 +84,11 @@ func TestAccessPoint_ConfigureTeamWifi(t *testing.T) {
 	if assert.NotNil(t, err) {
 		assert.Contains(t, err.Error(), "returned status 507: oh noes")
 	}
-	assert.Equal(t, "CONFIGURING", ap.Status)
+	assert.Equal(t, "CONFIGURING", ap.GetStatus())
 }
 
-func TestAccessPoint_updateMonitoring(t *testing.T) {
-	var ap AccessPoint
+func TestVh113AccessPoint_updateMonitoring(t *testing.T) {
+	var ap Vh113AccessPoint
 	wifiStatuses := [6]*TeamWifiStatus{{}, {}, {}, {}, {}, {}}
 	ap.SetSettings("dummy", "password2", 123, true, wifiStatuses)
 
@@ -120,7 +120,7 @@ func TestAccessPoint_updateMonitoring(t *testing.T) {
 	// All stations assigned.
 	assert.Nil(t, ap.updateMonitoring())
 	assert.Equal(t, 123, ap.channel) // Should not have changed to reflect the radio API.
-	assert.Equal(t, "ACTIVE", ap.Status)
+	assert.Equal(t, "ACTIVE", ap.GetStatus())
 	assert.Equal(t, TeamWifiStatus{254, true, 4, 1, 2, 3, 4}, *wifiStatuses[0])
 	assert.Equal(t, TeamWifiStatus{1114, false, 8, 5, 6, 7, 0}, *wifiStatuses[1])
 	assert.Equal(t, TeamWifiStatus{469, true, 12, 9, 10, 11, 1}, *wifiStatuses[2])
@@ -139,7 +139,7 @@ func TestAccessPoint_updateMonitoring(t *testing.T) {
 		"blue3": nil,
 	}
 	assert.Nil(t, ap.updateMonitoring())
-	assert.Equal(t, "CONFIGURING", ap.Status)
+	assert.Equal(t, "CONFIGURING", ap.GetStatus())
 	assert.Equal(t, TeamWifiStatus{}, *wifiStatuses[0])
 	assert.Equal(t, TeamWifiStatus{}, *wifiStatuses[1])
 	assert.Equal(t, TeamWifiStatus{469, true, 12, 9, 10, 11, 1}, *wifiStatuses[2])
@@ -161,11 +161,11 @@ func TestAccessPoint_updateMonitoring(t *testing.T) {
 	if assert.NotNil(t, err) {
 		assert.Contains(t, err.Error(), "returned status 404: gosh darn")
 	}
-	assert.Equal(t, "ERROR", ap.Status)
+	assert.Equal(t, "ERROR", ap.GetStatus())
 }
 
-func TestAccessPoint_statusMatchesLastConfiguration(t *testing.T) {
-	var ap AccessPoint
+func TestVh113AccessPoint_statusMatchesLastConfiguration(t *testing.T) {
+	var ap Vh113AccessPoint
 	wifiStatuses := [6]*TeamWifiStatus{{}, {}, {}, {}, {}, {}}
 	ap.SetSettings("dummy", "", 123, true, wifiStatuses)
 
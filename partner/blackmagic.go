@@ -1,30 +1,71 @@
 // Copyright 2024 Team 254. All Rights Reserved.
 // Author: pat@patfairbank.com (Patrick Fairbank)
 //
-// Client for interfacing with one or more Blackmagic HyperDeck devices to automatically record matches.
+// Client for interfacing with one or more Blackmagic HyperDeck devices to automatically record matches, using the
+// HyperDeck Ethernet protocol.
 
 package partner
 
 import (
+	"bufio"
 	"fmt"
 	"log"
 	"net"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
 const (
-	blackmagicPort             = 9993
-	blackmagicConnectTimeoutMs = 100
-	blackmagicStopDelaySec     = 10
+	blackmagicPort                = 9993
+	blackmagicConnectTimeoutMs    = 100
+	blackmagicResponseTimeoutMs   = 1000
+	blackmagicStopDelaySec        = 10
+	blackmagicPollPeriodSec       = 2
+	blackmagicLowRecordingTimeSec = 10 * 60
 )
 
 type BlackmagicClient struct {
 	deviceAddresses []string
+	deviceStatuses  map[string]*BlackmagicDeviceStatus
+	recording       bool
+	mutex           sync.Mutex
+}
+
+// Latest known state of a HyperDeck device, for display on the match play page.
+type BlackmagicDeviceStatus struct {
+	Address            string
+	Connected          bool
+	TransportStatus    string
+	RecordingTimeKnown bool
+	RecordingTimeSec   int
+	Warning            string
+}
+
+// Clip that a HyperDeck device started recording at the beginning of a match.
+type BlackmagicRecording struct {
+	Address  string
+	ClipName string
+	Timecode string
+}
+
+// Parsed response to a HyperDeck command.
+type blackmagicResponse struct {
+	code   int
+	text   string
+	params map[string]string
 }
 
 // Creates a new Blackmagic client with the given device addresses as a comma-separated string.
 func NewBlackmagicClient(addresses string) *BlackmagicClient {
+	client := new(BlackmagicClient)
+	client.SetAddresses(addresses)
+	return client
+}
+
+// Sets the device addresses as a comma-separated string. Each address may optionally include a port.
+func (client *BlackmagicClient) SetAddresses(addresses string) {
 	var deviceAddresses []string
 	for _, address := range strings.Split(addresses, ",") {
 		trimmedAddress := strings.TrimSpace(address)
@@ -32,34 +73,253 @@ func NewBlackmagicClient(addresses string) *BlackmagicClient {
 			deviceAddresses = append(deviceAddresses, trimmedAddress)
 		}
 	}
-	return &BlackmagicClient{deviceAddresses: deviceAddresses}
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.deviceAddresses = deviceAddresses
+	client.deviceStatuses = make(map[string]*BlackmagicDeviceStatus)
+	for _, address := range deviceAddresses {
+		client.deviceStatuses[address] = &BlackmagicDeviceStatus{Address: address}
+	}
 }
 
-// Starts recording across all devices.
-func (client *BlackmagicClient) StartRecording() {
-	client.sendCommand("record")
+// Loops indefinitely to poll the transport and disk space status of all devices.
+func (client *BlackmagicClient) Run() {
+	for {
+		client.updateStatuses()
+		time.Sleep(blackmagicPollPeriodSec * time.Second)
+	}
+}
+
+// Returns the latest status of each device, in the order in which they were configured.
+func (client *BlackmagicClient) GetStatuses() []BlackmagicDeviceStatus {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	statuses := make([]BlackmagicDeviceStatus, len(client.deviceAddresses))
+	for i, address := range client.deviceAddresses {
+		statuses[i] = *client.deviceStatuses[address]
+	}
+	return statuses
+}
+
+// Starts recording across all devices, naming the clip after the given match, and returns the clip recorded by each
+// device that accepted the command. Logs a warning for any device that isn't recording afterward.
+func (client *BlackmagicClient) StartRecording(clipName string) []BlackmagicRecording {
+	client.mutex.Lock()
+	client.recording = true
+	deviceAddresses := client.deviceAddresses
+	client.mutex.Unlock()
+
+	command := "record"
+	if clipName != "" {
+		command = fmt.Sprintf("record: name: %s", clipName)
+	}
+	var recordings []BlackmagicRecording
+	for _, address := range deviceAddresses {
+		recording := BlackmagicRecording{Address: address, ClipName: clipName}
+		err := withBlackmagicConnection(
+			address,
+			func(conn *blackmagicConnection) error {
+				if _, err := conn.command(command); err != nil {
+					return err
+				}
+
+				// The timecode at which the recording started allows the match to be located within a longer clip.
+				transportInfo, err := conn.command("transport info")
+				if err != nil {
+					return err
+				}
+				recording.Timecode = transportInfo.params["timecode"]
+				return nil
+			},
+		)
+		if err != nil {
+			log.Printf("Failed to send '%s' command to Blackmagic device at %s: %v", command, address, err)
+			continue
+		}
+		recordings = append(recordings, recording)
+	}
+
+	client.updateStatuses()
+	for _, status := range client.GetStatuses() {
+		if status.Warning != "" {
+			log.Printf("Warning: Blackmagic device at %s is not ready for match %s: %s", status.Address, clipName,
+				status.Warning)
+		}
+	}
+	return recordings
 }
 
 // Stops recording across all devices after a delay.
 func (client *BlackmagicClient) StopRecording() {
 	time.Sleep(blackmagicStopDelaySec * time.Second)
+	client.mutex.Lock()
+	client.recording = false
+	client.mutex.Unlock()
 	client.sendCommand("stop")
+	client.updateStatuses()
 }
 
 // Connects to all devices and executes the given command.
 func (client *BlackmagicClient) sendCommand(command string) {
-	for _, address := range client.deviceAddresses {
-		conn, err := net.DialTimeout(
-			"tcp", fmt.Sprintf("%s:%d", address, blackmagicPort), blackmagicConnectTimeoutMs*time.Millisecond,
+	client.mutex.Lock()
+	deviceAddresses := client.deviceAddresses
+	client.mutex.Unlock()
+
+	for _, address := range deviceAddresses {
+		err := withBlackmagicConnection(
+			address,
+			func(conn *blackmagicConnection) error {
+				_, err := conn.command(command)
+				return err
+			},
 		)
 		if err != nil {
-			log.Printf("Failed to connect to Blackmagic device at %s: %v", address, err)
+			log.Printf("Failed to send '%s' command to Blackmagic device at %s: %v", command, address, err)
+		}
+	}
+}
+
+// Queries each device for its transport and slot status and updates the cached statuses.
+func (client *BlackmagicClient) updateStatuses() {
+	client.mutex.Lock()
+	deviceAddresses := client.deviceAddresses
+	client.mutex.Unlock()
+
+	for _, address := range deviceAddresses {
+		status := BlackmagicDeviceStatus{Address: address}
+		err := withBlackmagicConnection(
+			address,
+			func(conn *blackmagicConnection) error {
+				transportInfo, err := conn.command("transport info")
+				if err != nil {
+					return err
+				}
+				status.TransportStatus = transportInfo.params["status"]
+
+				slotInfo, err := conn.command("slot info")
+				if err != nil {
+					return err
+				}
+				// Some slot states (e.g. no media inserted) omit the remaining recording time entirely.
+				if recordingTimeSec, err := strconv.Atoi(slotInfo.params["recording time"]); err == nil {
+					status.RecordingTimeKnown = true
+					status.RecordingTimeSec = recordingTimeSec
+				}
+				return nil
+			},
+		)
+		status.Connected = err == nil
+
+		client.mutex.Lock()
+		if _, ok := client.deviceStatuses[address]; ok {
+			status.Warning = client.getWarning(&status)
+			client.deviceStatuses[address] = &status
+		}
+		client.mutex.Unlock()
+	}
+}
+
+// Returns a description of any problem with the given device status, or the empty string if there is none. Must be
+// called with the mutex held.
+func (client *BlackmagicClient) getWarning(status *BlackmagicDeviceStatus) string {
+	if !status.Connected {
+		return "Not connected"
+	}
+	if client.recording && status.TransportStatus != "record" {
+		return "Not recording"
+	}
+	if status.RecordingTimeKnown && status.RecordingTimeSec < blackmagicLowRecordingTimeSec {
+		return fmt.Sprintf("Low disk space (%d min left)", status.RecordingTimeSec/60)
+	}
+	return ""
+}
+
+// A single command session with a HyperDeck device.
+type blackmagicConnection struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// Connects to the given device, consumes its connection banner and runs the given function, always closing the
+// connection afterward.
+func withBlackmagicConnection(address string, f func(conn *blackmagicConnection) error) error {
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, strconv.Itoa(blackmagicPort))
+	}
+	conn, err := net.DialTimeout("tcp", address, blackmagicConnectTimeoutMs*time.Millisecond)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	connection := &blackmagicConnection{conn: conn, reader: bufio.NewReader(conn)}
+	conn.SetDeadline(time.Now().Add(blackmagicResponseTimeoutMs * time.Millisecond))
+	banner, err := connection.readResponse()
+	if err != nil {
+		return err
+	}
+	if banner.code != 500 {
+		return fmt.Errorf("unexpected connection response: %d %s", banner.code, banner.text)
+	}
+	return f(connection)
+}
+
+// Sends the given command and returns its response, or an error if the device reports a failure.
+func (connection *blackmagicConnection) command(command string) (*blackmagicResponse, error) {
+	connection.conn.SetDeadline(time.Now().Add(blackmagicResponseTimeoutMs * time.Millisecond))
+	if _, err := fmt.Fprint(connection.conn, command+"\n"); err != nil {
+		return nil, err
+	}
+	for {
+		response, err := connection.readResponse()
+		if err != nil {
+			return nil, err
+		}
+		if response.code >= 500 {
+			// Skip asynchronous notifications; they aren't a response to the command.
 			continue
 		}
-		defer conn.Close()
-		_, err = fmt.Fprint(conn, command+"\n")
+		if response.code < 200 || response.code >= 300 {
+			return response, fmt.Errorf("device returned %d %s", response.code, response.text)
+		}
+		return response, nil
+	}
+}
+
+// Reads a single- or multi-line response from the device. Multi-line responses have a status line ending in a colon,
+// followed by "key: value" lines and terminated by a blank line.
+func (connection *blackmagicConnection) readResponse() (*blackmagicResponse, error) {
+	line, err := connection.readLine()
+	if err != nil {
+		return nil, err
+	}
+	codeString, text, _ := strings.Cut(line, " ")
+	code, err := strconv.Atoi(codeString)
+	if err != nil {
+		return nil, fmt.Errorf("invalid response line %q", line)
+	}
+	response := &blackmagicResponse{code: code, text: strings.TrimSuffix(text, ":"), params: map[string]string{}}
+	if !strings.HasSuffix(text, ":") {
+		return response, nil
+	}
+	for {
+		line, err = connection.readLine()
 		if err != nil {
-			log.Printf("Failed to send '%s' command to Blackmagic device at %s: %v", command, address, err)
+			return nil, err
 		}
+		if line == "" {
+			return response, nil
+		}
+		key, value, _ := strings.Cut(line, ":")
+		response.params[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+}
+
+func (connection *blackmagicConnection) readLine() (string, error) {
+	line, err := connection.reader.ReadString('\n')
+	if err != nil {
+		return "", err
 	}
+	return strings.TrimRight(line, "\r\n"), nil
 }
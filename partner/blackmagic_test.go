@@ -4,7 +4,12 @@
 package partner
 
 import (
+	"bufio"
+	"fmt"
 	"github.com/stretchr/testify/assert"
+	"net"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -30,3 +35,180 @@ func TestNewBlackmagicClient(t *testing.T) {
 		assert.Equal(t, "5.6.7.8", client.deviceAddresses[1])
 	}
 }
+
+// Fake HyperDeck device that speaks enough of the Ethernet protocol to exercise the client.
+type fakeHyperDeck struct {
+	listener        net.Listener
+	commands        []string
+	transportStatus string
+	recordingTime   int // omitted from the slot info if negative
+	recordError     bool
+	mutex           sync.Mutex
+}
+
+func newFakeHyperDeck(t *testing.T) *fakeHyperDeck {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	deck := &fakeHyperDeck{listener: listener, transportStatus: "stopped", recordingTime: 7200}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go deck.handleConnection(conn)
+		}
+	}()
+	return deck
+}
+
+func (deck *fakeHyperDeck) handleConnection(conn net.Conn) {
+	defer conn.Close()
+	fmt.Fprint(conn, "500 connection info:\r\nprotocol version: 1.11\r\nmodel: HyperDeck Studio\r\n\r\n")
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		command := scanner.Text()
+		deck.mutex.Lock()
+		deck.commands = append(deck.commands, command)
+		switch {
+		case strings.HasPrefix(command, "record"):
+			if deck.recordError {
+				fmt.Fprint(conn, "105 disk full\r\n")
+			} else {
+				deck.transportStatus = "record"
+				// Interleave an asynchronous notification to check that it is skipped.
+				fmt.Fprint(conn, "508 transport info:\r\nstatus: record\r\n\r\n200 ok\r\n")
+			}
+		case command == "stop":
+			deck.transportStatus = "stopped"
+			fmt.Fprint(conn, "200 ok\r\n")
+		case command == "transport info":
+			fmt.Fprintf(
+				conn, "208 transport info:\r\nstatus: %s\r\nspeed: 0\r\nslot id: 1\r\ntimecode: 01:02:03:04\r\n\r\n",
+				deck.transportStatus,
+			)
+		case command == "slot info":
+			if deck.recordingTime < 0 {
+				fmt.Fprint(conn, "202 slot info:\r\nslot id: 1\r\nstatus: empty\r\n\r\n")
+			} else {
+				fmt.Fprintf(
+					conn, "202 slot info:\r\nslot id: 1\r\nstatus: mounted\r\nrecording time: %d\r\n\r\n",
+					deck.recordingTime,
+				)
+			}
+		default:
+			fmt.Fprint(conn, "100 syntax error\r\n")
+		}
+		deck.mutex.Unlock()
+	}
+}
+
+func (deck *fakeHyperDeck) getCommands() []string {
+	deck.mutex.Lock()
+	defer deck.mutex.Unlock()
+	return append([]string{}, deck.commands...)
+}
+
+func TestBlackmagicClientRecording(t *testing.T) {
+	deck := newFakeHyperDeck(t)
+	client := NewBlackmagicClient(deck.listener.Addr().String())
+
+	client.updateStatuses()
+	assert.Equal(
+		t,
+		[]BlackmagicDeviceStatus{
+			{
+				Address:            deck.listener.Addr().String(),
+				Connected:          true,
+				TransportStatus:    "stopped",
+				RecordingTimeKnown: true,
+				RecordingTimeSec:   7200,
+			},
+		},
+		client.GetStatuses(),
+	)
+
+	recordings := client.StartRecording("Q12")
+	assert.Equal(
+		t,
+		[]BlackmagicRecording{{Address: deck.listener.Addr().String(), ClipName: "Q12", Timecode: "01:02:03:04"}},
+		recordings,
+	)
+	assert.Equal(
+		t,
+		[]string{"transport info", "slot info", "record: name: Q12", "transport info", "transport info", "slot info"},
+		deck.getCommands(),
+	)
+	statuses := client.GetStatuses()
+	assert.Equal(t, "record", statuses[0].TransportStatus)
+	assert.Equal(t, "", statuses[0].Warning)
+
+	// A deck that stops recording partway through the match should be flagged.
+	deck.mutex.Lock()
+	deck.transportStatus = "stopped"
+	deck.mutex.Unlock()
+	client.updateStatuses()
+	assert.Equal(t, "Not recording", client.GetStatuses()[0].Warning)
+
+	client.mutex.Lock()
+	client.recording = false
+	client.mutex.Unlock()
+	client.updateStatuses()
+	assert.Equal(t, "", client.GetStatuses()[0].Warning)
+
+	// A deck that rejects the record command should be flagged at match start.
+	deck.mutex.Lock()
+	deck.recordError = true
+	deck.recordingTime = 120
+	deck.mutex.Unlock()
+	assert.Empty(t, client.StartRecording("Q13"))
+	assert.Equal(t, "Not recording", client.GetStatuses()[0].Warning)
+
+	client.mutex.Lock()
+	client.recording = false
+	client.mutex.Unlock()
+	client.updateStatuses()
+	assert.Equal(t, "Low disk space (2 min left)", client.GetStatuses()[0].Warning)
+
+	// A deck that doesn't report its remaining recording time shouldn't be mistaken for one that is out of space.
+	deck.mutex.Lock()
+	deck.recordingTime = -1
+	deck.mutex.Unlock()
+	client.updateStatuses()
+	statuses = client.GetStatuses()
+	assert.False(t, statuses[0].RecordingTimeKnown)
+	assert.Equal(t, "", statuses[0].Warning)
+}
+
+func TestBlackmagicClientUnreachable(t *testing.T) {
+	listener, _ := net.Listen("tcp", "127.0.0.1:0")
+	address := listener.Addr().String()
+	listener.Close()
+	client := NewBlackmagicClient(address)
+
+	client.updateStatuses()
+	statuses := client.GetStatuses()
+	if assert.Equal(t, 1, len(statuses)) {
+		assert.False(t, statuses[0].Connected)
+		assert.Equal(t, "Not connected", statuses[0].Warning)
+	}
+
+	// Reconfiguring the addresses should reset the statuses.
+	client.SetAddresses("")
+	assert.Empty(t, client.GetStatuses())
+}
+
+func TestBlackmagicConnectionErrors(t *testing.T) {
+	deck := newFakeHyperDeck(t)
+	err := withBlackmagicConnection(
+		deck.listener.Addr().String(),
+		func(conn *blackmagicConnection) error {
+			_, err := conn.command("bogus")
+			return err
+		},
+	)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "device returned 100 syntax error", err.Error())
+	}
+}
@@ -0,0 +1,416 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Minimal MQTT 3.1.1 client for publishing live field state as retained topics and accepting control messages.
+
+package partner
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	mqttPort                = 1883
+	mqttProtocolLevel       = 4
+	mqttKeepAliveSec        = 30
+	mqttConnectTimeoutMs    = 1000
+	mqttReadTimeoutMs       = 500
+	mqttWriteTimeoutMs      = 500
+	mqttReconnectPeriodSec  = 2
+	mqttDefaultTopicPrefix  = "cheesy-arena"
+	mqttControlTopic        = "control"
+	mqttPacketConnect       = 1
+	mqttPacketConnack       = 2
+	mqttPacketPublish       = 3
+	mqttPacketSubscribe     = 8
+	mqttPacketSuback        = 9
+	mqttPacketPingreq       = 12
+	mqttPacketPingresp      = 13
+	mqttPublishRetainFlag   = 0x01
+	mqttSubscribeFlags      = 0x02
+	mqttMaxRemainingLength  = 268435455
+	mqttConnectCleanSession = 0x02
+)
+
+type MqttClient struct {
+	address        string
+	topicPrefix    string
+	clientId       string
+	conn           net.Conn
+	retained       map[string][]byte
+	controlHandler func(name, value string)
+	lastError      string
+	lastWrite      time.Time
+	mutex          sync.Mutex
+	writeMutex     sync.Mutex
+}
+
+// Health of the connection to the MQTT broker, for display on the match play page.
+type MqttStatus struct {
+	Connected bool
+	LastError string
+}
+
+// Creates a client that calls the given handler with the name and value of any message received on a control topic
+// (i.e. "<prefix>/control/<name>").
+func NewMqttClient(controlHandler func(name, value string)) *MqttClient {
+	return &MqttClient{
+		clientId:       "cheesy-arena-" + uuid.NewString()[:8],
+		retained:       make(map[string][]byte),
+		controlHandler: controlHandler,
+	}
+}
+
+// Sets the broker address (host with optional port) and the prefix prepended to all topics, dropping any existing
+// connection if they have changed.
+func (client *MqttClient) SetSettings(address, topicPrefix string) {
+	if topicPrefix == "" {
+		topicPrefix = mqttDefaultTopicPrefix
+	}
+	topicPrefix = strings.TrimSuffix(topicPrefix, "/")
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	if address != client.address || topicPrefix != client.topicPrefix {
+		client.disconnect(client.conn, "")
+	}
+	client.address = address
+	client.topicPrefix = topicPrefix
+}
+
+// Returns the current health of the connection to the broker.
+func (client *MqttClient) Status() MqttStatus {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	return MqttStatus{Connected: client.conn != nil, LastError: client.lastError}
+}
+
+// Serializes the given value as JSON and publishes it as a retained message on the given topic (relative to the
+// prefix). Unchanged values aren't republished. The latest value of every topic is also remembered so that it can be
+// republished whenever the connection is re-established.
+func (client *MqttClient) Publish(topic string, value any) {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		log.Printf("Failed to serialize MQTT message for topic %s: %v", topic, err)
+		return
+	}
+	client.PublishJson(topic, payload)
+}
+
+// Publishes the given already-serialized JSON payload as a retained message on the given topic, in the same manner as
+// Publish.
+func (client *MqttClient) PublishJson(topic string, payload []byte) {
+	// Holding the write mutex throughout keeps successive values of a topic in order, while the main mutex is only held
+	// long enough to update the retained values so that a stalled broker doesn't block Status().
+	client.writeMutex.Lock()
+	defer client.writeMutex.Unlock()
+	client.mutex.Lock()
+	if bytes.Equal(client.retained[topic], payload) {
+		client.mutex.Unlock()
+		return
+	}
+	client.retained[topic] = payload
+	conn := client.conn
+	fullTopic := client.fullTopic(topic)
+	client.mutex.Unlock()
+
+	if conn != nil {
+		if err := client.writePacket(conn, buildMqttPublishPacket(fullTopic, payload)); err != nil {
+			client.dropConnection(conn, err.Error())
+		}
+	}
+}
+
+// Loops indefinitely to maintain the connection to the broker and to process incoming control messages.
+func (client *MqttClient) Run() {
+	for {
+		client.mutex.Lock()
+		address := client.address
+		conn := client.conn
+		client.mutex.Unlock()
+
+		if address == "" {
+			time.Sleep(mqttReconnectPeriodSec * time.Second)
+			continue
+		}
+		if conn == nil {
+			if err := client.connect(); err != nil {
+				time.Sleep(mqttReconnectPeriodSec * time.Second)
+			}
+			continue
+		}
+		client.readAndHandlePacket(conn)
+	}
+}
+
+// Connects to the broker, subscribes to the control topics and republishes all retained values.
+func (client *MqttClient) connect() error {
+	client.mutex.Lock()
+	configuredAddress := client.address
+	topicPrefix := client.topicPrefix
+	client.mutex.Unlock()
+
+	address := configuredAddress
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, strconv.Itoa(mqttPort))
+	}
+	conn, err := client.handshake(address, topicPrefix)
+	if err != nil {
+		client.mutex.Lock()
+		client.lastError = err.Error()
+		client.mutex.Unlock()
+		return err
+	}
+
+	// Take the write mutex first so that no newer value can be published ahead of the republished retained ones.
+	client.writeMutex.Lock()
+	defer client.writeMutex.Unlock()
+	client.mutex.Lock()
+	if client.conn != nil || client.address != configuredAddress || client.topicPrefix != topicPrefix {
+		// The settings changed or another connection was made while this one was being established.
+		client.mutex.Unlock()
+		conn.Close()
+		return nil
+	}
+	client.conn = conn
+	client.lastError = ""
+	packets := make([][]byte, 0, len(client.retained))
+	for topic, payload := range client.retained {
+		packets = append(packets, buildMqttPublishPacket(client.fullTopic(topic), payload))
+	}
+	client.mutex.Unlock()
+
+	for _, packet := range packets {
+		if err = client.writePacket(conn, packet); err != nil {
+			client.dropConnection(conn, err.Error())
+			return err
+		}
+	}
+	log.Printf("Connected to MQTT broker at %s.", address)
+	return nil
+}
+
+// Opens the connection and performs the connect and subscribe exchange, returning the ready connection.
+func (client *MqttClient) handshake(address, topicPrefix string) (net.Conn, error) {
+	conn, err := net.DialTimeout("tcp", address, mqttConnectTimeoutMs*time.Millisecond)
+	if err != nil {
+		return nil, err
+	}
+	if err = writeMqttPacket(conn, buildMqttConnectPacket(client.clientId)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	conn.SetReadDeadline(time.Now().Add(mqttConnectTimeoutMs * time.Millisecond))
+	packetType, body, err := readMqttPacket(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if packetType != mqttPacketConnack || len(body) != 2 {
+		conn.Close()
+		return nil, fmt.Errorf("expected CONNACK from MQTT broker but got packet type %d", packetType)
+	}
+	if body[1] != 0 {
+		conn.Close()
+		return nil, fmt.Errorf("MQTT broker refused the connection with return code %d", body[1])
+	}
+	subscribePacket := buildMqttSubscribePacket(1, topicPrefix+"/"+mqttControlTopic+"/#")
+	if err = writeMqttPacket(conn, subscribePacket); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// Reads the next packet from the broker and dispatches any control message, sending a keepalive ping if the
+// connection has otherwise been idle.
+func (client *MqttClient) readAndHandlePacket(conn net.Conn) {
+	conn.SetReadDeadline(time.Now().Add(mqttReadTimeoutMs * time.Millisecond))
+	packetType, body, err := readMqttPacket(conn)
+	if err != nil {
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			client.sendKeepAlive(conn)
+			return
+		}
+		client.dropConnection(conn, err.Error())
+		return
+	}
+	if packetType != mqttPacketPublish {
+		return
+	}
+
+	topic, payload, err := parseMqttPublishPacket(body)
+	if err != nil {
+		log.Printf("Ignoring malformed MQTT message: %v", err)
+		return
+	}
+	client.mutex.Lock()
+	controlPrefix := client.topicPrefix + "/" + mqttControlTopic + "/"
+	client.mutex.Unlock()
+	if name, ok := strings.CutPrefix(topic, controlPrefix); ok && client.controlHandler != nil {
+		value := strings.TrimSpace(string(payload))
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+		client.controlHandler(name, value)
+	}
+}
+
+func (client *MqttClient) sendKeepAlive(conn net.Conn) {
+	client.writeMutex.Lock()
+	defer client.writeMutex.Unlock()
+	if time.Since(client.lastWrite) < mqttKeepAliveSec/2*time.Second {
+		return
+	}
+	client.mutex.Lock()
+	isCurrent := conn == client.conn
+	client.mutex.Unlock()
+	if !isCurrent {
+		return
+	}
+	if err := client.writePacket(conn, []byte{mqttPacketPingreq << 4, 0}); err != nil {
+		client.dropConnection(conn, err.Error())
+	}
+}
+
+// Writes the given packet to the given established connection and records the time of the write for the keepalive.
+// Must be called with the write mutex held.
+func (client *MqttClient) writePacket(conn net.Conn, packet []byte) error {
+	err := writeMqttPacket(conn, packet)
+	client.lastWrite = time.Now()
+	return err
+}
+
+// Closes the given connection after a failure if it is the current one, and records the given error.
+func (client *MqttClient) dropConnection(conn net.Conn, lastError string) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.disconnect(conn, lastError)
+}
+
+// Closes the given connection if it is the current one, and records the given error. Must be called with the mutex
+// held.
+func (client *MqttClient) disconnect(conn net.Conn, lastError string) {
+	if conn == nil || conn != client.conn {
+		return
+	}
+	conn.Close()
+	client.conn = nil
+	client.lastError = lastError
+	if lastError != "" {
+		log.Printf("Disconnected from MQTT broker: %s", lastError)
+	}
+}
+
+// Returns the full topic name for the given topic relative to the prefix. Must be called with the mutex held.
+func (client *MqttClient) fullTopic(topic string) string {
+	return client.topicPrefix + "/" + topic
+}
+
+// Writes the given packet to the given connection, giving up if the broker doesn't accept it in time.
+func writeMqttPacket(conn net.Conn, packet []byte) error {
+	conn.SetWriteDeadline(time.Now().Add(mqttWriteTimeoutMs * time.Millisecond))
+	_, err := conn.Write(packet)
+	return err
+}
+
+func buildMqttConnectPacket(clientId string) []byte {
+	var body bytes.Buffer
+	writeMqttString(&body, "MQTT")
+	body.WriteByte(mqttProtocolLevel)
+	body.WriteByte(mqttConnectCleanSession)
+	binary.Write(&body, binary.BigEndian, uint16(mqttKeepAliveSec))
+	writeMqttString(&body, clientId)
+	return buildMqttPacket(mqttPacketConnect<<4, body.Bytes())
+}
+
+func buildMqttPublishPacket(topic string, payload []byte) []byte {
+	var body bytes.Buffer
+	writeMqttString(&body, topic)
+	body.Write(payload)
+	return buildMqttPacket(mqttPacketPublish<<4|mqttPublishRetainFlag, body.Bytes())
+}
+
+func buildMqttSubscribePacket(packetId uint16, topicFilter string) []byte {
+	var body bytes.Buffer
+	binary.Write(&body, binary.BigEndian, packetId)
+	writeMqttString(&body, topicFilter)
+	body.WriteByte(0) // Requested QoS
+	return buildMqttPacket(mqttPacketSubscribe<<4|mqttSubscribeFlags, body.Bytes())
+}
+
+// Returns the topic and payload of the given QoS 0 PUBLISH packet body.
+func parseMqttPublishPacket(body []byte) (string, []byte, error) {
+	if len(body) < 2 {
+		return "", nil, fmt.Errorf("packet is too short")
+	}
+	topicLength := int(binary.BigEndian.Uint16(body))
+	if len(body) < 2+topicLength {
+		return "", nil, fmt.Errorf("topic length %d exceeds packet length", topicLength)
+	}
+	return string(body[2 : 2+topicLength]), body[2+topicLength:], nil
+}
+
+// Prepends the fixed header, consisting of the given first byte and the variable-length encoded body length.
+func buildMqttPacket(firstByte byte, body []byte) []byte {
+	packet := []byte{firstByte}
+	length := len(body)
+	for {
+		encodedByte := byte(length % 128)
+		length /= 128
+		if length > 0 {
+			encodedByte |= 0x80
+		}
+		packet = append(packet, encodedByte)
+		if length == 0 {
+			break
+		}
+	}
+	return append(packet, body...)
+}
+
+// Reads a single packet and returns its type and body. Flags in the first byte other than the type are discarded.
+func readMqttPacket(reader io.Reader) (byte, []byte, error) {
+	var header [1]byte
+	if _, err := io.ReadFull(reader, header[:]); err != nil {
+		return 0, nil, err
+	}
+	packetType := header[0] >> 4
+	length := 0
+	for multiplier := 1; ; multiplier *= 128 {
+		if _, err := io.ReadFull(reader, header[:]); err != nil {
+			return 0, nil, err
+		}
+		length += int(header[0]&0x7f) * multiplier
+		if length > mqttMaxRemainingLength {
+			return 0, nil, fmt.Errorf("invalid MQTT remaining length")
+		}
+		if header[0]&0x80 == 0 {
+			break
+		}
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(reader, body); err != nil {
+		return 0, nil, err
+	}
+	return packetType, body, nil
+}
+
+func writeMqttString(buffer *bytes.Buffer, value string) {
+	binary.Write(buffer, binary.BigEndian, uint16(len(value)))
+	buffer.WriteString(value)
+}
@@ -0,0 +1,262 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package partner
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Fake in-process MQTT broker that accepts connections and records the subscriptions and messages it receives.
+type fakeMqttBroker struct {
+	listener      net.Listener
+	conns         []net.Conn
+	clientIds     []string
+	subscriptions []string
+	messages      []fakeMqttMessage
+	mutex         sync.Mutex
+}
+
+type fakeMqttMessage struct {
+	topic   string
+	payload string
+	retain  bool
+}
+
+func newFakeMqttBroker(t *testing.T) *fakeMqttBroker {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	broker := &fakeMqttBroker{listener: listener}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			broker.mutex.Lock()
+			broker.conns = append(broker.conns, conn)
+			broker.mutex.Unlock()
+			go broker.handleConnection(t, conn)
+		}
+	}()
+	t.Cleanup(broker.close)
+	return broker
+}
+
+func (broker *fakeMqttBroker) handleConnection(t *testing.T, conn net.Conn) {
+	defer conn.Close()
+	for {
+		var firstByte [1]byte
+		if _, err := conn.Read(firstByte[:]); err != nil {
+			return
+		}
+		_, body, err := readMqttPacket(
+			bytes.NewReader(append([]byte{firstByte[0]}, readRemainingPacket(conn)...)),
+		)
+		if err != nil {
+			return
+		}
+		broker.mutex.Lock()
+		switch firstByte[0] >> 4 {
+		case mqttPacketConnect:
+			assert.Equal(t, "MQTT", string(body[2:6]))
+			assert.Equal(t, byte(mqttProtocolLevel), body[6])
+			clientIdLength := int(binary.BigEndian.Uint16(body[10:]))
+			broker.clientIds = append(broker.clientIds, string(body[12:12+clientIdLength]))
+			conn.Write([]byte{mqttPacketConnack << 4, 2, 0, 0})
+		case mqttPacketSubscribe:
+			assert.Equal(t, byte(mqttPacketSubscribe<<4|mqttSubscribeFlags), firstByte[0])
+			topicLength := int(binary.BigEndian.Uint16(body[2:]))
+			broker.subscriptions = append(broker.subscriptions, string(body[4:4+topicLength]))
+			conn.Write([]byte{mqttPacketSuback << 4, 3, body[0], body[1], 0})
+		case mqttPacketPublish:
+			topic, payload, err := parseMqttPublishPacket(body)
+			assert.Nil(t, err)
+			broker.messages = append(
+				broker.messages,
+				fakeMqttMessage{topic, string(payload), firstByte[0]&mqttPublishRetainFlag != 0},
+			)
+		case mqttPacketPingreq:
+			conn.Write([]byte{mqttPacketPingresp << 4, 0})
+		}
+		broker.mutex.Unlock()
+	}
+}
+
+// Reads the remaining length and body of a packet whose first byte has already been read, and returns them unparsed.
+func readRemainingPacket(conn net.Conn) []byte {
+	var packet []byte
+	length := 0
+	for multiplier := 1; ; multiplier *= 128 {
+		var encodedByte [1]byte
+		if _, err := conn.Read(encodedByte[:]); err != nil {
+			return packet
+		}
+		packet = append(packet, encodedByte[0])
+		length += int(encodedByte[0]&0x7f) * multiplier
+		if encodedByte[0]&0x80 == 0 {
+			break
+		}
+	}
+	body := make([]byte, length)
+	for read := 0; read < length; {
+		n, err := conn.Read(body[read:])
+		if err != nil {
+			return packet
+		}
+		read += n
+	}
+	return append(packet, body...)
+}
+
+// Sends a message on the given topic to all connected clients.
+func (broker *fakeMqttBroker) publish(topic, payload string) {
+	broker.mutex.Lock()
+	defer broker.mutex.Unlock()
+	for _, conn := range broker.conns {
+		conn.Write(buildMqttPublishPacket(topic, []byte(payload)))
+	}
+}
+
+func (broker *fakeMqttBroker) getMessages() []fakeMqttMessage {
+	broker.mutex.Lock()
+	defer broker.mutex.Unlock()
+	return append([]fakeMqttMessage(nil), broker.messages...)
+}
+
+func (broker *fakeMqttBroker) close() {
+	broker.listener.Close()
+	broker.mutex.Lock()
+	defer broker.mutex.Unlock()
+	for _, conn := range broker.conns {
+		conn.Close()
+	}
+}
+
+func TestMqttPacketEncoding(t *testing.T) {
+	assert.Equal(t, []byte{0x31, 7, 0, 1, 'a', 'b', 'c', 'd', 'e'}, buildMqttPublishPacket("a", []byte("bcde")))
+
+	// Bodies of 128 bytes or more need a multi-byte remaining length.
+	packet := buildMqttPacket(0x30, make([]byte, 321))
+	assert.Equal(t, []byte{0x30, 0xc1, 0x02}, packet[0:3])
+	packetType, body, err := readMqttPacket(bytes.NewReader(packet))
+	assert.Nil(t, err)
+	assert.Equal(t, byte(mqttPacketPublish), packetType)
+	assert.Equal(t, 321, len(body))
+
+	topic, payload, err := parseMqttPublishPacket([]byte{0, 3, 'a', '/', 'b', '1'})
+	assert.Nil(t, err)
+	assert.Equal(t, "a/b", topic)
+	assert.Equal(t, []byte("1"), payload)
+	_, _, err = parseMqttPublishPacket([]byte{0, 9, 'a'})
+	assert.EqualError(t, err, "topic length 9 exceeds packet length")
+}
+
+func TestMqttClientPublish(t *testing.T) {
+	broker := newFakeMqttBroker(t)
+	var controlMessages []string
+	var controlMutex sync.Mutex
+	client := NewMqttClient(
+		func(name, value string) {
+			controlMutex.Lock()
+			defer controlMutex.Unlock()
+			controlMessages = append(controlMessages, name+"="+value)
+		},
+	)
+
+	// Values published before connecting should be retained and sent once connected.
+	client.Publish("match/time", map[string]int{"MatchTimeSec": 5})
+	client.SetSettings(broker.listener.Addr().String(), "field1/")
+	assert.Nil(t, client.connect())
+	assert.Equal(t, MqttStatus{Connected: true}, client.Status())
+	client.Publish("match/time", map[string]int{"MatchTimeSec": 5})
+	client.Publish("match/time", map[string]int{"MatchTimeSec": 6})
+	client.Publish("rankings", []int{254, 1114})
+
+	assert.Eventually(t, func() bool { return len(broker.getMessages()) == 3 }, time.Second, 10*time.Millisecond)
+	assert.Equal(
+		t,
+		[]fakeMqttMessage{
+			{"field1/match/time", "{\"MatchTimeSec\":5}", true},
+			{"field1/match/time", "{\"MatchTimeSec\":6}", true},
+			{"field1/rankings", "[254,1114]", true},
+		},
+		broker.getMessages(),
+	)
+	broker.mutex.Lock()
+	assert.Equal(t, []string{"field1/control/#"}, broker.subscriptions)
+	assert.Equal(t, []string{client.clientId}, broker.clientIds)
+	broker.mutex.Unlock()
+
+	// Control messages should be passed to the handler with any JSON string quoting removed.
+	broker.publish("field1/control/audience_display_mode", "\"score\"")
+	broker.publish("field1/other", "ignored")
+	broker.publish("field1/control/audience_display_mode", "blank")
+	for i := 0; i < 4; i++ {
+		// The first packet read is the acknowledgement of the control topic subscription.
+		client.readAndHandlePacket(client.conn)
+	}
+	controlMutex.Lock()
+	assert.Equal(t, []string{"audience_display_mode=score", "audience_display_mode=blank"}, controlMessages)
+	controlMutex.Unlock()
+
+	// Changing the settings should drop the connection, and reconnecting should republish all retained values.
+	client.SetSettings(broker.listener.Addr().String(), "field2")
+	assert.False(t, client.Status().Connected)
+	assert.Nil(t, client.connect())
+	assert.Eventually(t, func() bool { return len(broker.getMessages()) == 5 }, time.Second, 10*time.Millisecond)
+	assert.ElementsMatch(
+		t,
+		[]fakeMqttMessage{
+			{"field2/match/time", "{\"MatchTimeSec\":6}", true}, {"field2/rankings", "[254,1114]", true},
+		},
+		broker.getMessages()[3:],
+	)
+}
+
+func TestMqttClientConnectionFailure(t *testing.T) {
+	broker := newFakeMqttBroker(t)
+	address := broker.listener.Addr().String()
+	broker.close()
+
+	client := NewMqttClient(nil)
+	client.SetSettings(address, "")
+	assert.NotNil(t, client.connect())
+	status := client.Status()
+	assert.False(t, status.Connected)
+	assert.Contains(t, status.LastError, "connection refused")
+	assert.Equal(t, mqttDefaultTopicPrefix, client.topicPrefix)
+}
+
+func TestMqttClientStatusWhileWriteStalled(t *testing.T) {
+	// A pipe that is never read from stands in for a broker that has stopped accepting data.
+	conn, brokerConn := net.Pipe()
+	defer brokerConn.Close()
+	client := NewMqttClient(nil)
+	client.SetSettings("broker", "")
+	client.conn = conn
+
+	done := make(chan struct{})
+	go func() {
+		client.PublishJson("match", []byte("{}"))
+		close(done)
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	startTime := time.Now()
+	assert.Equal(t, MqttStatus{Connected: true}, client.Status())
+	assert.Less(t, time.Since(startTime), 50*time.Millisecond)
+
+	<-done
+	status := client.Status()
+	assert.False(t, status.Connected)
+	assert.Contains(t, status.LastError, "timeout")
+	assert.Equal(t, []byte("{}"), client.retained["match"])
+}
@@ -0,0 +1,276 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Client for pulling match lineups from and pushing queueing status to Nexus (https://frc.nexus).
+
+package partner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Team254/cheesy-arena/model"
+)
+
+const (
+	nexusBaseUrl          = "https://frc.nexus"
+	nexusRequestTimeoutMs = 1000
+	nexusUpdatePeriodSec  = 2
+)
+
+// Queueing statuses shown to teams in the Nexus app.
+const (
+	NexusNowQueuing = "Now queuing"
+	NexusOnDeck     = "On deck"
+	NexusOnField    = "On field"
+)
+
+type NexusClient struct {
+	BaseUrl         string
+	eventCode       string
+	apiKey          string
+	httpClient      *http.Client
+	pendingStatuses map[string]string
+	upcomingMatches []model.TbaMatchKey
+	lineups         map[string]*NexusLineup
+	wakeChan        chan struct{}
+	mutex           sync.Mutex
+}
+
+// Lineup for a match as submitted by the teams; an alliance's list is empty if it hasn't submitted yet.
+type NexusLineup struct {
+	Red  []int
+	Blue []int
+}
+
+type nexusLineup struct {
+	Red  []string `json:"red"`
+	Blue []string `json:"blue"`
+}
+
+type nexusMatchStatus struct {
+	Status string `json:"status"`
+}
+
+// Creates a new Nexus client pointing at the production server.
+func NewNexusClient() *NexusClient {
+	return &NexusClient{
+		BaseUrl:         nexusBaseUrl,
+		httpClient:      &http.Client{Timeout: nexusRequestTimeoutMs * time.Millisecond},
+		pendingStatuses: make(map[string]string),
+		lineups:         make(map[string]*NexusLineup),
+		wakeChan:        make(chan struct{}, 1),
+	}
+}
+
+// Updates the event code and API key. Any status updates pending and lineups cached for a different event are
+// discarded.
+func (client *NexusClient) SetSettings(eventCode, apiKey string) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	if eventCode != client.eventCode {
+		client.pendingStatuses = make(map[string]string)
+		client.upcomingMatches = nil
+		client.lineups = make(map[string]*NexusLineup)
+	}
+	client.eventCode = eventCode
+	client.apiKey = apiKey
+}
+
+// Loops indefinitely to push pending queueing status updates and to refresh the lineups of upcoming matches, retrying
+// any requests that fail on the next iteration.
+func (client *NexusClient) Run() {
+	for {
+		client.pushStatuses()
+		client.fetchLineups()
+		select {
+		case <-client.wakeChan:
+		case <-time.After(nexusUpdatePeriodSec * time.Second):
+		}
+	}
+}
+
+// Sets the matches whose lineups should be kept up to date in the background, so that they are already on hand by the
+// time each match is loaded. Cached lineups for any other matches are discarded.
+func (client *NexusClient) SetUpcomingMatches(tbaMatchKeys []model.TbaMatchKey) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.upcomingMatches = tbaMatchKeys
+	lineups := make(map[string]*NexusLineup)
+	for _, tbaMatchKey := range tbaMatchKeys {
+		if lineup, ok := client.lineups[tbaMatchKey.String()]; ok {
+			lineups[tbaMatchKey.String()] = lineup
+		}
+	}
+	client.lineups = lineups
+	client.wake()
+}
+
+// Returns the most recently fetched lineup for the given upcoming match, or nil if none has been fetched yet. Never
+// blocks on the network.
+func (client *NexusClient) GetCachedLineup(tbaMatchKey model.TbaMatchKey) *NexusLineup {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	return client.lineups[tbaMatchKey.String()]
+}
+
+// Returns the cached lineup for the given match if there is one, and otherwise fetches it from Nexus right away, giving
+// up after the given timeout. Used for matches that haven't been fetched ahead of time, such as ones loaded out of
+// order.
+func (client *NexusClient) GetLineupWithTimeout(
+	tbaMatchKey model.TbaMatchKey, timeout time.Duration,
+) (*NexusLineup, error) {
+	if lineup := client.GetCachedLineup(tbaMatchKey); lineup != nil {
+		return lineup, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return client.getLineup(ctx, tbaMatchKey)
+}
+
+// Returns the lineup submitted in Nexus for the given match.
+func (client *NexusClient) GetLineup(tbaMatchKey model.TbaMatchKey) (*NexusLineup, error) {
+	return client.getLineup(context.Background(), tbaMatchKey)
+}
+
+func (client *NexusClient) getLineup(ctx context.Context, tbaMatchKey model.TbaMatchKey) (*NexusLineup, error) {
+	client.mutex.Lock()
+	path := fmt.Sprintf("/api/v1/event/%s/match/%s/lineup", client.eventCode, tbaMatchKey.String())
+	client.mutex.Unlock()
+
+	resp, err := client.sendRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("got status code %d from Nexus for match %s: %s", resp.StatusCode, tbaMatchKey, body)
+	}
+
+	var response nexusLineup
+	if err = json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+	var lineup NexusLineup
+	if lineup.Red, err = parseNexusTeams(response.Red); err != nil {
+		return nil, err
+	}
+	if lineup.Blue, err = parseNexusTeams(response.Blue); err != nil {
+		return nil, err
+	}
+	return &lineup, nil
+}
+
+// Records the queueing status to show for the given match. It is pushed to Nexus asynchronously, superseding any
+// not-yet-sent status for the same match.
+func (client *NexusClient) SetMatchStatus(tbaMatchKey model.TbaMatchKey, status string) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.pendingStatuses[tbaMatchKey.String()] = status
+	client.wake()
+}
+
+// Signals the background loop to run right away. Does not block if it has already been signaled.
+func (client *NexusClient) wake() {
+	select {
+	case client.wakeChan <- struct{}{}:
+	default:
+	}
+}
+
+// Fetches the lineups of all upcoming matches, keeping the previously fetched lineup for any that fail.
+func (client *NexusClient) fetchLineups() {
+	client.mutex.Lock()
+	upcomingMatches := client.upcomingMatches
+	client.mutex.Unlock()
+
+	for _, tbaMatchKey := range upcomingMatches {
+		lineup, err := client.GetLineup(tbaMatchKey)
+		if err != nil {
+			log.Printf("Failed to load lineup for match %s from Nexus: %v", tbaMatchKey, err)
+			continue
+		}
+
+		// Only cache the lineup if the match hasn't dropped out of the upcoming list in the meantime.
+		client.mutex.Lock()
+		if slices.Contains(client.upcomingMatches, tbaMatchKey) {
+			client.lineups[tbaMatchKey.String()] = lineup
+		}
+		client.mutex.Unlock()
+	}
+}
+
+// Sends all pending queueing statuses to Nexus, leaving any that fail to be retried later.
+func (client *NexusClient) pushStatuses() {
+	client.mutex.Lock()
+	eventCode := client.eventCode
+	statuses := make(map[string]string, len(client.pendingStatuses))
+	for matchKey, status := range client.pendingStatuses {
+		statuses[matchKey] = status
+	}
+	client.mutex.Unlock()
+
+	for matchKey, status := range statuses {
+		body, _ := json.Marshal(nexusMatchStatus{Status: status})
+		path := fmt.Sprintf("/api/v1/event/%s/match/%s/status", eventCode, matchKey)
+		resp, err := client.sendRequest(context.Background(), "POST", path, body)
+		if err != nil {
+			log.Printf("Failed to push status for match %s to Nexus: %v", matchKey, err)
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode != 200 {
+			log.Printf("Got status code %d from Nexus when pushing status for match %s.", resp.StatusCode, matchKey)
+			if resp.StatusCode >= 500 {
+				continue
+			}
+		}
+
+		// Only clear the pending status if it hasn't been changed in the meantime.
+		client.mutex.Lock()
+		if client.pendingStatuses[matchKey] == status {
+			delete(client.pendingStatuses, matchKey)
+		}
+		client.mutex.Unlock()
+	}
+}
+
+func (client *NexusClient) sendRequest(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
+	client.mutex.Lock()
+	url := client.BaseUrl + path
+	apiKey := client.apiKey
+	client.mutex.Unlock()
+
+	request, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Add("Nexus-Api-Key", apiKey)
+	if body != nil {
+		request.Header.Add("Content-Type", "application/json")
+	}
+	return client.httpClient.Do(request)
+}
+
+// Converts the given list of Nexus team numbers into integers.
+func parseNexusTeams(teams []string) ([]int, error) {
+	var teamIds []int
+	for _, team := range teams {
+		teamId, err := strconv.Atoi(team)
+		if err != nil {
+			return nil, fmt.Errorf("invalid team number %q in Nexus lineup", team)
+		}
+		teamIds = append(teamIds, teamId)
+	}
+	return teamIds, nil
+}
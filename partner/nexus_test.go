@@ -0,0 +1,176 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package partner
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// Mock implementation of the Nexus API.
+type mockNexusServer struct {
+	*httptest.Server
+	lineups  map[string]nexusLineup
+	statuses map[string]string
+	down     bool
+	mutex    sync.Mutex
+}
+
+func newMockNexusServer(t *testing.T) *mockNexusServer {
+	server := &mockNexusServer{lineups: make(map[string]nexusLineup), statuses: make(map[string]string)}
+	mux := http.NewServeMux()
+	mux.HandleFunc(
+		"GET /api/v1/event/{eventCode}/match/{matchKey}/lineup",
+		func(w http.ResponseWriter, r *http.Request) {
+			if !server.checkRequest(w, r) {
+				return
+			}
+			lineup, ok := server.lineups[r.PathValue("matchKey")]
+			if !ok {
+				http.Error(w, "match not found", 404)
+				return
+			}
+			assert.Nil(t, json.NewEncoder(w).Encode(lineup))
+		},
+	)
+	mux.HandleFunc(
+		"POST /api/v1/event/{eventCode}/match/{matchKey}/status",
+		func(w http.ResponseWriter, r *http.Request) {
+			if !server.checkRequest(w, r) {
+				return
+			}
+			var status nexusMatchStatus
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&status))
+			server.statuses[r.PathValue("matchKey")] = status.Status
+		},
+	)
+	server.Server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func (server *mockNexusServer) checkRequest(w http.ResponseWriter, r *http.Request) bool {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	if server.down {
+		http.Error(w, "service unavailable", 503)
+		return false
+	}
+	if r.PathValue("eventCode") != "2026test" || r.Header.Get("Nexus-Api-Key") != "api_key" {
+		http.Error(w, "unauthorized", 401)
+		return false
+	}
+	return true
+}
+
+func setupTestNexusClient(t *testing.T) (*NexusClient, *mockNexusServer) {
+	server := newMockNexusServer(t)
+	client := NewNexusClient()
+	client.BaseUrl = server.URL
+	client.SetSettings("2026test", "api_key")
+	return client, server
+}
+
+func TestNexusClient_GetLineup(t *testing.T) {
+	client, server := setupTestNexusClient(t)
+	server.lineups["p1"] = nexusLineup{Red: []string{"101", "102", "103"}, Blue: []string{"104", "105", "106"}}
+	server.lineups["sf2m1"] = nexusLineup{Red: nil, Blue: []string{"104", "105"}}
+	server.lineups["sf3m1"] = nexusLineup{Red: []string{"10a"}}
+
+	lineup, err := client.GetLineup(model.TbaMatchKey{CompLevel: "p", MatchNumber: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, &NexusLineup{Red: []int{101, 102, 103}, Blue: []int{104, 105, 106}}, lineup)
+
+	lineup, err = client.GetLineup(model.TbaMatchKey{CompLevel: "sf", SetNumber: 2, MatchNumber: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, &NexusLineup{Red: nil, Blue: []int{104, 105}}, lineup)
+
+	_, err = client.GetLineup(model.TbaMatchKey{CompLevel: "sf", SetNumber: 3, MatchNumber: 1})
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "invalid team number \"10a\" in Nexus lineup", err.Error())
+	}
+
+	_, err = client.GetLineup(model.TbaMatchKey{CompLevel: "p", MatchNumber: 2})
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "got status code 404 from Nexus for match p2: match not found\n", err.Error())
+	}
+
+	client.SetSettings("2026test", "wrong_key")
+	_, err = client.GetLineup(model.TbaMatchKey{CompLevel: "p", MatchNumber: 1})
+	assert.NotNil(t, err)
+
+	// An unreachable server should result in an error rather than hanging.
+	server.Close()
+	_, err = client.GetLineup(model.TbaMatchKey{CompLevel: "p", MatchNumber: 1})
+	assert.NotNil(t, err)
+}
+
+func TestNexusClient_PushStatuses(t *testing.T) {
+	client, server := setupTestNexusClient(t)
+
+	client.SetMatchStatus(model.TbaMatchKey{CompLevel: "p", MatchNumber: 1}, NexusOnField)
+	client.SetMatchStatus(model.TbaMatchKey{CompLevel: "p", MatchNumber: 2}, NexusNowQueuing)
+	client.SetMatchStatus(model.TbaMatchKey{CompLevel: "p", MatchNumber: 2}, NexusOnDeck)
+	client.pushStatuses()
+	assert.Equal(t, map[string]string{"p1": NexusOnField, "p2": NexusOnDeck}, server.statuses)
+	assert.Empty(t, client.pendingStatuses)
+
+	// Statuses that fail to send during an outage should be retried once Nexus is back.
+	server.mutex.Lock()
+	server.down = true
+	server.mutex.Unlock()
+	client.SetMatchStatus(model.TbaMatchKey{CompLevel: "p", MatchNumber: 3}, NexusNowQueuing)
+	client.pushStatuses()
+	assert.Equal(t, 1, len(client.pendingStatuses))
+	assert.NotContains(t, server.statuses, "p3")
+
+	server.mutex.Lock()
+	server.down = false
+	server.mutex.Unlock()
+	client.pushStatuses()
+	assert.Equal(t, NexusNowQueuing, server.statuses["p3"])
+	assert.Empty(t, client.pendingStatuses)
+
+	// Changing the event should discard anything pending for the old one.
+	client.SetMatchStatus(model.TbaMatchKey{CompLevel: "p", MatchNumber: 4}, NexusNowQueuing)
+	client.SetSettings("2026other", "api_key")
+	assert.Empty(t, client.pendingStatuses)
+}
+
+func TestNexusClient_FetchLineups(t *testing.T) {
+	client, server := setupTestNexusClient(t)
+	server.lineups["p1"] = nexusLineup{Red: []string{"101", "102", "103"}}
+	server.lineups["p2"] = nexusLineup{Blue: []string{"104", "105", "106"}}
+	p1 := model.TbaMatchKey{CompLevel: "p", MatchNumber: 1}
+	p2 := model.TbaMatchKey{CompLevel: "p", MatchNumber: 2}
+	p3 := model.TbaMatchKey{CompLevel: "p", MatchNumber: 3}
+
+	// Nothing should be returned before the lineups have been fetched in the background.
+	client.SetUpcomingMatches([]model.TbaMatchKey{p1, p2, p3})
+	assert.Nil(t, client.GetCachedLineup(p1))
+	client.fetchLineups()
+	assert.Equal(t, &NexusLineup{Red: []int{101, 102, 103}}, client.GetCachedLineup(p1))
+	assert.Equal(t, &NexusLineup{Blue: []int{104, 105, 106}}, client.GetCachedLineup(p2))
+	assert.Nil(t, client.GetCachedLineup(p3))
+
+	// The last known lineups should be kept during an outage.
+	server.mutex.Lock()
+	server.down = true
+	server.mutex.Unlock()
+	client.fetchLineups()
+	assert.Equal(t, &NexusLineup{Red: []int{101, 102, 103}}, client.GetCachedLineup(p1))
+
+	// Lineups for matches that are no longer upcoming should be discarded.
+	client.SetUpcomingMatches([]model.TbaMatchKey{p2, p3})
+	assert.Nil(t, client.GetCachedLineup(p1))
+	assert.Equal(t, &NexusLineup{Blue: []int{104, 105, 106}}, client.GetCachedLineup(p2))
+	client.SetSettings("2026other", "api_key")
+	assert.Nil(t, client.GetCachedLineup(p2))
+}
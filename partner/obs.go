@@ -0,0 +1,414 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Client for switching scenes and controlling recording in OBS Studio using the OBS WebSocket v5 protocol.
+
+package partner
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	obsPort                 = 4455
+	obsRpcVersion           = 1
+	obsConnectTimeoutMs     = 500
+	obsResponseTimeoutMs    = 1000
+	obsHealthCheckPeriodSec = 2
+	obsCommandQueueSize     = 20
+	obsOpHello              = 0
+	obsOpIdentify           = 1
+	obsOpIdentified         = 2
+	obsOpRequest            = 6
+	obsOpRequestResponse    = 7
+	obsSourceHiddenPrefix   = "-"
+	obsActionSeparator      = ";"
+	obsSceneSourceSeparator = "/"
+)
+
+type ObsClient struct {
+	address       string
+	password      string
+	conn          *websocket.Conn
+	nextRequestId int
+	lastError     string
+	commands      chan []obsRequest
+	mutex         sync.Mutex
+}
+
+// Health of the connection to OBS, for display on the match play page.
+type ObsStatus struct {
+	Connected bool
+	LastError string
+}
+
+// A single scene switch or source visibility change to make in OBS. If SourceName is empty, the program output is
+// switched to the scene; otherwise the source within the scene is shown or hidden.
+type ObsAction struct {
+	SceneName     string
+	SourceName    string
+	SourceVisible bool
+}
+
+type obsMessage struct {
+	Op   int `json:"op"`
+	Data any `json:"d"`
+}
+
+type obsHello struct {
+	Authentication *struct {
+		Challenge string `json:"challenge"`
+		Salt      string `json:"salt"`
+	} `json:"authentication"`
+}
+
+type obsIdentify struct {
+	RpcVersion         int    `json:"rpcVersion"`
+	Authentication     string `json:"authentication,omitempty"`
+	EventSubscriptions int    `json:"eventSubscriptions"`
+}
+
+type obsRequest struct {
+	RequestType string         `json:"requestType"`
+	RequestId   string         `json:"requestId"`
+	RequestData map[string]any `json:"requestData,omitempty"`
+}
+
+type obsRequestResponse struct {
+	RequestType   string `json:"requestType"`
+	RequestId     string `json:"requestId"`
+	RequestStatus struct {
+		Result  bool   `json:"result"`
+		Code    int    `json:"code"`
+		Comment string `json:"comment"`
+	} `json:"requestStatus"`
+	ResponseData map[string]any `json:"responseData"`
+}
+
+func NewObsClient() *ObsClient {
+	return &ObsClient{commands: make(chan []obsRequest, obsCommandQueueSize)}
+}
+
+// Sets the OBS address (host with optional port) and WebSocket server password, dropping any existing connection if
+// they have changed.
+func (client *ObsClient) SetSettings(address, password string) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	if address != client.address || password != client.password {
+		client.disconnect("")
+	}
+	client.address = address
+	client.password = password
+}
+
+// Loops indefinitely to execute queued commands in order and to keep the connection to OBS alive.
+func (client *ObsClient) Run() {
+	for {
+		select {
+		case requests := <-client.commands:
+			client.execute(requests)
+		case <-time.After(obsHealthCheckPeriodSec * time.Second):
+			client.checkConnection()
+		}
+	}
+}
+
+// Returns the current health of the connection to OBS.
+func (client *ObsClient) Status() ObsStatus {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	return ObsStatus{Connected: client.conn != nil, LastError: client.lastError}
+}
+
+// Queues the given scene and source changes to be made in OBS.
+func (client *ObsClient) PerformActions(actions []ObsAction) {
+	var requests []obsRequest
+	for _, action := range actions {
+		if action.SourceName == "" {
+			requests = append(
+				requests,
+				obsRequest{
+					RequestType: "SetCurrentProgramScene",
+					RequestData: map[string]any{"sceneName": action.SceneName},
+				},
+			)
+		} else {
+			// The scene item ID is filled in from the source name when the request is executed.
+			requests = append(
+				requests,
+				obsRequest{
+					RequestType: "SetSceneItemEnabled",
+					RequestData: map[string]any{
+						"sceneName":        action.SceneName,
+						"sourceName":       action.SourceName,
+						"sceneItemEnabled": action.SourceVisible,
+					},
+				},
+			)
+		}
+	}
+	client.queue(requests)
+}
+
+// Queues a command for OBS to start recording.
+func (client *ObsClient) StartRecording() {
+	client.queue([]obsRequest{{RequestType: "StartRecord"}})
+}
+
+// Queues a command for OBS to stop recording.
+func (client *ObsClient) StopRecording() {
+	client.queue([]obsRequest{{RequestType: "StopRecord"}})
+}
+
+// Parses the given actions string, in which each action is either a scene name to switch to or a "scene/source" pair
+// to show that source within the scene, or "scene/-source" to hide it. Multiple actions are separated by semicolons.
+func ParseObsActions(actions string) ([]ObsAction, error) {
+	var parsedActions []ObsAction
+	for _, actionString := range strings.Split(actions, obsActionSeparator) {
+		if strings.TrimSpace(actionString) == "" {
+			continue
+		}
+		sceneName, sourceName, hasSource := strings.Cut(actionString, obsSceneSourceSeparator)
+		action := ObsAction{SceneName: strings.TrimSpace(sceneName)}
+		if action.SceneName == "" {
+			return nil, fmt.Errorf("OBS action %q is missing a scene name", strings.TrimSpace(actionString))
+		}
+		if hasSource {
+			sourceName = strings.TrimSpace(sourceName)
+			action.SourceVisible = !strings.HasPrefix(sourceName, obsSourceHiddenPrefix)
+			action.SourceName = strings.TrimSpace(strings.TrimPrefix(sourceName, obsSourceHiddenPrefix))
+			if action.SourceName == "" {
+				return nil, fmt.Errorf("OBS action %q is missing a source name", strings.TrimSpace(actionString))
+			}
+		}
+		parsedActions = append(parsedActions, action)
+	}
+	return parsedActions, nil
+}
+
+func (client *ObsClient) queue(requests []obsRequest) {
+	client.mutex.Lock()
+	enabled := client.address != ""
+	client.mutex.Unlock()
+	if !enabled || len(requests) == 0 {
+		return
+	}
+
+	select {
+	case client.commands <- requests:
+	default:
+		log.Printf("Dropping OBS command %s due to a full queue.", requests[0].RequestType)
+	}
+}
+
+// Sends the given requests to OBS in order, connecting first if necessary.
+func (client *ObsClient) execute(requests []obsRequest) {
+	conn, err := client.connect()
+	if err != nil {
+		log.Printf("Failed to connect to OBS: %v", err)
+		return
+	}
+
+	for _, request := range requests {
+		if request.RequestType == "SetSceneItemEnabled" {
+			response, err := client.sendRequest(
+				conn,
+				obsRequest{
+					RequestType: "GetSceneItemId",
+					RequestData: map[string]any{
+						"sceneName": request.RequestData["sceneName"], "sourceName": request.RequestData["sourceName"],
+					},
+				},
+			)
+			if err != nil {
+				log.Printf("Failed to find source %v in OBS scene %v: %v", request.RequestData["sourceName"],
+					request.RequestData["sceneName"], err)
+				continue
+			}
+			request.RequestData = map[string]any{
+				"sceneName":        request.RequestData["sceneName"],
+				"sceneItemId":      response.ResponseData["sceneItemId"],
+				"sceneItemEnabled": request.RequestData["sceneItemEnabled"],
+			}
+		}
+		if _, err := client.sendRequest(conn, request); err != nil {
+			log.Printf("Failed to send %s request to OBS: %v", request.RequestType, err)
+		}
+	}
+}
+
+// Connects to OBS if not already connected, and otherwise checks that the connection is still alive.
+func (client *ObsClient) checkConnection() {
+	client.mutex.Lock()
+	address, conn := client.address, client.conn
+	client.mutex.Unlock()
+	if address == "" {
+		return
+	}
+	if conn == nil {
+		client.connect()
+		return
+	}
+	client.sendRequest(conn, obsRequest{RequestType: "GetVersion"})
+}
+
+// Returns the websocket connection to OBS, first opening it and completing the authentication handshake if there
+// isn't one. The network I/O is done without holding the mutex so that a slow or unreachable OBS host doesn't hold up
+// callers of Status().
+func (client *ObsClient) connect() (*websocket.Conn, error) {
+	client.mutex.Lock()
+	conn, address, password := client.conn, client.address, client.password
+	client.mutex.Unlock()
+	if conn != nil {
+		return conn, nil
+	}
+	if address == "" {
+		return nil, fmt.Errorf("OBS address is not configured")
+	}
+
+	host := address
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(host, strconv.Itoa(obsPort))
+	}
+	dialer := websocket.Dialer{HandshakeTimeout: obsConnectTimeoutMs * time.Millisecond}
+	conn, _, err := dialer.Dial((&url.URL{Scheme: "ws", Host: host}).String(), nil)
+	if err == nil {
+		if err = obsHandshake(conn, password); err != nil {
+			conn.Close()
+		}
+	}
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	if address != client.address || password != client.password {
+		// The settings were changed while connecting; the next command or health check will use the new ones.
+		if err == nil {
+			conn.Close()
+		}
+		return nil, fmt.Errorf("OBS settings changed while connecting")
+	}
+	if err != nil {
+		client.lastError = err.Error()
+		return nil, err
+	}
+	client.conn = conn
+	client.lastError = ""
+	log.Printf("Connected to OBS at %s.", host)
+	return conn, nil
+}
+
+// Completes the OBS authentication handshake on the given newly opened connection.
+func obsHandshake(conn *websocket.Conn, password string) error {
+	var hello obsHello
+	if err := readObsMessage(conn, obsOpHello, &hello); err != nil {
+		return err
+	}
+	identify := obsIdentify{RpcVersion: obsRpcVersion}
+	if hello.Authentication != nil {
+		identify.Authentication = obsAuthenticationString(
+			password, hello.Authentication.Salt, hello.Authentication.Challenge,
+		)
+	}
+	if err := conn.WriteJSON(obsMessage{Op: obsOpIdentify, Data: identify}); err != nil {
+		return err
+	}
+	if err := readObsMessage(conn, obsOpIdentified, nil); err != nil {
+		if closeError, ok := err.(*websocket.CloseError); ok {
+			err = fmt.Errorf("OBS closed the connection: %s", closeError.Text)
+		}
+		return err
+	}
+	return nil
+}
+
+// Sends the given request on the given connection and waits for its response. Drops the connection if OBS can't be
+// reached. Only called from the goroutine that runs the commands, so the request ID needs no locking.
+func (client *ObsClient) sendRequest(conn *websocket.Conn, request obsRequest) (*obsRequestResponse, error) {
+	client.nextRequestId++
+	request.RequestId = strconv.Itoa(client.nextRequestId)
+	if err := conn.WriteJSON(obsMessage{Op: obsOpRequest, Data: request}); err != nil {
+		client.dropConnection(conn, err.Error())
+		return nil, err
+	}
+	for {
+		var response obsRequestResponse
+		if err := readObsMessage(conn, obsOpRequestResponse, &response); err != nil {
+			client.dropConnection(conn, err.Error())
+			return nil, err
+		}
+		if response.RequestId != request.RequestId {
+			// Ignore the response to an earlier request that timed out.
+			continue
+		}
+		if !response.RequestStatus.Result {
+			return &response, fmt.Errorf(
+				"OBS returned code %d: %s", response.RequestStatus.Code, response.RequestStatus.Comment,
+			)
+		}
+		return &response, nil
+	}
+}
+
+// Reads messages from the given connection until one with the given opcode arrives and decodes its data into the
+// given value.
+func readObsMessage(conn *websocket.Conn, op int, data any) error {
+	for {
+		conn.SetReadDeadline(time.Now().Add(obsResponseTimeoutMs * time.Millisecond))
+		var message struct {
+			Op   int             `json:"op"`
+			Data json.RawMessage `json:"d"`
+		}
+		if err := conn.ReadJSON(&message); err != nil {
+			return err
+		}
+		if message.Op == op {
+			if data == nil {
+				return nil
+			}
+			return json.Unmarshal(message.Data, data)
+		}
+	}
+}
+
+// Closes the given connection after a failure and, unless it has already been replaced, records the given error.
+func (client *ObsClient) dropConnection(conn *websocket.Conn, lastError string) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	if client.conn == conn {
+		client.disconnect(lastError)
+	} else {
+		conn.Close()
+	}
+}
+
+// Closes the connection to OBS, if any, and records the given error. Must be called with the mutex held.
+func (client *ObsClient) disconnect(lastError string) {
+	if client.conn != nil {
+		client.conn.Close()
+		client.conn = nil
+		if lastError != "" {
+			log.Printf("Disconnected from OBS: %s", lastError)
+		}
+	}
+	client.lastError = lastError
+}
+
+// Returns the authentication string expected by OBS for the given password and the salt and challenge it provided.
+func obsAuthenticationString(password, salt, challenge string) string {
+	secretHash := sha256.Sum256([]byte(password + salt))
+	secret := base64.StdEncoding.EncodeToString(secretHash[:])
+	authenticationHash := sha256.Sum256([]byte(secret + challenge))
+	return base64.StdEncoding.EncodeToString(authenticationHash[:])
+}
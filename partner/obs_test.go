@@ -0,0 +1,262 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package partner
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// Fake OBS WebSocket server that authenticates clients and records the requests they make.
+type fakeObsServer struct {
+	*httptest.Server
+	password   string
+	sceneItems map[string]int
+	requests   []obsRequest
+	conns      []*websocket.Conn
+	mutex      sync.Mutex
+}
+
+func newFakeObsServer(t *testing.T, password string) *fakeObsServer {
+	server := &fakeObsServer{password: password, sceneItems: map[string]int{"Match/Score Bug": 7}}
+	upgrader := websocket.Upgrader{}
+	server.Server = httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				conn, err := upgrader.Upgrade(w, r, nil)
+				if !assert.Nil(t, err) {
+					return
+				}
+				defer conn.Close()
+				server.mutex.Lock()
+				server.conns = append(server.conns, conn)
+				server.mutex.Unlock()
+				server.handleConnection(t, conn)
+			},
+		),
+	)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func (server *fakeObsServer) address() string {
+	return strings.TrimPrefix(server.URL, "http://")
+}
+
+func (server *fakeObsServer) handleConnection(t *testing.T, conn *websocket.Conn) {
+	hello := map[string]any{"obsWebSocketVersion": "5.0.0", "rpcVersion": 1}
+	if server.password != "" {
+		hello["authentication"] = map[string]string{"challenge": "challenge123", "salt": "salt456"}
+	}
+	assert.Nil(t, conn.WriteJSON(obsMessage{Op: obsOpHello, Data: hello}))
+
+	var identify struct {
+		Op int
+		D  obsIdentify
+	}
+	if err := conn.ReadJSON(&identify); err != nil {
+		return
+	}
+	assert.Equal(t, obsOpIdentify, identify.Op)
+	if server.password != "" &&
+		identify.D.Authentication != obsAuthenticationString(server.password, "salt456", "challenge123") {
+		conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(4009, "Authentication failed."))
+		return
+	}
+	assert.Nil(t, conn.WriteJSON(obsMessage{Op: obsOpIdentified, Data: map[string]int{"negotiatedRpcVersion": 1}}))
+
+	for {
+		var message struct {
+			Op int
+			D  obsRequest
+		}
+		if err := conn.ReadJSON(&message); err != nil {
+			return
+		}
+		request := message.D
+		var response obsRequestResponse
+		response.RequestType = request.RequestType
+		response.RequestId = request.RequestId
+		response.RequestStatus.Result = true
+		response.RequestStatus.Code = 100
+		if request.RequestType == "GetSceneItemId" {
+			sceneItemId, ok := server.sceneItems[request.RequestData["sceneName"].(string)+"/"+
+				request.RequestData["sourceName"].(string)]
+			if ok {
+				response.ResponseData = map[string]any{"sceneItemId": sceneItemId}
+			} else {
+				response.RequestStatus.Result = false
+				response.RequestStatus.Code = 600
+				response.RequestStatus.Comment = "No scene items were found."
+			}
+		}
+		if request.RequestType != "GetVersion" {
+			server.mutex.Lock()
+			server.requests = append(server.requests, request)
+			server.mutex.Unlock()
+		}
+		assert.Nil(t, conn.WriteJSON(obsMessage{Op: obsOpRequestResponse, Data: response}))
+	}
+}
+
+func (server *fakeObsServer) getRequests() []string {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	var requests []string
+	for _, request := range server.requests {
+		requestData, _ := json.Marshal(request.RequestData)
+		requests = append(requests, request.RequestType+" "+string(requestData))
+	}
+	return requests
+}
+
+func TestParseObsActions(t *testing.T) {
+	actions, err := ParseObsActions("")
+	assert.Nil(t, err)
+	assert.Empty(t, actions)
+
+	actions, err = ParseObsActions(" Match ; Match / Score Bug;Intro/-Sponsors;")
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		[]ObsAction{
+			{SceneName: "Match"},
+			{SceneName: "Match", SourceName: "Score Bug", SourceVisible: true},
+			{SceneName: "Intro", SourceName: "Sponsors", SourceVisible: false},
+		},
+		actions,
+	)
+
+	_, err = ParseObsActions("Match;/Score Bug")
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "OBS action \"/Score Bug\" is missing a scene name", err.Error())
+	}
+	_, err = ParseObsActions("Match/-")
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "OBS action \"Match/-\" is missing a source name", err.Error())
+	}
+}
+
+func TestObsClientActions(t *testing.T) {
+	server := newFakeObsServer(t, "password")
+	client := NewObsClient()
+
+	// Nothing should be queued while OBS isn't configured.
+	client.PerformActions([]ObsAction{{SceneName: "Match"}})
+	assert.Empty(t, client.commands)
+
+	client.SetSettings(server.address(), "password")
+	client.PerformActions(
+		[]ObsAction{
+			{SceneName: "Match"},
+			{SceneName: "Match", SourceName: "Score Bug", SourceVisible: true},
+			{SceneName: "Match", SourceName: "Missing", SourceVisible: true},
+		},
+	)
+	client.StartRecording()
+	client.StopRecording()
+	for len(client.commands) > 0 {
+		client.execute(<-client.commands)
+	}
+	assert.Equal(
+		t,
+		[]string{
+			"SetCurrentProgramScene {\"sceneName\":\"Match\"}",
+			"GetSceneItemId {\"sceneName\":\"Match\",\"sourceName\":\"Score Bug\"}",
+			"SetSceneItemEnabled {\"sceneItemEnabled\":true,\"sceneItemId\":7,\"sceneName\":\"Match\"}",
+			"GetSceneItemId {\"sceneName\":\"Match\",\"sourceName\":\"Missing\"}",
+			"StartRecord null",
+			"StopRecord null",
+		},
+		server.getRequests(),
+	)
+	assert.Equal(t, ObsStatus{Connected: true}, client.Status())
+}
+
+func TestObsClientConnectionHealth(t *testing.T) {
+	server := newFakeObsServer(t, "password")
+	client := NewObsClient()
+
+	client.SetSettings(server.address(), "wrong")
+	client.checkConnection()
+	status := client.Status()
+	assert.False(t, status.Connected)
+	assert.Equal(t, "OBS closed the connection: Authentication failed.", status.LastError)
+
+	client.SetSettings(server.address(), "password")
+	client.checkConnection()
+	assert.Equal(t, ObsStatus{Connected: true}, client.Status())
+
+	// A dropped connection should be noticed by the next health check and re-established by the one after.
+	server.mutex.Lock()
+	server.conns[len(server.conns)-1].Close()
+	server.mutex.Unlock()
+	client.checkConnection()
+	assert.False(t, client.Status().Connected)
+	assert.NotEmpty(t, client.Status().LastError)
+	client.checkConnection()
+	assert.Equal(t, ObsStatus{Connected: true}, client.Status())
+
+	// An unreachable server should be reported without blocking.
+	server.Close()
+	server.mutex.Lock()
+	server.conns[len(server.conns)-1].Close()
+	server.mutex.Unlock()
+	client.checkConnection()
+	client.checkConnection()
+	assert.False(t, client.Status().Connected)
+	assert.Contains(t, client.Status().LastError, "connection refused")
+
+	// An unauthenticated server should accept any password.
+	server = newFakeObsServer(t, "")
+	client.SetSettings(server.address(), "")
+	client.checkConnection()
+	assert.Equal(t, ObsStatus{Connected: true}, client.Status())
+}
+
+func TestObsClientStatusWhileConnecting(t *testing.T) {
+	// Accept TCP connections but never complete the websocket handshake, so that the dial hangs until it times out.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer listener.Close()
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		if conn, err := listener.Accept(); err == nil {
+			accepted <- conn
+		}
+	}()
+
+	client := NewObsClient()
+	client.SetSettings(listener.Addr().String(), "password")
+	done := make(chan struct{})
+	go func() {
+		client.checkConnection()
+		close(done)
+	}()
+	conn := <-accepted
+	defer conn.Close()
+
+	startTime := time.Now()
+	assert.Equal(t, ObsStatus{}, client.Status())
+	assert.Less(t, time.Since(startTime), 50*time.Millisecond)
+	select {
+	case <-done:
+		assert.Fail(t, "connection attempt finished before the handshake timeout")
+	default:
+	}
+
+	<-done
+	status := client.Status()
+	assert.False(t, status.Connected)
+	assert.Contains(t, status.LastError, "timeout")
+}
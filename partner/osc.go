@@ -0,0 +1,153 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Client for sending Open Sound Control (OSC) cues over UDP to show control systems and audio consoles.
+
+package partner
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"log"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	oscCueSeparator     = ";"
+	oscAddressPrefix    = "/"
+	oscStringAlignBytes = 4
+)
+
+type OscClient struct {
+	targets []*net.UDPAddr
+	conn    *net.UDPConn
+	mutex   sync.Mutex
+}
+
+// A single OSC message, consisting of an address pattern and arguments of type int32, float32 or string.
+type OscMessage struct {
+	Address   string
+	Arguments []any
+}
+
+func NewOscClient() *OscClient {
+	return new(OscClient)
+}
+
+// Sets the comma-separated list of host:port targets that cues are sent to. Targets that can't be resolved are logged
+// and skipped.
+func (client *OscClient) SetTargets(targets string) {
+	var targetAddresses []*net.UDPAddr
+	for _, target := range strings.Split(targets, ",") {
+		target = strings.TrimSpace(target)
+		if target == "" {
+			continue
+		}
+		address, err := net.ResolveUDPAddr("udp", target)
+		if err != nil {
+			log.Printf("Ignoring invalid OSC target %s: %v", target, err)
+			continue
+		}
+		targetAddresses = append(targetAddresses, address)
+	}
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.targets = targetAddresses
+}
+
+// Sends the given messages to all configured targets.
+func (client *OscClient) Send(messages []OscMessage) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	if len(client.targets) == 0 || len(messages) == 0 {
+		return
+	}
+
+	if client.conn == nil {
+		conn, err := net.ListenUDP("udp", nil)
+		if err != nil {
+			log.Printf("Failed to open OSC socket: %v", err)
+			return
+		}
+		client.conn = conn
+	}
+	for _, message := range messages {
+		packet, err := message.encode()
+		if err != nil {
+			log.Printf("Failed to encode OSC message %s: %v", message.Address, err)
+			continue
+		}
+		for _, target := range client.targets {
+			if _, err = client.conn.WriteToUDP(packet, target); err != nil {
+				log.Printf("Failed to send OSC message %s to %s: %v", message.Address, target, err)
+			}
+		}
+	}
+}
+
+// Parses the given cue definition, in which each message is an address followed by any arguments separated by spaces,
+// and multiple messages are separated by semicolons. Arguments are sent as integers or floats if they can be parsed as
+// such and as strings otherwise.
+func ParseOscCues(cues string) ([]OscMessage, error) {
+	var messages []OscMessage
+	for _, cue := range strings.Split(cues, oscCueSeparator) {
+		fields := strings.Fields(cue)
+		if len(fields) == 0 {
+			continue
+		}
+		if !strings.HasPrefix(fields[0], oscAddressPrefix) {
+			return nil, fmt.Errorf("OSC address %q must start with %q", fields[0], oscAddressPrefix)
+		}
+		message := OscMessage{Address: fields[0]}
+		for _, field := range fields[1:] {
+			if intValue, err := strconv.ParseInt(field, 10, 32); err == nil {
+				message.Arguments = append(message.Arguments, int32(intValue))
+			} else if floatValue, err := strconv.ParseFloat(field, 32); err == nil {
+				message.Arguments = append(message.Arguments, float32(floatValue))
+			} else {
+				message.Arguments = append(message.Arguments, field)
+			}
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
+
+// Encodes the message into its OSC 1.0 binary representation.
+func (message *OscMessage) encode() ([]byte, error) {
+	var buffer bytes.Buffer
+	writeOscString(&buffer, message.Address)
+	typeTags := ","
+	var arguments bytes.Buffer
+	for _, argument := range message.Arguments {
+		switch value := argument.(type) {
+		case int32:
+			typeTags += "i"
+			binary.Write(&arguments, binary.BigEndian, value)
+		case float32:
+			typeTags += "f"
+			binary.Write(&arguments, binary.BigEndian, math.Float32bits(value))
+		case string:
+			typeTags += "s"
+			writeOscString(&arguments, value)
+		default:
+			return nil, fmt.Errorf("unsupported argument type %T", argument)
+		}
+	}
+	writeOscString(&buffer, typeTags)
+	buffer.Write(arguments.Bytes())
+	return buffer.Bytes(), nil
+}
+
+// Writes the given string null-terminated and padded out to a multiple of four bytes.
+func writeOscString(buffer *bytes.Buffer, value string) {
+	buffer.WriteString(value)
+	padding := oscStringAlignBytes - len(value)%oscStringAlignBytes
+	buffer.Write(make([]byte, padding))
+}
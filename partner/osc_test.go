@@ -0,0 +1,84 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package partner
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOscCues(t *testing.T) {
+	messages, err := ParseOscCues("")
+	assert.Nil(t, err)
+	assert.Empty(t, messages)
+
+	messages, err = ParseOscCues(" /cue/5/start ;/ch/01/mix/fader 0.75; /ch/01/mix/on 0 ;/label Q12 ;")
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		[]OscMessage{
+			{Address: "/cue/5/start"},
+			{Address: "/ch/01/mix/fader", Arguments: []any{float32(0.75)}},
+			{Address: "/ch/01/mix/on", Arguments: []any{int32(0)}},
+			{Address: "/label", Arguments: []any{"Q12"}},
+		},
+		messages,
+	)
+
+	_, err = ParseOscCues("/cue/1/start;cue/2/start")
+	assert.EqualError(t, err, "OSC address \"cue/2/start\" must start with \"/\"")
+}
+
+func TestOscMessageEncode(t *testing.T) {
+	message := OscMessage{Address: "/cue", Arguments: []any{int32(258), float32(0.5), "Q1"}}
+	packet, err := message.encode()
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		[]byte(
+			"/cue\x00\x00\x00\x00"+",ifs\x00\x00\x00\x00"+"\x00\x00\x01\x02"+"\x3f\x00\x00\x00"+"Q1\x00\x00",
+		),
+		packet,
+	)
+
+	message = OscMessage{Address: "/go", Arguments: []any{true}}
+	_, err = message.encode()
+	assert.EqualError(t, err, "unsupported argument type bool")
+}
+
+func TestOscClientSend(t *testing.T) {
+	listener1 := listenOscUdp(t)
+	listener2 := listenOscUdp(t)
+	client := NewOscClient()
+
+	// Nothing should be sent while no targets are configured.
+	client.Send([]OscMessage{{Address: "/cue/1/start"}})
+	assert.Nil(t, client.conn)
+
+	client.SetTargets(listener1.LocalAddr().String() + ", invalid-target ," + listener2.LocalAddr().String())
+	assert.Equal(t, 2, len(client.targets))
+	client.Send([]OscMessage{{Address: "/cue/1/start"}, {Address: "/sound", Arguments: []any{"abort"}}})
+	for _, listener := range []*net.UDPConn{listener1, listener2} {
+		assert.Equal(t, []byte("/cue/1/start\x00\x00\x00\x00,\x00\x00\x00"), readOscPacket(t, listener))
+		assert.Equal(t, []byte("/sound\x00\x00,s\x00\x00abort\x00\x00\x00"), readOscPacket(t, listener))
+	}
+}
+
+func listenOscUdp(t *testing.T) *net.UDPConn {
+	listener, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	assert.Nil(t, err)
+	t.Cleanup(func() { listener.Close() })
+	return listener
+}
+
+func readOscPacket(t *testing.T, listener *net.UDPConn) []byte {
+	buffer := make([]byte, 1024)
+	listener.SetReadDeadline(time.Now().Add(time.Second))
+	n, err := listener.Read(buffer)
+	assert.Nil(t, err)
+	return buffer[:n]
+}
@@ -0,0 +1,672 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Client for publishing event data to The Blue Alliance via its Trusted API, with a queue for retrying requests
+// that fail while the venue internet connection is down, and for downloading team information via its read API.
+
+package partner
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+)
+
+const (
+	tbaBaseUrl             = "https://www.thebluealliance.com"
+	tbaRequestTimeoutSec   = 10
+	tbaRetryIntervalSec    = 30
+	tbaMaxQueuedRequestAge = 24 * time.Hour
+)
+
+type TbaClient struct {
+	BaseUrl       string
+	eventCode     string
+	secretId      string
+	secret        string
+	apiKey        string
+	httpClient    *http.Client
+	eventNames    map[string]string
+	queue         []tbaRequest
+	nextRequestId int
+	wakeChan      chan struct{}
+	lastError     string
+	lastPublished time.Time
+	mutex         sync.Mutex
+}
+
+// Snapshot of the state of the publishing queue, for display on the settings page.
+type TbaPublishingStatus struct {
+	QueuedRequests int
+	LastError      string
+	LastPublished  time.Time
+}
+
+type tbaRequest struct {
+	id       int
+	resource string
+	action   string
+	body     []byte
+	queuedAt time.Time
+}
+
+type TbaTeam struct {
+	Key        string `json:"key"`
+	TeamNumber int    `json:"team_number"`
+	Name       string `json:"name"`
+	Nickname   string `json:"nickname"`
+	City       string `json:"city"`
+	StateProv  string `json:"state_prov"`
+	Country    string `json:"country"`
+	SchoolName string `json:"school_name"`
+	RookieYear int    `json:"rookie_year"`
+}
+
+type TbaRobot struct {
+	RobotName string `json:"robot_name"`
+	Year      int    `json:"year"`
+}
+
+type TbaTeamAward struct {
+	Name      string `json:"name"`
+	EventKey  string `json:"event_key"`
+	Year      int    `json:"year"`
+	EventName string `json:"-"`
+}
+
+type tbaEvent struct {
+	Name string `json:"name"`
+}
+
+type tbaMediaItem struct {
+	Type    string         `json:"type"`
+	Details map[string]any `json:"details"`
+}
+
+type TbaMatch struct {
+	CompLevel      string                        `json:"comp_level"`
+	SetNumber      int                           `json:"set_number"`
+	MatchNumber    int                           `json:"match_number"`
+	Alliances      map[string]*TbaMatchAlliance  `json:"alliances"`
+	ScoreBreakdown map[string]*TbaScoreBreakdown `json:"score_breakdown"`
+	TimeString     string                        `json:"time_string"`
+	TimeUtc        string                        `json:"time_utc"`
+	DisplayName    string                        `json:"display_name"`
+}
+
+type TbaMatchAlliance struct {
+	Teams      []string `json:"teams"`
+	Surrogates []string `json:"surrogates"`
+	Dqs        []string `json:"dqs"`
+	Score      *int     `json:"score"`
+}
+
+type TbaScoreBreakdown struct {
+	AutoLeavePoints             int  `json:"autoLeavePoints"`
+	AutoPoints                  int  `json:"autoPoints"`
+	AutoGamepiece1Level1Count   int  `json:"autoGamepiece1Level1Count"`
+	AutoGamepiece1Level2Count   int  `json:"autoGamepiece1Level2Count"`
+	AutoGamepiece2Count         int  `json:"autoGamepiece2Count"`
+	TeleopGamepiece1Level1Count int  `json:"teleopGamepiece1Level1Count"`
+	TeleopGamepiece1Level2Count int  `json:"teleopGamepiece1Level2Count"`
+	TeleopGamepiece2Count       int  `json:"teleopGamepiece2Count"`
+	Gamepiece1Points            int  `json:"gamepiece1Points"`
+	Gamepiece2Points            int  `json:"gamepiece2Points"`
+	ParkPoints                  int  `json:"parkPoints"`
+	FoulCount                   int  `json:"foulCount"`
+	TechFoulCount               int  `json:"techFoulCount"`
+	FoulPoints                  int  `json:"foulPoints"`
+	TotalPoints                 int  `json:"totalPoints"`
+	LeaveBonusAchieved          bool `json:"leaveBonusAchieved"`
+	Gamepiece1BonusAchieved     bool `json:"gamepiece1BonusAchieved"`
+	ParkBonusAchieved           bool `json:"parkBonusAchieved"`
+	RP                          int  `json:"rp"`
+}
+
+type TbaRankings struct {
+	Breakdowns []string     `json:"breakdowns"`
+	Rankings   []TbaRanking `json:"rankings"`
+}
+
+type TbaRanking struct {
+	TeamKey          string `json:"team_key"`
+	Rank             int    `json:"rank"`
+	RP               int    `json:"RP"`
+	MatchPoints      int    `json:"Match"`
+	AutoPoints       int    `json:"Auto"`
+	Gamepiece2Points int    `json:"Gamepiece2"`
+	Wins             int    `json:"wins"`
+	Losses           int    `json:"losses"`
+	Ties             int    `json:"ties"`
+	Dqs              int    `json:"dqs"`
+	Played           int    `json:"played"`
+}
+
+type TbaAward struct {
+	Name    string  `json:"name_str"`
+	TeamKey *string `json:"team_key"`
+	Awardee *string `json:"awardee"`
+}
+
+// Creates a new TBA client pointing at the production server.
+func NewTbaClient() *TbaClient {
+	return &TbaClient{
+		BaseUrl:    tbaBaseUrl,
+		httpClient: &http.Client{Timeout: tbaRequestTimeoutSec * time.Second},
+		eventNames: make(map[string]string),
+		wakeChan:   make(chan struct{}, 1),
+	}
+}
+
+// Updates the event code and credentials used to publish and download. Any queued requests for a different event are
+// discarded.
+func (client *TbaClient) SetSettings(eventCode, secretId, secret, apiKey string) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	if eventCode != client.eventCode {
+		client.queue = nil
+	}
+	client.eventCode = eventCode
+	client.secretId = secretId
+	client.secret = secret
+	client.apiKey = apiKey
+}
+
+// Returns true if a read API key has been configured, without which team information can't be downloaded.
+func (client *TbaClient) CanDownload() bool {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	return client.apiKey != ""
+}
+
+// Returns the given team's information, or nil if TBA doesn't know about the team.
+func (client *TbaClient) GetTeam(teamNumber int) (*TbaTeam, error) {
+	var team TbaTeam
+	found, err := client.getRequest(fmt.Sprintf("/api/v3/team/%s", getTbaTeam(teamNumber)), &team)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &team, nil
+}
+
+// Returns the name of the given team's robot for the given year, or the empty string if it isn't named.
+func (client *TbaClient) GetRobotName(teamNumber, year int) (string, error) {
+	var robots []TbaRobot
+	if _, err := client.getRequest(fmt.Sprintf("/api/v3/team/%s/robots", getTbaTeam(teamNumber)), &robots); err != nil {
+		return "", err
+	}
+	for _, robot := range robots {
+		if robot.Year == year {
+			return robot.RobotName, nil
+		}
+	}
+	return "", nil
+}
+
+// Returns all awards the given team has won, with the names of the events at which they were won filled in.
+func (client *TbaClient) GetTeamAwards(teamNumber int) ([]TbaTeamAward, error) {
+	var awards []TbaTeamAward
+	if _, err := client.getRequest(fmt.Sprintf("/api/v3/team/%s/awards", getTbaTeam(teamNumber)), &awards); err != nil {
+		return nil, err
+	}
+	for i, award := range awards {
+		eventName, err := client.getEventName(award.EventKey)
+		if err != nil {
+			return nil, err
+		}
+		awards[i].EventName = eventName
+	}
+	return awards, nil
+}
+
+// Returns the PNG image data for the given team's avatar for the given year, or nil if the team doesn't have one.
+func (client *TbaClient) GetTeamAvatar(teamNumber, year int) ([]byte, error) {
+	var mediaItems []tbaMediaItem
+	path := fmt.Sprintf("/api/v3/team/%s/media/%d", getTbaTeam(teamNumber), year)
+	if _, err := client.getRequest(path, &mediaItems); err != nil {
+		return nil, err
+	}
+	for _, item := range mediaItems {
+		if item.Type == "avatar" {
+			base64Image, ok := item.Details["base64Image"].(string)
+			if !ok {
+				return nil, fmt.Errorf("avatar for team %d has no image data", teamNumber)
+			}
+			return base64.StdEncoding.DecodeString(base64Image)
+		}
+	}
+	return nil, nil
+}
+
+// Returns the name of the given event, caching it since the same events recur across many teams' awards.
+func (client *TbaClient) getEventName(eventKey string) (string, error) {
+	client.mutex.Lock()
+	eventName, ok := client.eventNames[eventKey]
+	client.mutex.Unlock()
+	if ok {
+		return eventName, nil
+	}
+
+	var event tbaEvent
+	if _, err := client.getRequest(fmt.Sprintf("/api/v3/event/%s", eventKey), &event); err != nil {
+		return "", err
+	}
+	client.mutex.Lock()
+	client.eventNames[eventKey] = event.Name
+	client.mutex.Unlock()
+	return event.Name, nil
+}
+
+// Makes an authenticated request to the TBA read API and decodes the response into the given value. Returns false
+// without an error if the requested resource doesn't exist.
+func (client *TbaClient) getRequest(path string, v any) (bool, error) {
+	client.mutex.Lock()
+	url := client.BaseUrl + path
+	apiKey := client.apiKey
+	client.mutex.Unlock()
+
+	request, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return false, err
+	}
+	request.Header.Add("X-TBA-Auth-Key", apiKey)
+	resp, err := client.getHttpClient().Do(request)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == 404 {
+		return false, nil
+	}
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return false, fmt.Errorf("got status code %d from TBA for %s: %s", resp.StatusCode, path, body)
+	}
+	return true, json.NewDecoder(resp.Body).Decode(v)
+}
+
+// Loops indefinitely as the single worker that sends queued requests to TBA, both as soon as they are published and
+// periodically to retry any that previously failed because TBA could not be reached.
+func (client *TbaClient) Run() {
+	retryTicker := time.NewTicker(tbaRetryIntervalSec * time.Second)
+	defer retryTicker.Stop()
+	for {
+		client.sendQueuedRequests()
+		select {
+		case <-client.wakeChan:
+		case <-retryTicker.C:
+		}
+	}
+}
+
+// Returns the current state of the publishing queue.
+func (client *TbaClient) Status() TbaPublishingStatus {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	return TbaPublishingStatus{
+		QueuedRequests: len(client.queue),
+		LastError:      client.lastError,
+		LastPublished:  client.lastPublished,
+	}
+}
+
+// Publishes the full list of event teams.
+func (client *TbaClient) PublishTeams(database *model.Database) error {
+	teams, err := database.GetAllTeams()
+	if err != nil {
+		return err
+	}
+	teamKeys := make([]string, len(teams))
+	for i, team := range teams {
+		teamKeys[i] = getTbaTeam(team.Id)
+	}
+	return client.publish("team_list", "update", teamKeys)
+}
+
+// Publishes the schedule and results of all qualification and playoff matches.
+func (client *TbaClient) PublishMatches(database *model.Database) error {
+	qualMatches, err := database.GetMatchesByType(model.Qualification, false)
+	if err != nil {
+		return err
+	}
+	playoffMatches, err := database.GetMatchesByType(model.Playoff, false)
+	if err != nil {
+		return err
+	}
+	eventMatches := append(qualMatches, playoffMatches...)
+	tbaMatches := make([]TbaMatch, len(eventMatches))
+	for i, match := range eventMatches {
+		var matchResult *model.MatchResult
+		if match.IsComplete() {
+			if matchResult, err = database.GetMatchResultForMatch(match.Id); err != nil {
+				return err
+			}
+		}
+		tbaMatches[i] = createTbaMatch(&match, matchResult)
+	}
+	return client.publish("matches", "update", tbaMatches)
+}
+
+// Publishes the current qualification rankings.
+func (client *TbaClient) PublishRankings(database *model.Database) error {
+	rankings, err := database.GetAllRankings()
+	if err != nil {
+		return err
+	}
+	tbaRankings := TbaRankings{
+		Breakdowns: []string{"RP", "Match", "Auto", "Gamepiece2", "wins", "losses", "ties"},
+		Rankings:   make([]TbaRanking, len(rankings)),
+	}
+	for i, ranking := range rankings {
+		tbaRankings.Rankings[i] = TbaRanking{
+			TeamKey:          getTbaTeam(ranking.TeamId),
+			Rank:             ranking.Rank,
+			RP:               ranking.RankingPoints,
+			MatchPoints:      ranking.MatchPoints,
+			AutoPoints:       ranking.AutoPoints,
+			Gamepiece2Points: ranking.Gamepiece2Points,
+			Wins:             ranking.Wins,
+			Losses:           ranking.Losses,
+			Ties:             ranking.Ties,
+			Dqs:              ranking.Disqualifications,
+			Played:           ranking.Played,
+		}
+	}
+	return client.publish("rankings", "update", tbaRankings)
+}
+
+// Publishes the playoff alliances in seed order.
+func (client *TbaClient) PublishAlliances(database *model.Database) error {
+	alliances, err := database.GetAllAlliances()
+	if err != nil {
+		return err
+	}
+	tbaAlliances := make([][]string, len(alliances))
+	for i, alliance := range alliances {
+		tbaAlliances[i] = []string{}
+		for _, teamId := range alliance.TeamIds {
+			if teamId > 0 {
+				tbaAlliances[i] = append(tbaAlliances[i], getTbaTeam(teamId))
+			}
+		}
+	}
+	return client.publish("alliance_selections", "update", tbaAlliances)
+}
+
+// Publishes all judged, finalist and winner awards.
+func (client *TbaClient) PublishAwards(database *model.Database) error {
+	awards, err := database.GetAllAwards()
+	if err != nil {
+		return err
+	}
+	tbaAwards := make([]TbaAward, len(awards))
+	for i, award := range awards {
+		tbaAwards[i].Name = award.AwardName
+		if award.TeamId > 0 {
+			teamKey := getTbaTeam(award.TeamId)
+			tbaAwards[i].TeamKey = &teamKey
+		}
+		if award.PersonName != "" {
+			personName := award.PersonName
+			tbaAwards[i].Awardee = &personName
+		}
+	}
+	return client.publish("awards", "update", tbaAwards)
+}
+
+// Clears out all matches previously published for the event.
+func (client *TbaClient) DeletePublishedMatches() error {
+	client.mutex.Lock()
+	eventCode := client.eventCode
+	client.mutex.Unlock()
+	return client.publish("matches", "delete_all", eventCode)
+}
+
+// Publishes everything in the database, for use when enabling publishing partway through an event.
+func (client *TbaClient) PublishAll(database *model.Database) error {
+	if err := client.PublishTeams(database); err != nil {
+		return err
+	}
+	if err := client.PublishMatches(database); err != nil {
+		return err
+	}
+	if err := client.PublishRankings(database); err != nil {
+		return err
+	}
+	if err := client.PublishAlliances(database); err != nil {
+		return err
+	}
+	return client.PublishAwards(database)
+}
+
+// Serializes the given payload and queues it to be sent to TBA by the worker, so that callers never block on the
+// network and requests reach TBA in the order they were published. Each request carries the full current state of its
+// resource, so a newer request supersedes any queued older one of the same kind.
+func (client *TbaClient) publish(resource, action string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	client.mutex.Lock()
+	client.removeQueuedRequest(resource, action)
+	client.nextRequestId++
+	client.queue = append(
+		client.queue,
+		tbaRequest{id: client.nextRequestId, resource: resource, action: action, body: body, queuedAt: time.Now()},
+	)
+	client.mutex.Unlock()
+
+	// Wake up the worker without blocking if it has already been signaled.
+	select {
+	case client.wakeChan <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Attempts to send all queued requests in order, stopping at the first one that still can't get through. The mutex is
+// only held while inspecting and updating the queue, and not during the requests themselves.
+func (client *TbaClient) sendQueuedRequests() {
+	for {
+		client.mutex.Lock()
+		if len(client.queue) == 0 {
+			client.mutex.Unlock()
+			return
+		}
+		request := client.queue[0]
+		if time.Since(request.queuedAt) > tbaMaxQueuedRequestAge {
+			log.Printf("Discarding stale queued TBA %s/%s request.", request.resource, request.action)
+			client.queue = client.queue[1:]
+			client.mutex.Unlock()
+			continue
+		}
+		eventCode, secretId, secret := client.eventCode, client.secretId, client.secret
+		client.mutex.Unlock()
+
+		retryable, err := client.sendRequest(&request, eventCode, secretId, secret)
+
+		client.mutex.Lock()
+		if err != nil {
+			log.Printf("Failed to publish TBA %s/%s request: %v", request.resource, request.action, err)
+			client.lastError = err.Error()
+		} else {
+			client.lastError = ""
+			client.lastPublished = time.Now()
+		}
+		if err == nil || !retryable {
+			// Remove the request unless it was already superseded or discarded while it was being sent.
+			client.removeQueuedRequestById(request.id)
+		}
+		client.mutex.Unlock()
+		if err != nil && retryable {
+			return
+		}
+	}
+}
+
+// Drops any queued request for the given resource and action. Must be called with the mutex held.
+func (client *TbaClient) removeQueuedRequest(resource, action string) {
+	var queue []tbaRequest
+	for _, request := range client.queue {
+		if request.resource != resource || request.action != action {
+			queue = append(queue, request)
+		}
+	}
+	client.queue = queue
+}
+
+// Drops the queued request having the given ID, if it is still present. Must be called with the mutex held.
+func (client *TbaClient) removeQueuedRequestById(id int) {
+	var queue []tbaRequest
+	for _, request := range client.queue {
+		if request.id != id {
+			queue = append(queue, request)
+		}
+	}
+	client.queue = queue
+}
+
+// Signs and sends the given request using the given credentials. Returns whether a failure is worth retrying later
+// (i.e. is a connectivity problem or server error rather than a rejection of the request itself).
+func (client *TbaClient) sendRequest(request *tbaRequest, eventCode, secretId, secret string) (bool, error) {
+	path := fmt.Sprintf("/api/trusted/v1/event/%s/%s/%s", eventCode, request.resource, request.action)
+	signature := fmt.Sprintf("%x", md5.Sum(append([]byte(secret+path), request.body...)))
+
+	httpRequest, err := http.NewRequest("POST", client.BaseUrl+path, bytes.NewReader(request.body))
+	if err != nil {
+		return false, err
+	}
+	httpRequest.Header.Add("X-TBA-Auth-Id", secretId)
+	httpRequest.Header.Add("X-TBA-Auth-Sig", signature)
+	resp, err := client.getHttpClient().Do(httpRequest)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode >= 500, fmt.Errorf(
+			"got status code %d from TBA for %s/%s: %s", resp.StatusCode, request.resource, request.action, body,
+		)
+	}
+	return false, nil
+}
+
+func (client *TbaClient) getHttpClient() *http.Client {
+	if client.httpClient == nil {
+		return http.DefaultClient
+	}
+	return client.httpClient
+}
+
+// Converts the given match and its result (nil if not yet played) into the TBA format.
+func createTbaMatch(match *model.Match, matchResult *model.MatchResult) TbaMatch {
+	redAlliance := createTbaMatchAlliance(
+		[3]int{match.Red1, match.Red2, match.Red3},
+		[3]bool{match.Red1IsSurrogate, match.Red2IsSurrogate, match.Red3IsSurrogate},
+	)
+	blueAlliance := createTbaMatchAlliance(
+		[3]int{match.Blue1, match.Blue2, match.Blue3},
+		[3]bool{match.Blue1IsSurrogate, match.Blue2IsSurrogate, match.Blue3IsSurrogate},
+	)
+	tbaMatch := TbaMatch{
+		CompLevel:   match.TbaMatchKey.CompLevel,
+		SetNumber:   match.TbaMatchKey.SetNumber,
+		MatchNumber: match.TbaMatchKey.MatchNumber,
+		Alliances:   map[string]*TbaMatchAlliance{"red": redAlliance, "blue": blueAlliance},
+		TimeString:  match.Time.Local().Format("3:04 PM"),
+		TimeUtc:     match.Time.UTC().Format("2006-01-02T15:04:05"),
+		DisplayName: match.ShortName,
+	}
+
+	if matchResult != nil {
+		redSummary := matchResult.RedScore.Summarize(matchResult.BlueScore)
+		blueSummary := matchResult.BlueScore.Summarize(matchResult.RedScore)
+		redAlliance.Score = &redSummary.Score
+		blueAlliance.Score = &blueSummary.Score
+		redAlliance.Dqs = getTbaDqs(matchResult.RedScore, matchResult.RedCards, redAlliance.Teams)
+		blueAlliance.Dqs = getTbaDqs(matchResult.BlueScore, matchResult.BlueCards, blueAlliance.Teams)
+		tbaMatch.ScoreBreakdown = map[string]*TbaScoreBreakdown{
+			"red":  createTbaScoreBreakdown(matchResult.RedScore, matchResult.BlueScore, redSummary),
+			"blue": createTbaScoreBreakdown(matchResult.BlueScore, matchResult.RedScore, blueSummary),
+		}
+	}
+
+	return tbaMatch
+}
+
+func createTbaMatchAlliance(teamIds [3]int, surrogates [3]bool) *TbaMatchAlliance {
+	alliance := TbaMatchAlliance{Teams: []string{}, Surrogates: []string{}, Dqs: []string{}}
+	for i, teamId := range teamIds {
+		if teamId == 0 {
+			continue
+		}
+		alliance.Teams = append(alliance.Teams, getTbaTeam(teamId))
+		if surrogates[i] {
+			alliance.Surrogates = append(alliance.Surrogates, getTbaTeam(teamId))
+		}
+	}
+	return &alliance
+}
+
+func createTbaScoreBreakdown(score, opponentScore *game.Score, summary *game.ScoreSummary) *TbaScoreBreakdown {
+	breakdown := TbaScoreBreakdown{
+		AutoLeavePoints:             summary.LeavePoints,
+		AutoPoints:                  summary.AutoPoints,
+		AutoGamepiece1Level1Count:   score.Mayhem.AutoGamepiece1Level1Count,
+		AutoGamepiece1Level2Count:   score.Mayhem.AutoGamepiece1Level2Count,
+		AutoGamepiece2Count:         score.Mayhem.AutoGamepiece2Count,
+		TeleopGamepiece1Level1Count: score.Mayhem.TeleopGamepiece1Level1Count,
+		TeleopGamepiece1Level2Count: score.Mayhem.TeleopGamepiece1Level2Count,
+		TeleopGamepiece2Count:       score.Mayhem.TeleopGamepiece2Count,
+		Gamepiece1Points:            summary.Gamepiece1Points,
+		Gamepiece2Points:            summary.Gamepiece2Points,
+		ParkPoints:                  summary.ParkPoints,
+		FoulPoints:                  summary.FoulPoints,
+		TotalPoints:                 summary.Score,
+		LeaveBonusAchieved:          summary.LeaveBonusRankingPoint,
+		Gamepiece1BonusAchieved:     summary.Gamepiece1BonusRankingPoint,
+		ParkBonusAchieved:           summary.ParkBonusRankingPoint,
+		RP:                          summary.BonusRankingPoints,
+	}
+
+	// TBA expects the fouls committed by the opposing alliance, matching how the foul points are awarded.
+	for _, foul := range opponentScore.Fouls {
+		if foul.IsMajor {
+			breakdown.TechFoulCount++
+		} else {
+			breakdown.FoulCount++
+		}
+	}
+	return &breakdown
+}
+
+// Returns the teams on the alliance that were disqualified, either by a red card or a playoff disqualification.
+func getTbaDqs(score *game.Score, cards map[string]string, teamKeys []string) []string {
+	dqs := []string{}
+	for _, teamKey := range teamKeys {
+		teamId, _ := strconv.Atoi(teamKey[3:])
+		card := cards[strconv.Itoa(teamId)]
+		if score.PlayoffDq || card == "red" || card == "dq" {
+			dqs = append(dqs, teamKey)
+		}
+	}
+	return dqs
+}
+
+// Returns the TBA key for the given team number.
+func getTbaTeam(team int) string {
+	return fmt.Sprintf("frc%d", team)
+}
@@ -0,0 +1,412 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package partner
+
+import (
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// Fake implementation of the TBA Trusted API that verifies request signatures and records what was published.
+type fakeTbaServer struct {
+	*httptest.Server
+	secretId   string
+	secret     string
+	down       bool
+	statusCode int
+	requests   []string
+	bodies     map[string][]byte
+	mutex      sync.Mutex
+}
+
+func newFakeTbaServer(t *testing.T) *fakeTbaServer {
+	server := &fakeTbaServer{secretId: "secret_id", secret: "secret", bodies: make(map[string][]byte)}
+	server.Server = httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				server.mutex.Lock()
+				defer server.mutex.Unlock()
+				if server.down {
+					// Simulate a dropped connection.
+					hijacker, _ := w.(http.Hijacker)
+					conn, _, _ := hijacker.Hijack()
+					conn.Close()
+					return
+				}
+				if server.statusCode != 0 {
+					http.Error(w, "fake error", server.statusCode)
+					return
+				}
+
+				body, _ := io.ReadAll(r.Body)
+				signature := fmt.Sprintf("%x", md5.Sum(append([]byte(server.secret+r.URL.Path), body...)))
+				if r.Header.Get("X-TBA-Auth-Id") != server.secretId || r.Header.Get("X-TBA-Auth-Sig") != signature {
+					http.Error(w, "invalid signature", 401)
+					return
+				}
+				server.requests = append(server.requests, r.URL.Path)
+				server.bodies[r.URL.Path] = body
+			},
+		),
+	)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func (server *fakeTbaServer) setDown(down bool) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.down = down
+}
+
+func (server *fakeTbaServer) decode(t *testing.T, path string, v any) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	assert.Nil(t, json.Unmarshal(server.bodies[path], v))
+}
+
+func setupTestTbaClient(t *testing.T) (*TbaClient, *fakeTbaServer) {
+	server := newFakeTbaServer(t)
+	client := NewTbaClient()
+	client.BaseUrl = server.URL
+	client.SetSettings("2026test", "secret_id", "secret", "api_key")
+	return client, server
+}
+
+func TestPublishTeams(t *testing.T) {
+	database := model.SetupTestDb(t)
+	client, server := setupTestTbaClient(t)
+	database.CreateTeam(&model.Team{Id: 254})
+	database.CreateTeam(&model.Team{Id: 1114})
+
+	assert.Nil(t, client.PublishTeams(database))
+	client.sendQueuedRequests()
+	var teams []string
+	server.decode(t, "/api/trusted/v1/event/2026test/team_list/update", &teams)
+	assert.Equal(t, []string{"frc254", "frc1114"}, teams)
+}
+
+func TestPublishMatches(t *testing.T) {
+	database := model.SetupTestDb(t)
+	client, server := setupTestTbaClient(t)
+	match1 := model.Match{
+		Type:             model.Qualification,
+		TypeOrder:        1,
+		ShortName:        "Q1",
+		Time:             time.Unix(600, 0),
+		Red1:             7,
+		Red2:             8,
+		Red3:             9,
+		Blue1:            10,
+		Blue2:            11,
+		Blue3:            12,
+		Blue3IsSurrogate: true,
+		Status:           game.RedWonMatch,
+		TbaMatchKey:      model.TbaMatchKey{CompLevel: "qm", SetNumber: 0, MatchNumber: 1},
+	}
+	match2 := model.Match{
+		Type:        model.Playoff,
+		TypeOrder:   1,
+		ShortName:   "SF4-3",
+		Red1:        1,
+		Red2:        2,
+		Red3:        3,
+		Blue1:       4,
+		Blue2:       5,
+		Blue3:       6,
+		TbaMatchKey: model.TbaMatchKey{CompLevel: "sf", SetNumber: 4, MatchNumber: 3},
+	}
+	database.CreateMatch(&match1)
+	database.CreateMatch(&match2)
+	matchResult := model.BuildTestMatchResult(match1.Id, 1)
+	matchResult.BlueCards = map[string]string{"11": "red"}
+	database.CreateMatchResult(matchResult)
+
+	assert.Nil(t, client.PublishMatches(database))
+	client.sendQueuedRequests()
+	var matches []TbaMatch
+	server.decode(t, "/api/trusted/v1/event/2026test/matches/update", &matches)
+	if assert.Equal(t, 2, len(matches)) {
+		redSummary := matchResult.RedScore.Summarize(matchResult.BlueScore)
+		blueSummary := matchResult.BlueScore.Summarize(matchResult.RedScore)
+		assert.Equal(t, "qm", matches[0].CompLevel)
+		assert.Equal(t, 1, matches[0].MatchNumber)
+		assert.Equal(t, "Q1", matches[0].DisplayName)
+		assert.Equal(t, "1970-01-01T00:10:00", matches[0].TimeUtc)
+		assert.Equal(t, []string{"frc7", "frc8", "frc9"}, matches[0].Alliances["red"].Teams)
+		assert.Equal(t, []string{"frc12"}, matches[0].Alliances["blue"].Surrogates)
+		assert.Equal(t, []string{"frc11"}, matches[0].Alliances["blue"].Dqs)
+		assert.Equal(t, []string{}, matches[0].Alliances["red"].Dqs)
+		if assert.NotNil(t, matches[0].Alliances["red"].Score) {
+			assert.Equal(t, redSummary.Score, *matches[0].Alliances["red"].Score)
+			assert.Equal(t, blueSummary.Score, *matches[0].Alliances["blue"].Score)
+		}
+		assert.Equal(t, redSummary.Score, matches[0].ScoreBreakdown["red"].TotalPoints)
+		assert.Equal(t, redSummary.AutoPoints, matches[0].ScoreBreakdown["red"].AutoPoints)
+		assert.Equal(t, blueSummary.FoulPoints, matches[0].ScoreBreakdown["blue"].FoulPoints)
+		assert.Equal(
+			t, matchResult.RedScore.Mayhem.TeleopGamepiece2Count, matches[0].ScoreBreakdown["red"].TeleopGamepiece2Count,
+		)
+
+		// Unplayed matches should have no score.
+		assert.Equal(t, "sf", matches[1].CompLevel)
+		assert.Equal(t, 4, matches[1].SetNumber)
+		assert.Equal(t, 3, matches[1].MatchNumber)
+		assert.Nil(t, matches[1].Alliances["red"].Score)
+		assert.Nil(t, matches[1].ScoreBreakdown)
+	}
+
+	assert.Nil(t, client.DeletePublishedMatches())
+	client.sendQueuedRequests()
+	var eventCode string
+	server.decode(t, "/api/trusted/v1/event/2026test/matches/delete_all", &eventCode)
+	assert.Equal(t, "2026test", eventCode)
+}
+
+func TestPublishRankings(t *testing.T) {
+	database := model.SetupTestDb(t)
+	client, server := setupTestTbaClient(t)
+	database.CreateRanking(game.TestRanking2())
+	database.CreateRanking(game.TestRanking1())
+
+	assert.Nil(t, client.PublishRankings(database))
+	client.sendQueuedRequests()
+	var rankings TbaRankings
+	server.decode(t, "/api/trusted/v1/event/2026test/rankings/update", &rankings)
+	assert.Equal(t, []string{"RP", "Match", "Auto", "Gamepiece2", "wins", "losses", "ties"}, rankings.Breakdowns)
+	if assert.Equal(t, 2, len(rankings.Rankings)) {
+		ranking := game.TestRanking1()
+		assert.Equal(t, "frc254", rankings.Rankings[0].TeamKey)
+		assert.Equal(t, ranking.Rank, rankings.Rankings[0].Rank)
+		assert.Equal(t, ranking.RankingPoints, rankings.Rankings[0].RP)
+		assert.Equal(t, ranking.Played, rankings.Rankings[0].Played)
+		assert.Equal(t, "frc1114", rankings.Rankings[1].TeamKey)
+	}
+}
+
+func TestPublishAlliancesAndAwards(t *testing.T) {
+	database := model.SetupTestDb(t)
+	client, server := setupTestTbaClient(t)
+	model.BuildTestAlliances(database)
+	database.CreateAward(&model.Award{Type: model.JudgedAward, AwardName: "Safety Award", TeamId: 254})
+	database.CreateAward(&model.Award{Type: model.JudgedAward, AwardName: "Volunteer of the Year", PersonName: "Bob"})
+
+	assert.Nil(t, client.PublishAlliances(database))
+	client.sendQueuedRequests()
+	var alliances [][]string
+	server.decode(t, "/api/trusted/v1/event/2026test/alliance_selections/update", &alliances)
+	assert.Equal(
+		t,
+		[][]string{{"frc254", "frc469", "frc2848", "frc74", "frc3175"}, {"frc1718", "frc2451", "frc1619"}},
+		alliances,
+	)
+
+	assert.Nil(t, client.PublishAwards(database))
+	client.sendQueuedRequests()
+	var awards []TbaAward
+	server.decode(t, "/api/trusted/v1/event/2026test/awards/update", &awards)
+	if assert.Equal(t, 2, len(awards)) {
+		assert.Equal(t, "Safety Award", awards[0].Name)
+		assert.Equal(t, "frc254", *awards[0].TeamKey)
+		assert.Nil(t, awards[0].Awardee)
+		assert.Equal(t, "Volunteer of the Year", awards[1].Name)
+		assert.Nil(t, awards[1].TeamKey)
+		assert.Equal(t, "Bob", *awards[1].Awardee)
+	}
+}
+
+func TestTbaClientRetryQueue(t *testing.T) {
+	database := model.SetupTestDb(t)
+	client, server := setupTestTbaClient(t)
+	database.CreateTeam(&model.Team{Id: 254})
+
+	// Requests made while TBA is unreachable should stay queued, with newer requests superseding older ones.
+	server.setDown(true)
+	assert.Nil(t, client.PublishTeams(database))
+	assert.Nil(t, client.DeletePublishedMatches())
+	database.CreateTeam(&model.Team{Id: 1114})
+	assert.Nil(t, client.PublishTeams(database))
+	assert.Equal(t, 2, client.Status().QueuedRequests)
+	client.sendQueuedRequests()
+	status := client.Status()
+	assert.Equal(t, 2, status.QueuedRequests)
+	assert.NotEqual(t, "", status.LastError)
+	assert.True(t, status.LastPublished.IsZero())
+
+	// Retrying while still down should leave the queue intact.
+	client.sendQueuedRequests()
+	assert.Equal(t, 2, client.Status().QueuedRequests)
+	assert.Empty(t, server.requests)
+
+	server.setDown(false)
+	client.sendQueuedRequests()
+	status = client.Status()
+	assert.Equal(t, 0, status.QueuedRequests)
+	assert.Equal(t, "", status.LastError)
+	assert.False(t, status.LastPublished.IsZero())
+	assert.Equal(
+		t,
+		[]string{
+			"/api/trusted/v1/event/2026test/matches/delete_all",
+			"/api/trusted/v1/event/2026test/team_list/update",
+		},
+		server.requests,
+	)
+	var teams []string
+	server.decode(t, "/api/trusted/v1/event/2026test/team_list/update", &teams)
+	assert.Equal(t, []string{"frc254", "frc1114"}, teams)
+
+	// Server errors are retried but rejections of the request itself are not.
+	server.statusCode = 503
+	assert.Nil(t, client.PublishTeams(database))
+	client.sendQueuedRequests()
+	assert.Equal(t, 1, client.Status().QueuedRequests)
+	server.statusCode = 401
+	client.sendQueuedRequests()
+	status = client.Status()
+	assert.Equal(t, 0, status.QueuedRequests)
+	assert.Contains(t, status.LastError, "got status code 401 from TBA for team_list/update")
+
+	// Changing the event should discard anything queued for the old one.
+	server.statusCode = 503
+	assert.Nil(t, client.PublishTeams(database))
+	client.sendQueuedRequests()
+	assert.Equal(t, 1, client.Status().QueuedRequests)
+	client.SetSettings("2026other", "secret_id", "secret", "api_key")
+	assert.Equal(t, 0, client.Status().QueuedRequests)
+}
+
+func TestTbaClientWorker(t *testing.T) {
+	database := model.SetupTestDb(t)
+	client, server := setupTestTbaClient(t)
+	database.CreateTeam(&model.Team{Id: 254})
+	go client.Run()
+
+	// Publishing shouldn't wait on TBA, and the worker should deliver the requests in the order they were published.
+	assert.Nil(t, client.DeletePublishedMatches())
+	assert.Nil(t, client.PublishTeams(database))
+	assert.Nil(t, client.PublishRankings(database))
+	assert.Eventually(t, func() bool { return client.Status().QueuedRequests == 0 }, time.Second, time.Millisecond)
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	assert.Equal(
+		t,
+		[]string{
+			"/api/trusted/v1/event/2026test/matches/delete_all",
+			"/api/trusted/v1/event/2026test/team_list/update",
+			"/api/trusted/v1/event/2026test/rankings/update",
+		},
+		server.requests,
+	)
+}
+
+func TestTbaClientBadCredentials(t *testing.T) {
+	database := model.SetupTestDb(t)
+	client, _ := setupTestTbaClient(t)
+	client.SetSettings("2026test", "secret_id", "wrong_secret", "api_key")
+
+	assert.Nil(t, client.PublishTeams(database))
+	client.sendQueuedRequests()
+	status := client.Status()
+	assert.Equal(t, "got status code 401 from TBA for team_list/update: invalid signature\n", status.LastError)
+	assert.Equal(t, 0, status.QueuedRequests)
+}
+
+// Returns a stand-in for the TBA read API that serves the given canned responses by path.
+func newFakeTbaReadServer(t *testing.T, responses map[string]any) *httptest.Server {
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("X-TBA-Auth-Key") != "api_key" {
+					http.Error(w, "invalid key", 401)
+					return
+				}
+				response, ok := responses[r.URL.Path]
+				if !ok {
+					http.NotFound(w, r)
+					return
+				}
+				assert.Nil(t, json.NewEncoder(w).Encode(response))
+			},
+		),
+	)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGetTeamData(t *testing.T) {
+	server := newFakeTbaReadServer(
+		t,
+		map[string]any{
+			"/api/v3/team/frc254": TbaTeam{
+				Key: "frc254", TeamNumber: 254, Nickname: "The Cheesy Poofs", City: "San Jose", RookieYear: 1999,
+			},
+			"/api/v3/team/frc254/robots": []TbaRobot{{"Barrage", 2015}, {"Dreadnought", 2026}},
+			"/api/v3/team/frc254/awards": []TbaTeamAward{
+				{Name: "Winner", EventKey: "2025cc", Year: 2025},
+				{Name: "Innovation in Control Award", EventKey: "2025cc", Year: 2025},
+			},
+			"/api/v3/event/2025cc": tbaEvent{"Chezy Champs"},
+			"/api/v3/team/frc254/media/2026": []tbaMediaItem{
+				{Type: "cdphotothread", Details: map[string]any{}},
+				{Type: "avatar", Details: map[string]any{"base64Image": "iVBORw0KGgo="}},
+			},
+		},
+	)
+	client := NewTbaClient()
+	client.BaseUrl = server.URL
+	assert.False(t, client.CanDownload())
+	client.SetSettings("", "", "", "api_key")
+	assert.True(t, client.CanDownload())
+
+	team, err := client.GetTeam(254)
+	assert.Nil(t, err)
+	if assert.NotNil(t, team) {
+		assert.Equal(t, "The Cheesy Poofs", team.Nickname)
+		assert.Equal(t, "San Jose", team.City)
+		assert.Equal(t, 1999, team.RookieYear)
+	}
+	team, err = client.GetTeam(9999)
+	assert.Nil(t, err)
+	assert.Nil(t, team)
+
+	robotName, err := client.GetRobotName(254, 2026)
+	assert.Nil(t, err)
+	assert.Equal(t, "Dreadnought", robotName)
+	robotName, err = client.GetRobotName(254, 2024)
+	assert.Nil(t, err)
+	assert.Equal(t, "", robotName)
+
+	awards, err := client.GetTeamAwards(254)
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(awards)) {
+		assert.Equal(t, "Winner", awards[0].Name)
+		assert.Equal(t, "Chezy Champs", awards[0].EventName)
+		assert.Equal(t, "Chezy Champs", awards[1].EventName)
+	}
+
+	avatar, err := client.GetTeamAvatar(254, 2026)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n'}, avatar)
+	avatar, err = client.GetTeamAvatar(254, 2025)
+	assert.Nil(t, err)
+	assert.Nil(t, avatar)
+
+	client.SetSettings("", "", "", "wrong_key")
+	_, err = client.GetTeam(254)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "got status code 401 from TBA for /api/v3/team/frc254: invalid key\n", err.Error())
+	}
+}
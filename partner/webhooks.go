@@ -0,0 +1,173 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Client for delivering HMAC-signed event notifications to external webhook subscribers.
+
+package partner
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/google/uuid"
+)
+
+const (
+	webhookMaxAttempts      = 5
+	webhookInitialBackoffMs = 1000
+	webhookTimeoutSec       = 5
+	webhookMaxLogEntries    = 100
+	WebhookEventHeader      = "X-Cheesy-Arena-Event"
+	WebhookDeliveryHeader   = "X-Cheesy-Arena-Delivery"
+	WebhookSignatureHeader  = "X-Cheesy-Arena-Signature"
+)
+
+type WebhookClient struct {
+	httpClient        *http.Client
+	initialBackoff    time.Duration
+	deliveries        []*WebhookDelivery
+	deliveryWaitGroup sync.WaitGroup
+	mutex             sync.Mutex
+}
+
+// Record of an attempt to deliver an event to a single subscriber, for display in the delivery log.
+type WebhookDelivery struct {
+	Id          string
+	Event       string
+	Url         string
+	CreatedAt   time.Time
+	Attempts    int
+	StatusCode  int
+	LastError   string
+	Succeeded   bool
+	CompletedAt time.Time
+}
+
+// JSON body that is POSTed to subscribers.
+type webhookPayload struct {
+	Id        string    `json:"id"`
+	Event     string    `json:"event"`
+	Timestamp time.Time `json:"timestamp"`
+	Data      any       `json:"data"`
+}
+
+func NewWebhookClient() *WebhookClient {
+	return &WebhookClient{
+		httpClient:     &http.Client{Timeout: webhookTimeoutSec * time.Second},
+		initialBackoff: webhookInitialBackoffMs * time.Millisecond,
+	}
+}
+
+// Asynchronously delivers the given event and data to each of the given subscribers, retrying with exponential backoff
+// if a subscriber can't be reached or returns an error.
+func (client *WebhookClient) Deliver(subscriptions []model.WebhookSubscription, event string, data any) {
+	// Serialize the data once up front so that a failure affects all subscribers equally.
+	dataJson, err := json.Marshal(data)
+	if err != nil {
+		log.Printf("Failed to serialize %s webhook: %v", event, err)
+		return
+	}
+
+	for _, subscription := range subscriptions {
+		payload := webhookPayload{
+			Id: uuid.NewString(), Event: event, Timestamp: time.Now(), Data: json.RawMessage(dataJson),
+		}
+		body, err := json.Marshal(payload)
+		if err != nil {
+			log.Printf("Failed to serialize %s webhook: %v", event, err)
+			continue
+		}
+		delivery := &WebhookDelivery{Id: payload.Id, Event: event, Url: subscription.Url, CreatedAt: payload.Timestamp}
+		client.mutex.Lock()
+		client.deliveries = append(client.deliveries, delivery)
+		if len(client.deliveries) > webhookMaxLogEntries {
+			client.deliveries = client.deliveries[len(client.deliveries)-webhookMaxLogEntries:]
+		}
+		client.mutex.Unlock()
+
+		client.deliveryWaitGroup.Add(1)
+		go func(secret string) {
+			defer client.deliveryWaitGroup.Done()
+			client.deliverWithRetries(delivery, secret, body)
+		}(subscription.Secret)
+	}
+}
+
+// Returns a snapshot of the most recent deliveries, newest first.
+func (client *WebhookClient) Deliveries() []WebhookDelivery {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	deliveries := make([]WebhookDelivery, len(client.deliveries))
+	for i, delivery := range client.deliveries {
+		deliveries[len(deliveries)-1-i] = *delivery
+	}
+	return deliveries
+}
+
+// Returns the hex-encoded HMAC-SHA256 signature of the given body using the given secret, prefixed with the algorithm
+// name. Subscribers can verify the request by computing the same value and comparing it to the signature header.
+func WebhookSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (client *WebhookClient) deliverWithRetries(delivery *WebhookDelivery, secret string, body []byte) {
+	backoff := client.initialBackoff
+	for attempt := 1; attempt <= webhookMaxAttempts; attempt++ {
+		statusCode, err := client.post(delivery, secret, body)
+		client.mutex.Lock()
+		delivery.Attempts = attempt
+		delivery.StatusCode = statusCode
+		if err == nil {
+			delivery.LastError = ""
+			delivery.Succeeded = true
+			delivery.CompletedAt = time.Now()
+			client.mutex.Unlock()
+			return
+		}
+		delivery.LastError = err.Error()
+		if attempt == webhookMaxAttempts {
+			delivery.CompletedAt = time.Now()
+		}
+		client.mutex.Unlock()
+
+		if attempt < webhookMaxAttempts {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+	log.Printf("Giving up on %s webhook to %s: %s", delivery.Event, delivery.Url, delivery.LastError)
+}
+
+// Sends a single signed delivery attempt and returns the response status code.
+func (client *WebhookClient) post(delivery *WebhookDelivery, secret string, body []byte) (int, error) {
+	request, err := http.NewRequest("POST", delivery.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(WebhookEventHeader, delivery.Event)
+	request.Header.Set(WebhookDeliveryHeader, delivery.Id)
+	if secret != "" {
+		request.Header.Set(WebhookSignatureHeader, WebhookSignature(secret, body))
+	}
+	response, err := client.httpClient.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode/100 != 2 {
+		return response.StatusCode, fmt.Errorf("subscriber returned status %s", response.Status)
+	}
+	return response.StatusCode, nil
+}
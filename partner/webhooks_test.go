@@ -0,0 +1,132 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package partner
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookSignature(t *testing.T) {
+	assert.Equal(
+		t,
+		"sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8",
+		WebhookSignature("key", []byte("The quick brown fox jumps over the lazy dog")),
+	)
+}
+
+func TestWebhookClientDeliver(t *testing.T) {
+	var requests []*http.Request
+	var bodies [][]byte
+	var mutex sync.Mutex
+	failuresRemaining := 2
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				mutex.Lock()
+				defer mutex.Unlock()
+				body, _ := io.ReadAll(r.Body)
+				requests = append(requests, r)
+				bodies = append(bodies, body)
+				if failuresRemaining > 0 {
+					failuresRemaining--
+					w.WriteHeader(503)
+				}
+			},
+		),
+	)
+	defer server.Close()
+	client := NewWebhookClient()
+	client.initialBackoff = time.Millisecond
+
+	subscriptions := []model.WebhookSubscription{{Id: 1, Url: server.URL + "/hook", Secret: "shh"}}
+	client.Deliver(subscriptions, model.WebhookMatchLoaded, map[string]string{"ShortName": "Q12"})
+	client.deliveryWaitGroup.Wait()
+
+	if assert.Equal(t, 3, len(requests)) {
+		request := requests[2]
+		assert.Equal(t, "/hook", request.URL.Path)
+		assert.Equal(t, "application/json", request.Header.Get("Content-Type"))
+		assert.Equal(t, model.WebhookMatchLoaded, request.Header.Get(WebhookEventHeader))
+		assert.Equal(t, WebhookSignature("shh", bodies[2]), request.Header.Get(WebhookSignatureHeader))
+		var payload map[string]any
+		assert.Nil(t, json.Unmarshal(bodies[2], &payload))
+		assert.Equal(t, model.WebhookMatchLoaded, payload["event"])
+		assert.Equal(t, request.Header.Get(WebhookDeliveryHeader), payload["id"])
+		assert.Equal(t, map[string]any{"ShortName": "Q12"}, payload["data"])
+
+		// Retries should carry the identical signed body.
+		assert.Equal(t, bodies[0], bodies[2])
+	}
+	deliveries := client.Deliveries()
+	if assert.Equal(t, 1, len(deliveries)) {
+		assert.Equal(t, 3, deliveries[0].Attempts)
+		assert.Equal(t, 200, deliveries[0].StatusCode)
+		assert.True(t, deliveries[0].Succeeded)
+		assert.Equal(t, "", deliveries[0].LastError)
+	}
+
+	// A subscriber that never succeeds should be given up on after the maximum number of attempts.
+	failuresRemaining = 100
+	client.Deliver(subscriptions, model.WebhookAwardCreated, nil)
+	client.deliveryWaitGroup.Wait()
+	deliveries = client.Deliveries()
+	if assert.Equal(t, 2, len(deliveries)) {
+		assert.Equal(t, model.WebhookAwardCreated, deliveries[0].Event)
+		assert.Equal(t, webhookMaxAttempts, deliveries[0].Attempts)
+		assert.False(t, deliveries[0].Succeeded)
+		assert.Equal(t, "subscriber returned status 503 Service Unavailable", deliveries[0].LastError)
+		assert.False(t, deliveries[0].CompletedAt.IsZero())
+	}
+	assert.Equal(t, 3+webhookMaxAttempts, len(requests))
+}
+
+func TestWebhookClientDeliveryLogLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	client := NewWebhookClient()
+
+	subscriptions := []model.WebhookSubscription{{Url: server.URL}}
+	for i := 0; i < webhookMaxLogEntries+5; i++ {
+		client.Deliver(subscriptions, model.WebhookMatchStarted, i)
+	}
+	client.deliveryWaitGroup.Wait()
+	assert.Equal(t, webhookMaxLogEntries, len(client.Deliveries()))
+}
+
+func TestWebhookClientDeliverMultipleSubscribers(t *testing.T) {
+	var paths []string
+	var mutex sync.Mutex
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				mutex.Lock()
+				defer mutex.Unlock()
+				paths = append(paths, r.URL.Path)
+			},
+		),
+	)
+	defer server.Close()
+	client := NewWebhookClient()
+
+	subscriptions := []model.WebhookSubscription{{Url: server.URL + "/hook1"}, {Url: server.URL + "/hook2"}}
+	client.Deliver(subscriptions, model.WebhookMatchStarted, map[string]int{"MatchId": 12})
+	client.deliveryWaitGroup.Wait()
+	assert.ElementsMatch(t, []string{"/hook1", "/hook2"}, paths)
+	assert.Equal(t, 2, len(client.Deliveries()))
+
+	// Data that can't be serialized shouldn't be delivered to anyone.
+	client.Deliver(subscriptions, model.WebhookMatchStarted, make(chan int))
+	client.deliveryWaitGroup.Wait()
+	assert.Equal(t, 2, len(paths))
+	assert.Equal(t, 2, len(client.Deliveries()))
+}
@@ -41,6 +41,9 @@ const handleArenaStatus = function (data) {
     const teamBandwidthElement = $(teamElementPrefix + "Bandwidth");
     const teamTripTimeElement = $(teamElementPrefix + "TripTime");
     const teamMissedPacketsElement = $(teamElementPrefix + "MissedPackets");
+    const teamDiagnosticsDsElement = $(teamElementPrefix + "DiagnosticsDs");
+    const teamDiagnosticsRadioElement = $(teamElementPrefix + "DiagnosticsRadio");
+    const teamDiagnosticsRioElement = $(teamElementPrefix + "DiagnosticsRio");
 
     teamNotesTextElement.attr("data-station", station);
 
@@ -142,6 +145,12 @@ const handleArenaStatus = function (data) {
       }
     }
 
+    // Format the network reachability results for each of the team's devices.
+    const diagnostics = stationStatus.NetworkDiagnostics;
+    formatHostDiagnostics(teamDiagnosticsDsElement, "DS", diagnostics, diagnostics.DriverStation);
+    formatHostDiagnostics(teamDiagnosticsRadioElement, "RADIO", diagnostics, diagnostics.Radio);
+    formatHostDiagnostics(teamDiagnosticsRioElement, "RIO", diagnostics, diagnostics.RoboRio);
+
     if (stationStatus.EStop) {
       teamBypassElement.attr("data-status-ok", false);
       teamBypassElement.text("E-STP");
@@ -158,6 +167,32 @@ const handleArenaStatus = function (data) {
   });
 };
 
+// Formats the given element to reflect whether the given device was reachable during the last diagnostics check.
+const formatHostDiagnostics = function (element, label, diagnostics, host) {
+  if (diagnostics.TeamId === 0) {
+    // Diagnostics are disabled or there is no team in this station.
+    element.attr("data-status-ok", "");
+    element.text(label);
+    element.attr("title", "");
+    return;
+  }
+
+  element.attr("data-status-ok", host.Reachable);
+  if (host.IpAddress) {
+    element.text(label + " ." + host.IpAddress.split(".")[3]);
+  } else {
+    element.text(label + " ?");
+  }
+  let title = host.IpAddress ? host.IpAddress : "No address found";
+  if (host.MacAddress) {
+    title += `\n${host.MacAddress} (${host.LeaseSource})`;
+  }
+  if (diagnostics.NeighborError) {
+    title += `\nSwitch lookup failed: ${diagnostics.NeighborError}`;
+  }
+  element.attr("title", title);
+};
+
 // Handles a websocket message to update the match time countdown.
 const handleMatchTime = function (data) {
   translateMatchTime(data, function (matchState, matchStateText, countdownSec) {
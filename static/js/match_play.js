@@ -276,6 +276,38 @@ const handleArenaStatus = function (data) {
   $.each(data.PlcArmorBlockStatuses, function (name, status) {
     $("#plc" + name + "Status").attr("data-ready", status);
   });
+
+  const recordingStatuses = $("#recordingStatuses");
+  recordingStatuses.empty();
+  $.each(data.RecordingStatuses, function (i, status) {
+    let text = status.Address;
+    if (status.Connected) {
+      text += ` - ${status.TransportStatus}`;
+      if (status.RecordingTimeKnown) {
+        text += `, ${Math.floor(status.RecordingTimeSec / 60)} min left`;
+      }
+    }
+    if (status.Warning !== "") {
+      text += ` (${status.Warning})`;
+    }
+    const badge = $("<span class='badge badge-scoring'></span>").text(text);
+    badge.attr("data-ready", status.Warning === "");
+    recordingStatuses.append(badge, $("<br/>"));
+  });
+
+  let obsText = data.ObsStatus.Connected ? "Connected" : "Not Connected";
+  if (data.ObsStatus.LastError !== "") {
+    obsText += ` (${data.ObsStatus.LastError})`;
+  }
+  $("#obsStatus").text(obsText);
+  $("#obsStatus").attr("data-ready", data.ObsStatus.Connected);
+
+  let mqttText = data.MqttStatus.Connected ? "Connected" : "Not Connected";
+  if (data.MqttStatus.LastError !== "") {
+    mqttText += ` (${data.MqttStatus.LastError})`;
+  }
+  $("#mqttStatus").text(mqttText);
+  $("#mqttStatus").attr("data-ready", data.MqttStatus.Connected);
 };
 
 // Handles a websocket message to update the teams for the current match.
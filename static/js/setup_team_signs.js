@@ -0,0 +1,30 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Client-side logic for the Team Signs preview page.
+
+var websocket;
+
+// Handles a websocket message to update the simulated content of each sign.
+const handleTeamSigns = function (data) {
+  const teamSigns = $("#teamSigns");
+  teamSigns.empty();
+  $.each(data, function (index, sign) {
+    const signElement = $("#teamSignTemplate .team-sign").clone();
+    let name = sign.Name;
+    name += sign.Id > 0 ? ` (ID ${sign.Id})` : " (not configured)";
+    signElement.find(".team-sign-name").text(name);
+    signElement.find(".team-sign-front").text(sign.FrontText).css("color", sign.FrontColor);
+    signElement.find(".team-sign-rear").text(sign.RearText);
+    teamSigns.append(signElement);
+  });
+};
+
+$(function () {
+  // Set up the websocket back to the server.
+  websocket = new CheesyWebsocket("/setup/team_signs/websocket", {
+    teamSigns: function (event) {
+      handleTeamSigns(event.data);
+    },
+  });
+});
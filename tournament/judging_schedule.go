@@ -169,12 +169,12 @@ func createTeamMatchMap(teams []model.Team, matches []model.Match) map[int][]mod
 	}
 
 	for _, match := range matches {
-		teamMatches[match.Red1] = append(teamMatches[match.Red1], match)
-		teamMatches[match.Red2] = append(teamMatches[match.Red2], match)
-		teamMatches[match.Red3] = append(teamMatches[match.Red3], match)
-		teamMatches[match.Blue1] = append(teamMatches[match.Blue1], match)
-		teamMatches[match.Blue2] = append(teamMatches[match.Blue2], match)
-		teamMatches[match.Blue3] = append(teamMatches[match.Blue3], match)
+		for _, teamId := range [6]int{match.Red1, match.Red2, match.Red3, match.Blue1, match.Blue2, match.Blue3} {
+			// Skip the empty third stations of two-team alliances.
+			if teamId != 0 {
+				teamMatches[teamId] = append(teamMatches[teamId], match)
+			}
+		}
 	}
 
 	return teamMatches
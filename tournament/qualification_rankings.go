@@ -135,6 +135,10 @@ func CalculateTeamCards(database *model.Database, matchType model.MatchType) err
 func addMatchResultToRankings(
 	rankings map[int]*game.Ranking, teamId int, matchResult *model.MatchResult, isRed bool,
 ) {
+	if teamId == 0 {
+		// This is the empty third station of a two-team alliance.
+		return
+	}
 	ranking := rankings[teamId]
 	if ranking == nil {
 		ranking = &game.Ranking{TeamId: teamId}
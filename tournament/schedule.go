@@ -18,27 +18,78 @@ import (
 )
 
 const (
-	schedulesDir  = "schedules"
-	TeamsPerMatch = 6
+	schedulesDir        = "schedules"
+	TeamsPerMatch       = 6
+	stationsPerAlliance = TeamsPerMatch / 2
 )
 
-// Creates a random schedule for the given parameters and returns it as a list of matches.
+// Creates a random schedule for the given parameters and returns it as a list of matches. The pre-generated schedule
+// template for the number of teams and matches is used if one exists; otherwise the schedule is generated natively.
+// Alliances may have either two or three teams; the third station of each alliance is left empty for the former. If
+// any teams have limited availability, the schedule is always generated natively so that it can take that into account.
 func BuildRandomSchedule(
-	teams []model.Team, scheduleBlocks []model.ScheduleBlock, matchType model.MatchType,
+	teams []model.Team, teamsPerAlliance int, scheduleBlocks []model.ScheduleBlock, matchType model.MatchType,
 ) ([]model.Match, error) {
-	// Load the anonymized, pre-randomized match schedule for the given number of teams and matches per team.
+	// Generate a random permutation of the team ordering to fill into the pre-randomized schedule.
 	numTeams := len(teams)
-	numMatches := countMatches(scheduleBlocks)
-	matchesPerTeam := int(float32(numMatches*TeamsPerMatch) / float32(numTeams))
+	matchesPerTeam := countMatchesPerTeam(numTeams, teamsPerAlliance, scheduleBlocks)
+	teamShuffle := rand.Perm(numTeams)
+	unavailable := buildTeamUnavailability(teams, teamShuffle, teamsPerAlliance, matchesPerTeam, scheduleBlocks)
+
+	// Load the anonymized, pre-randomized match schedule for the given number of teams and matches per team. The
+	// pre-generated templates only exist for three-team alliances.
+	var anonSchedule [][12]int
+	err := os.ErrNotExist
+	if teamsPerAlliance == stationsPerAlliance && unavailable == nil {
+		anonSchedule, err = loadScheduleTemplate(numTeams, matchesPerTeam)
+	}
+	if os.IsNotExist(err) {
+		anonSchedule, err = GenerateAnonSchedule(
+			numTeams,
+			teamsPerAlliance,
+			matchesPerTeam,
+			ScheduleGeneratorOptions{Seed: rand.Int63(), AllowIncomplete: true, Unavailable: unavailable},
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return buildScheduleFromTemplate(teams, anonSchedule, scheduleBlocks, matchType, teamShuffle)
+}
 
+// Creates a schedule for the given parameters using the native schedule generator, even if a pre-generated template
+// exists, and returns it as a list of matches. The same seed always produces the same schedule, so an error is returned
+// if the generator runs out of time rather than a schedule that couldn't be reproduced.
+func BuildGeneratedSchedule(
+	teams []model.Team,
+	teamsPerAlliance int,
+	scheduleBlocks []model.ScheduleBlock,
+	matchType model.MatchType,
+	options ScheduleGeneratorOptions,
+) ([]model.Match, error) {
+	numTeams := len(teams)
+	matchesPerTeam := countMatchesPerTeam(numTeams, teamsPerAlliance, scheduleBlocks)
+	teamShuffle := rand.New(rand.NewSource(options.Seed)).Perm(numTeams)
+	options.Unavailable = buildTeamUnavailability(teams, teamShuffle, teamsPerAlliance, matchesPerTeam, scheduleBlocks)
+	anonSchedule, err := GenerateAnonSchedule(numTeams, teamsPerAlliance, matchesPerTeam, options)
+	if err != nil {
+		return nil, err
+	}
+	return buildScheduleFromTemplate(teams, anonSchedule, scheduleBlocks, matchType, teamShuffle)
+}
+
+// Loads the pre-generated anonymized schedule template for the given number of teams and matches per team. Returns an
+// error satisfying os.IsNotExist if there is no such template.
+func loadScheduleTemplate(numTeams, matchesPerTeam int) ([][12]int, error) {
 	// Adjust the number of matches to remove any excess from non-perfect block scheduling.
-	numMatches = int(math.Ceil(float64(numTeams) * float64(matchesPerTeam) / TeamsPerMatch))
+	numMatches := int(math.Ceil(float64(numTeams) * float64(matchesPerTeam) / TeamsPerMatch))
 
 	file, err := os.Open(
 		fmt.Sprintf("%s/%d_%d.csv", filepath.Join(model.BaseDir, schedulesDir), numTeams, matchesPerTeam),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("No schedule template exists for %d teams and %d matches", numTeams, matchesPerTeam)
+		return nil, err
 	}
 	defer file.Close()
 	reader := csv.NewReader(file)
@@ -60,49 +111,118 @@ func BuildRandomSchedule(
 			}
 		}
 	}
+	return anonSchedule, nil
+}
 
-	// Generate a random permutation of the team ordering to fill into the pre-randomized schedule.
-	teamShuffle := rand.Perm(numTeams)
+// Fills the given teams into the anonymized schedule, in the order given by the shuffle, and returns it as a list of
+// matches. A team index of zero in the anonymized schedule denotes an empty station.
+func buildScheduleFromTemplate(
+	teams []model.Team,
+	anonSchedule [][12]int,
+	scheduleBlocks []model.ScheduleBlock,
+	matchType model.MatchType,
+	teamShuffle []int,
+) ([]model.Match, error) {
+	teamId := func(anonTeam int) int {
+		if anonTeam == 0 {
+			return 0
+		}
+		return teams[teamShuffle[anonTeam-1]].Id
+	}
+
+	numMatches := len(anonSchedule)
 	matches := make([]model.Match, numMatches)
 	for i, anonMatch := range anonSchedule {
-		matches[i].Type = matchType
-		matches[i].TypeOrder = i + 1
-		if matchType == model.Practice {
-			matches[i].ShortName = fmt.Sprintf("P%d", i+1)
-			matches[i].LongName = fmt.Sprintf("Practice %d", i+1)
-			matches[i].TbaMatchKey.CompLevel = "p"
-		} else if matchType == model.Qualification {
-			matches[i].ShortName = fmt.Sprintf("Q%d", i+1)
-			matches[i].LongName = fmt.Sprintf("Qualification %d", i+1)
-			matches[i].TbaMatchKey.CompLevel = "qm"
-		} else {
-			return nil, fmt.Errorf("invalid match type %q", matchType)
+		if err := setMatchNames(&matches[i], i+1, matchType); err != nil {
+			return nil, err
 		}
-		matches[i].Red1 = teams[teamShuffle[anonMatch[0]-1]].Id
+		matches[i].Red1 = teamId(anonMatch[0])
 		matches[i].Red1IsSurrogate = anonMatch[1] == 1
-		matches[i].Red2 = teams[teamShuffle[anonMatch[2]-1]].Id
+		matches[i].Red2 = teamId(anonMatch[2])
 		matches[i].Red2IsSurrogate = anonMatch[3] == 1
-		matches[i].Red3 = teams[teamShuffle[anonMatch[4]-1]].Id
+		matches[i].Red3 = teamId(anonMatch[4])
 		matches[i].Red3IsSurrogate = anonMatch[5] == 1
-		matches[i].Blue1 = teams[teamShuffle[anonMatch[6]-1]].Id
+		matches[i].Blue1 = teamId(anonMatch[6])
 		matches[i].Blue1IsSurrogate = anonMatch[7] == 1
-		matches[i].Blue2 = teams[teamShuffle[anonMatch[8]-1]].Id
+		matches[i].Blue2 = teamId(anonMatch[8])
 		matches[i].Blue2IsSurrogate = anonMatch[9] == 1
-		matches[i].Blue3 = teams[teamShuffle[anonMatch[10]-1]].Id
+		matches[i].Blue3 = teamId(anonMatch[10])
 		matches[i].Blue3IsSurrogate = anonMatch[11] == 1
-		matches[i].TbaMatchKey.MatchNumber = i + 1
 	}
 
 	// Fill in the match times.
+	assignMatchTimes(matches, scheduleBlocks)
+
+	return matches, nil
+}
+
+// Sets the type, order and names of the given match based on its position within the schedule.
+func setMatchNames(match *model.Match, typeOrder int, matchType model.MatchType) error {
+	match.Type = matchType
+	match.TypeOrder = typeOrder
+	if matchType == model.Practice {
+		match.ShortName = fmt.Sprintf("P%d", typeOrder)
+		match.LongName = fmt.Sprintf("Practice %d", typeOrder)
+		match.TbaMatchKey.CompLevel = "p"
+	} else if matchType == model.Qualification {
+		match.ShortName = fmt.Sprintf("Q%d", typeOrder)
+		match.LongName = fmt.Sprintf("Qualification %d", typeOrder)
+		match.TbaMatchKey.CompLevel = "qm"
+	} else {
+		return fmt.Errorf("invalid match type %q", matchType)
+	}
+	match.TbaMatchKey.MatchNumber = typeOrder
+	return nil
+}
+
+// Fills in the times of the given matches, in order, from the slots available within the given schedule blocks.
+// Returns the number of matches that were assigned a time.
+func assignMatchTimes(matches []model.Match, scheduleBlocks []model.ScheduleBlock) int {
 	matchIndex := 0
 	for _, block := range scheduleBlocks {
-		for i := 0; i < block.NumMatches && matchIndex < numMatches; i++ {
+		for i := 0; i < block.NumMatches && matchIndex < len(matches); i++ {
 			matches[matchIndex].Time = block.StartTime.Add(time.Duration(i*block.MatchSpacingSec) * time.Second)
 			matchIndex++
 		}
 	}
+	return matchIndex
+}
 
-	return matches, nil
+// Works out which matches each team would be unable to play in, given the times that the schedule blocks assign to the
+// matches and the teams' availability windows. Returns a matrix indexed by anonymized team, in the order given by the
+// shuffle, and then by match, or nil if no team has limited availability.
+func buildTeamUnavailability(
+	teams []model.Team,
+	teamShuffle []int,
+	teamsPerAlliance int,
+	matchesPerTeam int,
+	scheduleBlocks []model.ScheduleBlock,
+) [][]bool {
+	hasLimitedAvailability := false
+	for _, team := range teams {
+		if !team.AvailableFrom.IsZero() || !team.AvailableUntil.IsZero() {
+			hasLimitedAvailability = true
+		}
+	}
+	if !hasLimitedAvailability {
+		return nil
+	}
+
+	matches := make([]model.Match, int(math.Ceil(float64(len(teams)*matchesPerTeam)/float64(2*teamsPerAlliance))))
+	numTimedMatches := assignMatchTimes(matches, scheduleBlocks)
+	unavailable := make([][]bool, len(teams))
+	for i, teamIndex := range teamShuffle {
+		unavailable[i] = make([]bool, numTimedMatches)
+		for j, match := range matches[:numTimedMatches] {
+			unavailable[i][j] = !teams[teamIndex].IsAvailableAt(match.Time)
+		}
+	}
+	return unavailable
+}
+
+// Returns the number of matches each team can play on alliances of the given size within the given schedule blocks.
+func countMatchesPerTeam(numTeams, teamsPerAlliance int, scheduleBlocks []model.ScheduleBlock) int {
+	return int(float32(countMatches(scheduleBlocks)*2*teamsPerAlliance) / float32(numTeams))
 }
 
 // Returns the total number of matches that can be run within the given schedule blocks.
@@ -113,3 +233,11 @@ func countMatches(scheduleBlocks []model.ScheduleBlock) int {
 	}
 	return numMatches
 }
+
+// Returns an error if the given number of teams per alliance isn't one that the schedule supports.
+func validateTeamsPerAlliance(teamsPerAlliance int) error {
+	if teamsPerAlliance < 2 || teamsPerAlliance > stationsPerAlliance {
+		return fmt.Errorf("Alliances must have 2 or %d teams (requested %d)", stationsPerAlliance, teamsPerAlliance)
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Functions for analyzing the fairness of a practice or qualification match schedule.
+
+package tournament
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+)
+
+// Summary of how fair a match schedule is, both overall and for each team.
+type ScheduleAnalysis struct {
+	NumMatches             int
+	NumTeams               int
+	NumSurrogates          int
+	MinMatchesBetween      int
+	RepeatPartners         int
+	RepeatOpponents        int
+	HasStrengthOfSchedule  bool
+	Teams                  []ScheduleTeamAnalysis
+	AvailabilityViolations []ScheduleAvailabilityViolation
+}
+
+// Statistics about a single team's matches within a schedule. Repeat partners and opponents count each pairing with
+// the same team beyond the first, and the gaps count the number of other matches between consecutive appearances. The
+// strength of schedule is the average rank of the team's partners minus that of its opponents, so that a positive
+// value means that its opponents were ranked better than its partners; it is only valid once rankings exist.
+type ScheduleTeamAnalysis struct {
+	TeamId                 int
+	NumMatches             int
+	NumSurrogateMatches    int
+	RepeatPartners         int
+	RepeatOpponents        int
+	MinMatchesBetween      int
+	AverageMatchesBetween  float64
+	RedCount               int
+	BlueCount              int
+	StationCounts          [3]int
+	AveragePartnerRank     float64
+	AverageOpponentRank    float64
+	StrengthOfSchedule     float64
+	AvailabilityViolations int
+}
+
+// A match that a team is scheduled to play in at a time outside of its availability window.
+type ScheduleAvailabilityViolation struct {
+	TeamId    int
+	MatchName string
+	Time      time.Time
+}
+
+// Computes fairness statistics for the given schedule. The teams are used to check that each one is only scheduled
+// within its availability window. The rankings are used to calculate each team's strength of schedule and may be empty
+// if they don't exist yet.
+func AnalyzeSchedule(matches []model.Match, teams []model.Team, rankings game.Rankings) ScheduleAnalysis {
+	analysis := ScheduleAnalysis{NumMatches: len(matches), MinMatchesBetween: -1}
+	ranks := make(map[int]int)
+	for _, ranking := range rankings {
+		ranks[ranking.TeamId] = ranking.Rank
+	}
+	teamsById := make(map[int]model.Team)
+	for _, team := range teams {
+		teamsById[team.Id] = team
+	}
+
+	teamAnalyses := make(map[int]*ScheduleTeamAnalysis)
+	lastMatchIndices := make(map[int]int)
+	partnerCounts := make(map[[2]int]int)
+	opponentCounts := make(map[[2]int]int)
+	partnerRanks := make(map[int][]int)
+	opponentRanks := make(map[int][]int)
+	for matchIndex, match := range matches {
+		alliances := [2][3]int{{match.Red1, match.Red2, match.Red3}, {match.Blue1, match.Blue2, match.Blue3}}
+		surrogates := [2][3]bool{
+			{match.Red1IsSurrogate, match.Red2IsSurrogate, match.Red3IsSurrogate},
+			{match.Blue1IsSurrogate, match.Blue2IsSurrogate, match.Blue3IsSurrogate},
+		}
+		for allianceIndex, alliance := range alliances {
+			for station, teamId := range alliance {
+				if teamId == 0 {
+					continue
+				}
+				teamAnalysis, ok := teamAnalyses[teamId]
+				if !ok {
+					teamAnalysis = &ScheduleTeamAnalysis{TeamId: teamId, MinMatchesBetween: -1}
+					teamAnalyses[teamId] = teamAnalysis
+				}
+				teamAnalysis.NumMatches++
+				if surrogates[allianceIndex][station] {
+					teamAnalysis.NumSurrogateMatches++
+					analysis.NumSurrogates++
+				}
+				if allianceIndex == 0 {
+					teamAnalysis.RedCount++
+				} else {
+					teamAnalysis.BlueCount++
+				}
+				teamAnalysis.StationCounts[station]++
+				if team, ok := teamsById[teamId]; ok && !team.IsAvailableAt(match.Time) {
+					teamAnalysis.AvailabilityViolations++
+					analysis.AvailabilityViolations = append(
+						analysis.AvailabilityViolations,
+						ScheduleAvailabilityViolation{TeamId: teamId, MatchName: match.ShortName, Time: match.Time},
+					)
+				}
+
+				if lastMatchIndex, ok := lastMatchIndices[teamId]; ok {
+					matchesBetween := matchIndex - lastMatchIndex - 1
+					if teamAnalysis.MinMatchesBetween == -1 || matchesBetween < teamAnalysis.MinMatchesBetween {
+						teamAnalysis.MinMatchesBetween = matchesBetween
+					}
+					teamAnalysis.AverageMatchesBetween += float64(matchesBetween)
+				}
+				lastMatchIndices[teamId] = matchIndex
+
+				for _, partnerId := range alliance {
+					if partnerId != 0 && partnerId != teamId {
+						partnerCounts[[2]int{teamId, partnerId}]++
+						if rank, ok := ranks[partnerId]; ok {
+							partnerRanks[teamId] = append(partnerRanks[teamId], rank)
+						}
+					}
+				}
+				for _, opponentId := range alliances[1-allianceIndex] {
+					if opponentId != 0 {
+						opponentCounts[[2]int{teamId, opponentId}]++
+						if rank, ok := ranks[opponentId]; ok {
+							opponentRanks[teamId] = append(opponentRanks[teamId], rank)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	for pair, count := range partnerCounts {
+		teamAnalyses[pair[0]].RepeatPartners += count - 1
+		if pair[0] < pair[1] {
+			analysis.RepeatPartners += count - 1
+		}
+	}
+	for pair, count := range opponentCounts {
+		teamAnalyses[pair[0]].RepeatOpponents += count - 1
+		if pair[0] < pair[1] {
+			analysis.RepeatOpponents += count - 1
+		}
+	}
+
+	analysis.HasStrengthOfSchedule = len(ranks) > 0
+	for teamId, teamAnalysis := range teamAnalyses {
+		if teamAnalysis.NumMatches > 1 {
+			teamAnalysis.AverageMatchesBetween /= float64(teamAnalysis.NumMatches - 1)
+			if analysis.MinMatchesBetween == -1 || teamAnalysis.MinMatchesBetween < analysis.MinMatchesBetween {
+				analysis.MinMatchesBetween = teamAnalysis.MinMatchesBetween
+			}
+		}
+		if len(partnerRanks[teamId]) > 0 && len(opponentRanks[teamId]) > 0 {
+			teamAnalysis.AveragePartnerRank = averageRank(partnerRanks[teamId])
+			teamAnalysis.AverageOpponentRank = averageRank(opponentRanks[teamId])
+			teamAnalysis.StrengthOfSchedule = teamAnalysis.AveragePartnerRank - teamAnalysis.AverageOpponentRank
+		}
+		analysis.Teams = append(analysis.Teams, *teamAnalysis)
+	}
+	sort.Slice(analysis.Teams, func(i, j int) bool {
+		return analysis.Teams[i].TeamId < analysis.Teams[j].TeamId
+	})
+	analysis.NumTeams = len(analysis.Teams)
+
+	return analysis
+}
+
+func averageRank(ranks []int) float64 {
+	total := 0
+	for _, rank := range ranks {
+		total += rank
+	}
+	return float64(total) / float64(len(ranks))
+}
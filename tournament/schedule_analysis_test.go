@@ -0,0 +1,109 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package tournament
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzeSchedule(t *testing.T) {
+	matches := []model.Match{
+		{Red1: 1, Red2: 2, Red3: 3, Blue1: 4, Blue2: 5, Blue3: 6},
+		{Red1: 7, Red2: 8, Red3: 9, Blue1: 10, Blue2: 11, Blue3: 12},
+		{Red1: 4, Red2: 1, Red3: 7, Blue1: 2, Blue2: 10, Blue3: 3, Blue3IsSurrogate: true},
+		{Red1: 5, Red2: 11, Red3: 8, Blue1: 12, Blue2: 6, Blue3: 9},
+		{Red1: 2, Red2: 3, Red3: 12, Blue1: 1, Blue2: 11, Blue3: 4},
+	}
+	analysis := AnalyzeSchedule(matches, nil, nil)
+	assert.Equal(t, 5, analysis.NumMatches)
+	assert.Equal(t, 12, analysis.NumTeams)
+	assert.Equal(t, 1, analysis.NumSurrogates)
+	assert.Equal(t, 0, analysis.MinMatchesBetween)
+	assert.Equal(t, 3, analysis.RepeatPartners)
+	assert.Equal(t, 10, analysis.RepeatOpponents)
+	assert.False(t, analysis.HasStrengthOfSchedule)
+	assert.Empty(t, analysis.AvailabilityViolations)
+	if assert.Equal(t, 12, len(analysis.Teams)) {
+		assert.Equal(
+			t,
+			ScheduleTeamAnalysis{
+				TeamId:                1,
+				NumMatches:            3,
+				RepeatPartners:        1,
+				RepeatOpponents:       2,
+				MinMatchesBetween:     1,
+				AverageMatchesBetween: 1,
+				RedCount:              2,
+				BlueCount:             1,
+				StationCounts:         [3]int{2, 1, 0},
+			},
+			analysis.Teams[0],
+		)
+		assert.Equal(
+			t,
+			ScheduleTeamAnalysis{
+				TeamId:                3,
+				NumMatches:            3,
+				NumSurrogateMatches:   1,
+				RepeatPartners:        2,
+				RepeatOpponents:       3,
+				MinMatchesBetween:     1,
+				AverageMatchesBetween: 1,
+				RedCount:              2,
+				BlueCount:             1,
+				StationCounts:         [3]int{0, 1, 2},
+			},
+			analysis.Teams[2],
+		)
+		assert.Equal(t, 0, analysis.Teams[11].MinMatchesBetween)
+		assert.Equal(t, 0.5, analysis.Teams[11].AverageMatchesBetween)
+	}
+
+	// Check the strength of schedule once rankings exist.
+	rankings := game.Rankings{}
+	for i := 1; i <= 12; i++ {
+		rankings = append(rankings, game.Ranking{TeamId: i, Rank: i})
+	}
+	analysis = AnalyzeSchedule(matches[0:1], nil, rankings)
+	assert.True(t, analysis.HasStrengthOfSchedule)
+	assert.Equal(t, -1, analysis.MinMatchesBetween)
+	assert.Equal(t, -1, analysis.Teams[0].MinMatchesBetween)
+	assert.Equal(t, 2.5, analysis.Teams[0].AveragePartnerRank)
+	assert.Equal(t, 5.0, analysis.Teams[0].AverageOpponentRank)
+	assert.Equal(t, -2.5, analysis.Teams[0].StrengthOfSchedule)
+	assert.Equal(t, 2.5, analysis.Teams[5].StrengthOfSchedule)
+}
+
+func TestAnalyzeScheduleAvailability(t *testing.T) {
+	matches := []model.Match{
+		{ShortName: "Q1", Time: time.Unix(1000, 0), Red1: 1, Red2: 2, Red3: 3, Blue1: 4, Blue2: 5, Blue3: 6},
+		{ShortName: "Q2", Time: time.Unix(2000, 0), Red1: 4, Red2: 5, Red3: 6, Blue1: 1, Blue2: 2, Blue3: 3},
+		{ShortName: "Q3", Time: time.Unix(3000, 0), Red1: 2, Red2: 4, Red3: 6, Blue1: 1, Blue2: 3, Blue3: 5},
+	}
+	teams := []model.Team{
+		{Id: 1, AvailableFrom: time.Unix(1500, 0)},
+		{Id: 2, AvailableUntil: time.Unix(2500, 0)},
+		{Id: 3, AvailableFrom: time.Unix(1000, 0), AvailableUntil: time.Unix(3000, 0)},
+		{Id: 4},
+		{Id: 5},
+		{Id: 6},
+	}
+	analysis := AnalyzeSchedule(matches, teams, nil)
+	assert.Equal(
+		t,
+		[]ScheduleAvailabilityViolation{
+			{TeamId: 1, MatchName: "Q1", Time: time.Unix(1000, 0)},
+			{TeamId: 2, MatchName: "Q3", Time: time.Unix(3000, 0)},
+		},
+		analysis.AvailabilityViolations,
+	)
+	assert.Equal(t, 1, analysis.Teams[0].AvailabilityViolations)
+	assert.Equal(t, 1, analysis.Teams[1].AvailabilityViolations)
+	assert.Equal(t, 0, analysis.Teams[2].AvailabilityViolations)
+}
@@ -0,0 +1,319 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Native generator for anonymized match schedules, used when no pre-generated schedule template exists.
+
+package tournament
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+const (
+	DefaultScheduleGeneratorTimeBudget = 10 * time.Second
+	scheduleGeneratorIterationsPerSlot = 500
+	scheduleGeneratorStartTemperature  = 50.0
+	scheduleGeneratorEndTemperature    = 0.05
+	scheduleGeneratorDeadlineInterval  = 1024
+
+	// Penalty weights for the different aspects of schedule quality, in decreasing order of importance.
+	duplicateTeamPenalty  = 1000000.0
+	unavailablePenalty    = 100000.0
+	matchGapPenalty       = 200.0
+	partnerRepeatPenalty  = 10.0
+	redBlueBalancePenalty = 8.0
+	opponentRepeatPenalty = 3.0
+	stationBalancePenalty = 1.0
+)
+
+// Parameters controlling the schedule generator. The same seed always produces the same schedule; if the time budget
+// runs out before all of the iterations have been completed, an error is returned instead, since the result would
+// depend on the speed of the machine. Setting AllowIncomplete accepts the partially optimized schedule in that case,
+// for callers that don't need it to be reproducible. Unavailable optionally marks, for each anonymized team and match
+// index, the matches that the team should be kept out of because it isn't at the event at that time.
+type ScheduleGeneratorOptions struct {
+	Seed            int64
+	TimeBudget      time.Duration
+	Iterations      int
+	AllowIncomplete bool
+	Unavailable     [][]bool
+}
+
+// Holds the working state of the simulated annealing schedule optimization.
+type scheduleGenerator struct {
+	numTeams         int
+	numMatches       int
+	teamsPerAlliance int
+	teamsPerMatch    int
+	minMatchGap      int
+	unavailable      [][]bool
+	slots            []int
+	teamSlots        [][]int
+	partnerCounts    []int
+	opponentCounts   []int
+}
+
+// Generates a schedule in which each of the given number of teams plays the given number of matches on alliances of
+// the given size, in the same anonymized format as the pre-generated schedule templates: each match is a list of
+// 1-based team indices, each followed by a flag indicating whether that team is a surrogate. With two-team alliances
+// the third station of each alliance is left as zero. The schedule is optimized using simulated annealing, in the
+// style of MatchMaker, to maximize the time between each team's matches and the variety of its partners and
+// opponents, and to balance its red/blue alliance and station assignments. Teams that need to play an extra match to
+// fill out the last one do so as surrogates in their third match.
+func GenerateAnonSchedule(
+	numTeams, teamsPerAlliance, matchesPerTeam int, options ScheduleGeneratorOptions,
+) ([][12]int, error) {
+	if err := validateTeamsPerAlliance(teamsPerAlliance); err != nil {
+		return nil, err
+	}
+	teamsPerMatch := 2 * teamsPerAlliance
+	if numTeams < teamsPerMatch {
+		return nil, fmt.Errorf("At least %d teams are needed to generate a schedule", teamsPerMatch)
+	}
+	if matchesPerTeam < 1 {
+		return nil, fmt.Errorf("Not enough matches to give each of the %d teams at least one", numTeams)
+	}
+	if options.TimeBudget <= 0 {
+		options.TimeBudget = DefaultScheduleGeneratorTimeBudget
+	}
+
+	rng := rand.New(rand.NewSource(options.Seed))
+	numMatches := int(math.Ceil(float64(numTeams*matchesPerTeam) / float64(teamsPerMatch)))
+	generator := newScheduleGenerator(numTeams, teamsPerAlliance, matchesPerTeam, numMatches, rng)
+	generator.unavailable = options.Unavailable
+	if options.Iterations <= 0 {
+		options.Iterations = scheduleGeneratorIterationsPerSlot * len(generator.slots)
+	}
+	completedIterations := generator.optimize(rng, options.Iterations, time.Now().Add(options.TimeBudget))
+	if completedIterations < options.Iterations && !options.AllowIncomplete {
+		return nil, fmt.Errorf(
+			"Ran out of time after %d of %d iterations, so the schedule for seed %d wouldn't be reproducible; "+
+				"generate a random schedule instead or allow more time",
+			completedIterations,
+			options.Iterations,
+			options.Seed,
+		)
+	}
+
+	if generator.hasDuplicateTeams() {
+		return nil, fmt.Errorf(
+			"Failed to generate a schedule for %d teams and %d matches without teams appearing twice in a match",
+			numTeams,
+			matchesPerTeam,
+		)
+	}
+
+	// Mark each surrogate team's third match as its surrogate appearance.
+	anonSchedule := make([][12]int, numMatches)
+	surrogateSlots := make(map[int]bool)
+	for _, slots := range generator.teamSlots {
+		if len(slots) > matchesPerTeam {
+			surrogateSlots[slots[min(2, len(slots)-1)]] = true
+		}
+	}
+	for slot, team := range generator.slots {
+		position := slot % teamsPerMatch
+		column := 2 * (position/teamsPerAlliance*stationsPerAlliance + position%teamsPerAlliance)
+		anonSchedule[slot/teamsPerMatch][column] = team + 1
+		if surrogateSlots[slot] {
+			anonSchedule[slot/teamsPerMatch][column+1] = 1
+		}
+	}
+	return anonSchedule, nil
+}
+
+// Creates a generator with an initial schedule built from consecutive random permutations of the teams, which
+// already spaces each team's matches out reasonably well.
+func newScheduleGenerator(
+	numTeams, teamsPerAlliance, matchesPerTeam, numMatches int, rng *rand.Rand,
+) *scheduleGenerator {
+	teamsPerMatch := 2 * teamsPerAlliance
+	generator := scheduleGenerator{
+		numTeams:         numTeams,
+		numMatches:       numMatches,
+		teamsPerAlliance: teamsPerAlliance,
+		teamsPerMatch:    teamsPerMatch,
+		minMatchGap:      max(1, int(0.75*float64(numTeams)/float64(teamsPerMatch))),
+		teamSlots:        make([][]int, numTeams),
+		partnerCounts:    make([]int, numTeams*numTeams),
+		opponentCounts:   make([]int, numTeams*numTeams),
+	}
+	for i := 0; i < matchesPerTeam; i++ {
+		generator.slots = append(generator.slots, rng.Perm(numTeams)...)
+	}
+
+	// Fill out the last match with teams that will play an extra match as surrogates.
+	surrogates := rng.Perm(numTeams)
+	for i := 0; len(generator.slots) < numMatches*teamsPerMatch; i++ {
+		generator.slots = append(generator.slots, surrogates[i])
+	}
+
+	for slot, team := range generator.slots {
+		generator.teamSlots[team] = append(generator.teamSlots[team], slot)
+	}
+	for match := 0; match < numMatches; match++ {
+		generator.updateMatchPairs(match, 1)
+	}
+	return &generator
+}
+
+// Runs simulated annealing on the schedule by repeatedly swapping two team slots, accepting swaps that make it worse
+// with a probability that decreases as the temperature cools. Returns the number of iterations completed before the
+// deadline.
+func (generator *scheduleGenerator) optimize(rng *rand.Rand, iterations int, deadline time.Time) int {
+	coolingRate := math.Pow(scheduleGeneratorEndTemperature/scheduleGeneratorStartTemperature, 1/float64(iterations))
+	temperature := scheduleGeneratorStartTemperature
+	for i := 0; i < iterations; i++ {
+		if i%scheduleGeneratorDeadlineInterval == 0 && time.Now().After(deadline) {
+			return i
+		}
+		temperature *= coolingRate
+
+		slot1 := rng.Intn(len(generator.slots))
+		slot2 := rng.Intn(len(generator.slots))
+		if generator.slots[slot1] == generator.slots[slot2] {
+			continue
+		}
+		delta := generator.swap(slot1, slot2)
+		if delta > 0 && rng.Float64() >= math.Exp(-delta/temperature) {
+			generator.swap(slot1, slot2)
+		}
+	}
+	return iterations
+}
+
+// Swaps the teams in the given slots and returns the resulting change in the schedule's penalty score.
+func (generator *scheduleGenerator) swap(slot1, slot2 int) float64 {
+	team1 := generator.slots[slot1]
+	team2 := generator.slots[slot2]
+	match1 := slot1 / generator.teamsPerMatch
+	match2 := slot2 / generator.teamsPerMatch
+
+	delta := -generator.teamPenalty(team1) - generator.teamPenalty(team2)
+	delta += generator.updateMatchPairs(match1, -1)
+	if match2 != match1 {
+		delta += generator.updateMatchPairs(match2, -1)
+	}
+
+	generator.slots[slot1], generator.slots[slot2] = team2, team1
+	replaceTeamSlot(generator.teamSlots[team1], slot1, slot2)
+	replaceTeamSlot(generator.teamSlots[team2], slot2, slot1)
+
+	delta += generator.updateMatchPairs(match1, 1)
+	if match2 != match1 {
+		delta += generator.updateMatchPairs(match2, 1)
+	}
+	delta += generator.teamPenalty(team1) + generator.teamPenalty(team2)
+	return delta
+}
+
+// Adds or removes (depending on the sign) the partner and opponent pairings in the given match to or from the running
+// counts, and returns the resulting change in the penalty score. Each repeat of a pairing is penalized linearly more
+// than the last.
+func (generator *scheduleGenerator) updateMatchPairs(match int, sign int) float64 {
+	delta := 0.0
+	teams := generator.slots[match*generator.teamsPerMatch : (match+1)*generator.teamsPerMatch]
+	for i := 0; i < generator.teamsPerMatch; i++ {
+		for j := i + 1; j < generator.teamsPerMatch; j++ {
+			if teams[i] == teams[j] {
+				delta += float64(sign) * duplicateTeamPenalty
+				continue
+			}
+			counts, weight := generator.opponentCounts, opponentRepeatPenalty
+			if i/generator.teamsPerAlliance == j/generator.teamsPerAlliance {
+				counts, weight = generator.partnerCounts, partnerRepeatPenalty
+			}
+			index := min(teams[i], teams[j])*generator.numTeams + max(teams[i], teams[j])
+			if sign > 0 {
+				delta += weight * float64(counts[index])
+				counts[index]++
+			} else {
+				counts[index]--
+				delta -= weight * float64(counts[index])
+			}
+		}
+	}
+	return delta
+}
+
+// Returns the penalty score for the given team's matches being too close together or outside its availability window,
+// and for imbalances in its red/blue alliance and station assignments.
+func (generator *scheduleGenerator) teamPenalty(team int) float64 {
+	penalty := 0.0
+	slots := generator.teamSlots[team]
+	redCount := 0
+	var stationCounts [stationsPerAlliance]int
+	for i, slot := range slots {
+		if generator.isUnavailable(team, slot/generator.teamsPerMatch) {
+			penalty += unavailablePenalty
+		}
+		if i > 0 {
+			if gap := slot/generator.teamsPerMatch - slots[i-1]/generator.teamsPerMatch; gap < generator.minMatchGap {
+				penalty += matchGapPenalty * float64((generator.minMatchGap-gap)*(generator.minMatchGap-gap))
+			}
+		}
+		station := slot % generator.teamsPerMatch
+		if station < generator.teamsPerAlliance {
+			redCount++
+		}
+		stationCounts[station%generator.teamsPerAlliance]++
+	}
+
+	if imbalance := abs(2*redCount-len(slots)) - 1; imbalance > 0 {
+		penalty += redBlueBalancePenalty * float64(imbalance*imbalance)
+	}
+	minStationCount, maxStationCount := len(slots), 0
+	for _, count := range stationCounts[:generator.teamsPerAlliance] {
+		minStationCount = min(minStationCount, count)
+		maxStationCount = max(maxStationCount, count)
+	}
+	if imbalance := maxStationCount - minStationCount - 1; imbalance > 0 {
+		penalty += stationBalancePenalty * float64(imbalance*imbalance)
+	}
+	return penalty
+}
+
+// Returns true if any team appears more than once in the same match.
+func (generator *scheduleGenerator) hasDuplicateTeams() bool {
+	for _, slots := range generator.teamSlots {
+		for i := 1; i < len(slots); i++ {
+			if slots[i]/generator.teamsPerMatch == slots[i-1]/generator.teamsPerMatch {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Returns true if the given team has been marked as unavailable for the given match.
+func (generator *scheduleGenerator) isUnavailable(team, match int) bool {
+	return team < len(generator.unavailable) && match < len(generator.unavailable[team]) &&
+		generator.unavailable[team][match]
+}
+
+// Replaces the old slot with the new one in the given sorted list of a team's slots, keeping it sorted.
+func replaceTeamSlot(slots []int, oldSlot, newSlot int) {
+	i := 0
+	for slots[i] != oldSlot {
+		i++
+	}
+	slots[i] = newSlot
+	for i > 0 && slots[i-1] > slots[i] {
+		slots[i-1], slots[i] = slots[i], slots[i-1]
+		i--
+	}
+	for i < len(slots)-1 && slots[i+1] < slots[i] {
+		slots[i+1], slots[i] = slots[i], slots[i+1]
+		i++
+	}
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
@@ -0,0 +1,159 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package tournament
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateAnonSchedule(t *testing.T) {
+	for _, testCase := range []struct {
+		numTeams         int
+		teamsPerAlliance int
+		matchesPerTeam   int
+		minMatchGap      int
+	}{
+		{6, 3, 10, 1},
+		{7, 3, 3, 1},
+		{18, 3, 6, 2},
+		{38, 3, 10, 4},
+		{13, 3, 20, 1},
+		{8, 3, 30, 1},
+		{4, 2, 10, 1},
+		{9, 2, 5, 1},
+		{24, 2, 8, 4},
+	} {
+		anonSchedule, err := GenerateAnonSchedule(
+			testCase.numTeams,
+			testCase.teamsPerAlliance,
+			testCase.matchesPerTeam,
+			ScheduleGeneratorOptions{Seed: 254},
+		)
+		if !assert.Nil(t, err) {
+			continue
+		}
+		teamsPerMatch := 2 * testCase.teamsPerAlliance
+		numSlots := testCase.numTeams * testCase.matchesPerTeam
+		assert.Equal(t, (numSlots+teamsPerMatch-1)/teamsPerMatch, len(anonSchedule))
+
+		appearances := make(map[int][]int)
+		surrogates := make(map[int]int)
+		redCounts := make(map[int]int)
+		for i, match := range anonSchedule {
+			teamsInMatch := make(map[int]bool)
+			for j := 0; j < TeamsPerMatch; j++ {
+				team := match[2*j]
+				if j%stationsPerAlliance >= testCase.teamsPerAlliance {
+					// Stations beyond the alliance size should be left empty.
+					assert.Equal(t, 0, team)
+					assert.Equal(t, 0, match[2*j+1])
+					continue
+				}
+				assert.False(t, teamsInMatch[team], "team %d appears twice in match %d", team, i+1)
+				teamsInMatch[team] = true
+				appearances[team] = append(appearances[team], i)
+				if match[2*j+1] == 1 {
+					surrogates[team]++
+					assert.Equal(t, min(3, testCase.matchesPerTeam+1), len(appearances[team]))
+				}
+				if j < TeamsPerMatch/2 {
+					redCounts[team]++
+				}
+			}
+		}
+		assert.Equal(t, len(anonSchedule)*teamsPerMatch-numSlots, len(surrogates))
+
+		assert.Equal(t, testCase.numTeams, len(appearances))
+		for team, matches := range appearances {
+			assert.Equal(t, testCase.matchesPerTeam+surrogates[team], len(matches))
+			assert.LessOrEqual(t, surrogates[team], 1)
+			for i := 1; i < len(matches); i++ {
+				assert.GreaterOrEqual(t, matches[i]-matches[i-1], testCase.minMatchGap)
+			}
+			assert.LessOrEqual(t, abs(2*redCounts[team]-len(matches)), 2)
+		}
+	}
+}
+
+func TestGenerateAnonScheduleSeed(t *testing.T) {
+	options := ScheduleGeneratorOptions{Seed: 1114, Iterations: 20000}
+	schedule1, _ := GenerateAnonSchedule(24, 3, 8, options)
+	schedule2, _ := GenerateAnonSchedule(24, 3, 8, options)
+	assert.Equal(t, schedule1, schedule2)
+	options.Seed = 254
+	schedule3, _ := GenerateAnonSchedule(24, 3, 8, options)
+	assert.NotEqual(t, schedule1, schedule3)
+
+	teams := make([]model.Team, 24)
+	for i := 0; i < len(teams); i++ {
+		teams[i].Id = i + 101
+	}
+	scheduleBlocks := []model.ScheduleBlock{{0, model.Practice, time.Unix(0, 0).UTC(), 32, 60}}
+	matches1, err := BuildGeneratedSchedule(teams, 3, scheduleBlocks, model.Practice, options)
+	assert.Nil(t, err)
+	matches2, _ := BuildGeneratedSchedule(teams, 3, scheduleBlocks, model.Practice, options)
+	assert.Equal(t, matches1, matches2)
+	assert.Equal(t, 32, len(matches1))
+	assert.Equal(t, time.Unix(1860, 0).UTC(), matches1[31].Time)
+}
+
+func TestGenerateAnonScheduleErrors(t *testing.T) {
+	_, err := GenerateAnonSchedule(5, 3, 10, ScheduleGeneratorOptions{})
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "At least 6 teams are needed to generate a schedule", err.Error())
+	}
+	_, err = GenerateAnonSchedule(3, 2, 10, ScheduleGeneratorOptions{})
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "At least 4 teams are needed to generate a schedule", err.Error())
+	}
+	_, err = GenerateAnonSchedule(20, 4, 10, ScheduleGeneratorOptions{})
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "Alliances must have 2 or 3 teams (requested 4)", err.Error())
+	}
+	_, err = GenerateAnonSchedule(20, 3, 0, ScheduleGeneratorOptions{})
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "Not enough matches to give each of the 20 teams at least one", err.Error())
+	}
+
+	// Running out of time before the teams have been separated should fail rather than return a bad schedule.
+	_, err = GenerateAnonSchedule(
+		10, 3, 12, ScheduleGeneratorOptions{Seed: 1, TimeBudget: time.Nanosecond, AllowIncomplete: true},
+	)
+	if assert.NotNil(t, err) {
+		assert.Equal(
+			t,
+			"Failed to generate a schedule for 10 teams and 12 matches without teams appearing twice in a match",
+			err.Error(),
+		)
+	}
+}
+
+func TestGenerateAnonScheduleTimeBudget(t *testing.T) {
+	// Running out of time should be reported rather than returning a schedule that the seed wouldn't reproduce.
+	startTime := time.Now()
+	options := ScheduleGeneratorOptions{Seed: 254, TimeBudget: 100 * time.Millisecond, Iterations: 1000000000}
+	_, err := GenerateAnonSchedule(6, 3, 500, options)
+	if assert.NotNil(t, err) {
+		assert.Regexp(
+			t,
+			"^Ran out of time after \\d+ of 1000000000 iterations, so the schedule for seed 254 wouldn't be "+
+				"reproducible; generate a random schedule instead or allow more time$",
+			err.Error(),
+		)
+	}
+	assert.Less(t, time.Since(startTime), time.Second)
+
+	// Large match counts should be cut short by the time budget rather than rejected or left running indefinitely, if
+	// the caller doesn't need the schedule to be reproducible.
+	startTime = time.Now()
+	options.AllowIncomplete = true
+	anonSchedule, err := GenerateAnonSchedule(6, 3, 500, options)
+	assert.Nil(t, err)
+	assert.Equal(t, 500, len(anonSchedule))
+	assert.Less(t, time.Since(startTime), time.Second)
+}
@@ -0,0 +1,339 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Functions for importing externally generated practice and qualification match schedules.
+
+package tournament
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Team254/cheesy-arena/model"
+)
+
+// Formats that are accepted for the match time column, in order of preference.
+var scheduleImportTimeFormats = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05 -0700 MST",
+	"2006-01-02 03:04:05 PM",
+	"2006-01-02 03:04 PM",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+}
+
+// Names of the alliance station columns in the import file, in the same order as the team fields on a match.
+var scheduleImportStations = []string{"Red1", "Red2", "Red3", "Blue1", "Blue2", "Blue3"}
+
+// Represents a single match row from an imported schedule file.
+type scheduleImportRow struct {
+	rowNumber  int
+	time       time.Time
+	teams      [TeamsPerMatch]int
+	surrogates [TeamsPerMatch]bool
+}
+
+// Parses a CSV schedule containing one match per row and returns it as a list of matches of the given type, after
+// validating it against the given team list. The file must have a header row with Red1 through Blue3 team number
+// columns, and may optionally have Red1IsSurrogate through Blue3IsSurrogate and Time columns; this is the same format
+// as the schedule CSV report. With two-team alliances the Red3 and Blue3 columns may be omitted and must otherwise be
+// empty. If no match times are given, they are filled in from the given schedule blocks instead.
+func ImportSchedule(
+	reader io.Reader,
+	teams []model.Team,
+	teamsPerAlliance int,
+	scheduleBlocks []model.ScheduleBlock,
+	matchType model.MatchType,
+) ([]model.Match, error) {
+	if err := validateTeamsPerAlliance(teamsPerAlliance); err != nil {
+		return nil, err
+	}
+	rows, problems := parseScheduleImport(reader, teamsPerAlliance)
+	if len(problems) == 0 {
+		problems = validateScheduleImport(rows, teams, matchType)
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+
+	matches := make([]model.Match, len(rows))
+	hasTimes := false
+	for i, row := range rows {
+		if err := setMatchNames(&matches[i], i+1, matchType); err != nil {
+			return nil, err
+		}
+		matches[i].Time = row.time
+		matches[i].Red1, matches[i].Red1IsSurrogate = row.teams[0], row.surrogates[0]
+		matches[i].Red2, matches[i].Red2IsSurrogate = row.teams[1], row.surrogates[1]
+		matches[i].Red3, matches[i].Red3IsSurrogate = row.teams[2], row.surrogates[2]
+		matches[i].Blue1, matches[i].Blue1IsSurrogate = row.teams[3], row.surrogates[3]
+		matches[i].Blue2, matches[i].Blue2IsSurrogate = row.teams[4], row.surrogates[4]
+		matches[i].Blue3, matches[i].Blue3IsSurrogate = row.teams[5], row.surrogates[5]
+		hasTimes = hasTimes || !row.time.IsZero()
+	}
+
+	if !hasTimes {
+		if numAssigned := assignMatchTimes(matches, scheduleBlocks); numAssigned < len(matches) {
+			return nil, fmt.Errorf(
+				"The schedule file has no match times and the schedule blocks only have room for %d of its %d "+
+					"matches",
+				numAssigned,
+				len(matches),
+			)
+		}
+	}
+
+	return matches, nil
+}
+
+// Reads the rows of the given CSV schedule file, returning a list of any formatting problems found.
+func parseScheduleImport(reader io.Reader, teamsPerAlliance int) ([]scheduleImportRow, []string) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+	csvLines, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, []string{fmt.Sprintf("Failed to parse CSV: %s", err.Error())}
+	}
+	if len(csvLines) == 0 {
+		return nil, []string{"The schedule file is empty"}
+	}
+
+	// Map the header row onto the columns that are understood.
+	columns := make(map[string]int)
+	for i, header := range csvLines[0] {
+		columns[normalizeScheduleImportHeader(header)] = i
+	}
+	teamColumns := make([]int, TeamsPerMatch)
+	surrogateColumns := make([]int, TeamsPerMatch)
+	var problems []string
+	for i, station := range scheduleImportStations {
+		var ok bool
+		if teamColumns[i], ok = columns[strings.ToLower(station)]; !ok {
+			teamColumns[i] = -1
+			if i%stationsPerAlliance < teamsPerAlliance {
+				problems = append(problems, fmt.Sprintf("The schedule file is missing the %s column", station))
+			}
+		}
+		surrogateColumns[i] = -1
+		for _, suffix := range []string{"issurrogate", "surrogate", "surr"} {
+			if column, ok := columns[strings.ToLower(station)+suffix]; ok {
+				surrogateColumns[i] = column
+				break
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return nil, problems
+	}
+	timeColumn, hasTimeColumn := columns["time"]
+
+	var rows []scheduleImportRow
+	for i, csvLine := range csvLines[1:] {
+		row := scheduleImportRow{rowNumber: i + 1}
+		cell := func(column int) string {
+			if column >= 0 && column < len(csvLine) {
+				return strings.TrimSpace(csvLine[column])
+			}
+			return ""
+		}
+		if strings.Join(csvLine, "") == "" {
+			continue
+		}
+
+		for j, station := range scheduleImportStations {
+			value := cell(teamColumns[j])
+			if j%stationsPerAlliance >= teamsPerAlliance {
+				// Stations beyond the alliance size have to be left empty.
+				if value != "" && value != "0" {
+					problems = append(
+						problems,
+						fmt.Sprintf(
+							"Row %d: %s must be empty since alliances have %d teams",
+							row.rowNumber,
+							station,
+							teamsPerAlliance,
+						),
+					)
+				}
+				continue
+			}
+			if row.teams[j], err = strconv.Atoi(value); err != nil || row.teams[j] <= 0 {
+				problems = append(
+					problems, fmt.Sprintf("Row %d: invalid %s team number '%s'", row.rowNumber, station, value),
+				)
+			}
+			if value = cell(surrogateColumns[j]); value != "" {
+				if row.surrogates[j], err = strconv.ParseBool(value); err != nil {
+					problems = append(
+						problems,
+						fmt.Sprintf("Row %d: invalid %s surrogate flag '%s'", row.rowNumber, station, value),
+					)
+				}
+			}
+		}
+		if hasTimeColumn {
+			if value := cell(timeColumn); value != "" {
+				if row.time, err = parseScheduleImportTime(value); err != nil {
+					problems = append(problems, fmt.Sprintf("Row %d: invalid match time '%s'", row.rowNumber, value))
+				}
+			}
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 && len(problems) == 0 {
+		problems = append(problems, "The schedule file doesn't contain any matches")
+	}
+
+	// Times must either be given for every match or for none of them.
+	var missingTimeRows []string
+	numTimes := 0
+	for _, row := range rows {
+		if row.time.IsZero() {
+			missingTimeRows = append(missingTimeRows, strconv.Itoa(row.rowNumber))
+		} else {
+			numTimes++
+		}
+	}
+	if numTimes > 0 && len(missingTimeRows) > 0 {
+		problems = append(
+			problems, fmt.Sprintf("Rows %s are missing a match time", strings.Join(missingTimeRows, ", ")),
+		)
+	}
+
+	return rows, problems
+}
+
+// Checks the given schedule rows against the team list and returns a list of any problems found.
+func validateScheduleImport(rows []scheduleImportRow, teams []model.Team, matchType model.MatchType) []string {
+	var problems []string
+	teamIds := make(map[int]bool)
+	for _, team := range teams {
+		teamIds[team.Id] = true
+	}
+
+	matchCounts := make(map[int]int)
+	surrogateCounts := make(map[int]int)
+	unknownTeams := make(map[int]bool)
+	for _, row := range rows {
+		for i, teamId := range row.teams {
+			if teamId == 0 {
+				// This is an unused station on a two-team alliance.
+				continue
+			}
+			if !teamIds[teamId] && !unknownTeams[teamId] {
+				unknownTeams[teamId] = true
+				problems = append(
+					problems, fmt.Sprintf("Row %d: team %d is not in the team list", row.rowNumber, teamId),
+				)
+			}
+			for j := 0; j < i; j++ {
+				if row.teams[j] == teamId {
+					problems = append(
+						problems,
+						fmt.Sprintf(
+							"Row %d: team %d appears in both %s and %s",
+							row.rowNumber,
+							teamId,
+							scheduleImportStations[j],
+							scheduleImportStations[i],
+						),
+					)
+				}
+			}
+			if row.surrogates[i] {
+				surrogateCounts[teamId]++
+			} else {
+				matchCounts[teamId]++
+			}
+		}
+	}
+
+	for _, team := range teams {
+		if matchCounts[team.Id]+surrogateCounts[team.Id] == 0 {
+			problems = append(problems, fmt.Sprintf("Team %d doesn't appear in the schedule", team.Id))
+		}
+	}
+
+	// Surrogate appearances don't count towards rankings, so each team should play the same number of counted
+	// qualification matches and never be a surrogate more than once.
+	if matchType == model.Qualification && len(problems) == 0 {
+		var teamIdsWithMatches []int
+		for teamId := range matchCounts {
+			teamIdsWithMatches = append(teamIdsWithMatches, teamId)
+		}
+		for teamId := range surrogateCounts {
+			if _, ok := matchCounts[teamId]; !ok {
+				teamIdsWithMatches = append(teamIdsWithMatches, teamId)
+			}
+		}
+		sort.Ints(teamIdsWithMatches)
+		minMatches, maxMatches := -1, 0
+		for _, teamId := range teamIdsWithMatches {
+			if surrogateCounts[teamId] > 1 {
+				problems = append(
+					problems,
+					fmt.Sprintf(
+						"Team %d is a surrogate in %d matches; the maximum is 1", teamId, surrogateCounts[teamId],
+					),
+				)
+			}
+			if minMatches == -1 || matchCounts[teamId] < minMatches {
+				minMatches = matchCounts[teamId]
+			}
+			if matchCounts[teamId] > maxMatches {
+				maxMatches = matchCounts[teamId]
+			}
+		}
+		if minMatches != maxMatches {
+			var unevenTeams []string
+			for _, teamId := range teamIdsWithMatches {
+				if matchCounts[teamId] != maxMatches {
+					unevenTeams = append(unevenTeams, fmt.Sprintf("%d (%d)", teamId, matchCounts[teamId]))
+				}
+			}
+			problems = append(
+				problems,
+				fmt.Sprintf(
+					"Teams don't all play the same number of non-surrogate matches; most play %d but these teams "+
+						"don't: %s",
+					maxMatches,
+					strings.Join(unevenTeams, ", "),
+				),
+			)
+		}
+	}
+
+	return problems
+}
+
+// Parses the given match time, which is interpreted in the local time zone unless it specifies one.
+func parseScheduleImportTime(value string) (time.Time, error) {
+	var err error
+	for _, format := range scheduleImportTimeFormats {
+		var matchTime time.Time
+		if matchTime, err = time.ParseInLocation(format, value, time.Local); err == nil {
+			return matchTime, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// Returns the given column header in lowercase with any spaces, punctuation and byte order marks removed.
+func normalizeScheduleImportHeader(header string) string {
+	return strings.Map(
+		func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return unicode.ToLower(r)
+			}
+			return -1
+		},
+		header,
+	)
+}
@@ -0,0 +1,146 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package tournament
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportSchedule(t *testing.T) {
+	teams := importTestTeams(7)
+	data := "Match,Type,Time,Red1,Red1IsSurrogate,Red2,Red2IsSurrogate,Red3,Red3IsSurrogate,Blue1,Blue1IsSurrogate," +
+		"Blue2,Blue2IsSurrogate,Blue3,Blue3IsSurrogate\n" +
+		"Q1,Qualification,2026-04-01 09:00:00 -0700 PDT,101,false,102,false,103,false,104,false,105,false,106,false\n" +
+		"Q2,Qualification,2026-04-01 09:07:00 -0700 PDT,107,false,101,true,102,false,103,false,104,false,105,false\n" +
+		"Q3,Qualification,2026-04-01 09:14:00 -0700 PDT,106,false,107,false,101,false,102,true,103,true,104,true\n" +
+		"Q4,Qualification,2026-04-01 09:21:00 -0700 PDT,105,false,106,false,107,false,101,false,102,false,103,false\n" +
+		"Q5,Qualification,2026-04-01 09:28:00 -0700 PDT,104,false,105,false,106,false,107,false,101,false,102,false\n" +
+		"Q6,Qualification,2026-04-01 09:35:00 -0700 PDT,103,false,104,false,105,true,106,true,107,false,101,false\n" +
+		"Q7,Qualification,2026-04-01 09:42:00 -0700 PDT,102,false,103,false,104,false,105,false,106,false,107,true\n"
+	matches, err := ImportSchedule(strings.NewReader(data), teams, 3, nil, model.Qualification)
+	if assert.Nil(t, err) && assert.Equal(t, 7, len(matches)) {
+		matchTime := time.Date(2026, 4, 1, 16, 7, 0, 0, time.UTC)
+		matches[1].Time = matches[1].Time.UTC()
+		assertMatch(
+			t, matches[1], model.Qualification, 2, matchTime.Unix(), "Q2", "Qualification 2", "qm", 107, 101, 102, 103,
+			104, 105,
+		)
+		assert.True(t, matches[1].Red2IsSurrogate)
+		assert.False(t, matches[1].Red1IsSurrogate)
+		assert.True(t, matches[2].Blue3IsSurrogate)
+	}
+
+	// Columns can be in any order with minor differences in naming, and times can come from the schedule blocks.
+	data = "blue 1,blue 2,blue 3,red 1,red 2,red 3\n104,105,106,101,102,103\n\n101,102,103,104,105,106\n"
+	scheduleBlocks := []model.ScheduleBlock{
+		{MatchType: model.Practice, StartTime: time.Unix(1000, 0).UTC(), NumMatches: 5, MatchSpacingSec: 360},
+	}
+	matches, err = ImportSchedule(strings.NewReader(data), importTestTeams(6), 3, scheduleBlocks, model.Practice)
+	if assert.Nil(t, err) && assert.Equal(t, 2, len(matches)) {
+		assertMatch(t, matches[0], model.Practice, 1, 1000, "P1", "Practice 1", "p", 101, 102, 103, 104, 105, 106)
+		assertMatch(t, matches[1], model.Practice, 2, 1360, "P2", "Practice 2", "p", 104, 105, 106, 101, 102, 103)
+	}
+	_, err = ImportSchedule(strings.NewReader(data), importTestTeams(6), 3, scheduleBlocks[0:0], model.Practice)
+	if assert.NotNil(t, err) {
+		assert.Equal(
+			t,
+			"The schedule file has no match times and the schedule blocks only have room for 0 of its 2 matches",
+			err.Error(),
+		)
+	}
+}
+
+func TestImportScheduleTwoTeamAlliances(t *testing.T) {
+	teams := importTestTeams(4)
+	data := "Red1,Red2,Blue1,Blue2\n101,102,103,104\n103,101,104,102\n"
+	scheduleBlocks := []model.ScheduleBlock{
+		{MatchType: model.Practice, StartTime: time.Unix(0, 0).UTC(), NumMatches: 2, MatchSpacingSec: 360},
+	}
+	matches, err := ImportSchedule(strings.NewReader(data), teams, 2, scheduleBlocks, model.Practice)
+	if assert.Nil(t, err) && assert.Equal(t, 2, len(matches)) {
+		assertMatch(t, matches[0], model.Practice, 1, 0, "P1", "Practice 1", "p", 101, 102, 0, 103, 104, 0)
+		assertMatch(t, matches[1], model.Practice, 2, 360, "P2", "Practice 2", "p", 103, 101, 0, 104, 102, 0)
+	}
+
+	// Empty third station columns are allowed, but not teams in them.
+	data = "Red1,Red2,Red3,Red3IsSurrogate,Blue1,Blue2,Blue3\n101,102,0,false,103,104,\n103,101,,,104,102,101\n"
+	_, err = ImportSchedule(strings.NewReader(data), teams, 2, scheduleBlocks, model.Practice)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "Row 2: Blue3 must be empty since alliances have 2 teams", err.Error())
+	}
+
+	// Three-team alliances still require the third station columns.
+	data = "Red1,Red2,Blue1,Blue2\n101,102,103,104\n"
+	_, err = ImportSchedule(strings.NewReader(data), importTestTeams(6), 3, nil, model.Practice)
+	if assert.NotNil(t, err) {
+		assert.Equal(
+			t,
+			"The schedule file is missing the Red3 column; The schedule file is missing the Blue3 column",
+			err.Error(),
+		)
+	}
+}
+
+func TestImportScheduleErrors(t *testing.T) {
+	teams := importTestTeams(6)
+	assertImportError := func(data string, matchType model.MatchType, expectedErr string) {
+		_, err := ImportSchedule(strings.NewReader(data), teams, 3, nil, matchType)
+		if assert.NotNil(t, err) {
+			assert.Equal(t, expectedErr, err.Error())
+		}
+	}
+
+	assertImportError("", model.Qualification, "The schedule file is empty")
+	assertImportError(
+		"Red1,Red2,Red3,Blue1,Blue2\n", model.Qualification, "The schedule file is missing the Blue3 column",
+	)
+	assertImportError(
+		"Red1,Red2,Red3,Blue1,Blue2,Blue3\n", model.Qualification, "The schedule file doesn't contain any matches",
+	)
+	assertImportError(
+		"Time,Red1,Red2,Red3,Blue1,Blue2,Blue3,Blue3Surrogate\n"+
+			"tomorrow,101,102,abc,104,105,0,maybe\n2026-04-01 09:00,101,102,103,104,105,106,\n"+
+			",101,102,103,104,105,106,",
+		model.Qualification,
+		"Row 1: invalid Red3 team number 'abc'; Row 1: invalid Blue3 team number '0'; Row 1: invalid Blue3 "+
+			"surrogate flag 'maybe'; Row 1: invalid match time 'tomorrow'; Rows 1, 3 are missing a match time",
+	)
+	assertImportError(
+		"Red1,Red2,Red3,Blue1,Blue2,Blue3\n101,102,103,104,105,254\n101,102,101,104,105,254\n",
+		model.Practice,
+		"Row 1: team 254 is not in the team list; Row 2: team 101 appears in both Red1 and Red3; Team 106 doesn't "+
+			"appear in the schedule",
+	)
+
+	// Qualification schedules must be balanced once surrogate appearances are excluded.
+	data := "Red1,Red2,Red3,Blue1,Blue2,Blue3,Red1Surr\n101,102,103,104,105,106,0\n101,102,103,104,105,106,0\n" +
+		"101,102,103,104,105,106,1\n"
+	_, err := ImportSchedule(strings.NewReader(data), teams, 3, nil, model.Practice)
+	assert.Contains(t, err.Error(), "schedule blocks only have room")
+	assertImportError(
+		data,
+		model.Qualification,
+		"Teams don't all play the same number of non-surrogate matches; most play 3 but these teams don't: 101 (2)",
+	)
+	data = "Red1,Red2,Red3,Blue1,Blue2,Blue3,Red1Surr\n101,102,103,104,105,106,1\n101,102,103,104,105,106,1\n"
+	assertImportError(
+		data,
+		model.Qualification,
+		"Team 101 is a surrogate in 2 matches; the maximum is 1; Teams don't all play the same number of "+
+			"non-surrogate matches; most play 2 but these teams don't: 101 (0)",
+	)
+}
+
+func importTestTeams(numTeams int) []model.Team {
+	teams := make([]model.Team, numTeams)
+	for i := 0; i < numTeams; i++ {
+		teams[i].Id = i + 101
+	}
+	return teams
+}
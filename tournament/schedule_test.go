@@ -21,11 +21,30 @@ func TestMain(m *testing.M) {
 func TestNonExistentSchedule(t *testing.T) {
 	teams := make([]model.Team, 5)
 	scheduleBlocks := []model.ScheduleBlock{{0, model.Test, time.Unix(0, 0).UTC(), 2, 60}}
-	_, err := BuildRandomSchedule(teams, scheduleBlocks, model.Test)
-	expectedErr := "No schedule template exists for 5 teams and 2 matches"
+	_, err := BuildRandomSchedule(teams, 3, scheduleBlocks, model.Test)
+	expectedErr := "At least 6 teams are needed to generate a schedule"
 	if assert.NotNil(t, err) {
 		assert.Equal(t, expectedErr, err.Error())
 	}
+
+	// Team and match counts without a template should fall back to the native schedule generator.
+	rand.Seed(0)
+	teams = make([]model.Team, 120)
+	for i := 0; i < len(teams); i++ {
+		teams[i].Id = i + 101
+	}
+	scheduleBlocks = []model.ScheduleBlock{{0, model.Qualification, time.Unix(0, 0).UTC(), 60, 60}}
+	matches, err := BuildRandomSchedule(teams, 3, scheduleBlocks, model.Qualification)
+	assert.Nil(t, err)
+	assert.Equal(t, 60, len(matches))
+	assert.Equal(t, "Q60", matches[59].ShortName)
+	assert.Equal(t, time.Unix(3540, 0).UTC(), matches[59].Time)
+
+	// There is no fixed limit on the number of matches per team; the generator is bounded by its time budget instead.
+	scheduleBlocks = []model.ScheduleBlock{{0, model.Qualification, time.Unix(0, 0).UTC(), 40, 60}}
+	matches, err = BuildRandomSchedule(teams[0:6], 3, scheduleBlocks, model.Qualification)
+	assert.Nil(t, err)
+	assert.Equal(t, 40, len(matches))
 }
 
 func TestMalformedSchedule(t *testing.T) {
@@ -36,7 +55,7 @@ func TestMalformedSchedule(t *testing.T) {
 	scheduleFile.Close()
 	teams := make([]model.Team, 5)
 	scheduleBlocks := []model.ScheduleBlock{{0, model.Test, time.Unix(0, 0).UTC(), 1, 60}}
-	_, err := BuildRandomSchedule(teams, scheduleBlocks, model.Test)
+	_, err := BuildRandomSchedule(teams, 3, scheduleBlocks, model.Test)
 	expectedErr := "Schedule file contains 2 matches, expected 1"
 	if assert.NotNil(t, err) {
 		assert.Equal(t, expectedErr, err.Error())
@@ -46,7 +65,7 @@ func TestMalformedSchedule(t *testing.T) {
 	scheduleFile, _ = os.Create(filename)
 	scheduleFile.WriteString("1,0,asdf,0,3,0,4,0,5,0,6,0\n")
 	scheduleFile.Close()
-	_, err = BuildRandomSchedule(teams, scheduleBlocks, model.Test)
+	_, err = BuildRandomSchedule(teams, 3, scheduleBlocks, model.Test)
 	if assert.NotNil(t, err) {
 		assert.Contains(t, err.Error(), "strconv.Atoi")
 	}
@@ -61,7 +80,7 @@ func TestScheduleTeams(t *testing.T) {
 		teams[i].Id = i + 101
 	}
 	scheduleBlocks := []model.ScheduleBlock{{0, model.Practice, time.Unix(0, 0).UTC(), 6, 60}}
-	matches, err := BuildRandomSchedule(teams, scheduleBlocks, model.Practice)
+	matches, err := BuildRandomSchedule(teams, 3, scheduleBlocks, model.Practice)
 	assert.Nil(t, err)
 	assertMatch(t, matches[0], model.Practice, 1, 0, "P1", "Practice 1", "p", 115, 111, 108, 109, 116, 117)
 	assertMatch(t, matches[1], model.Practice, 2, 60, "P2", "Practice 2", "p", 114, 112, 103, 101, 104, 118)
@@ -72,13 +91,13 @@ func TestScheduleTeams(t *testing.T) {
 
 	// Check with excess room for matches in the schedule.
 	scheduleBlocks = []model.ScheduleBlock{{0, model.Practice, time.Unix(0, 0).UTC(), 7, 60}}
-	matches, err = BuildRandomSchedule(teams, scheduleBlocks, model.Practice)
+	matches, err = BuildRandomSchedule(teams, 3, scheduleBlocks, model.Practice)
 	assert.Nil(t, err)
 
 	// Check with qualification matches.
 	rand.Seed(0)
 	scheduleBlocks = []model.ScheduleBlock{{0, model.Qualification, time.Unix(0, 0).UTC(), 6, 60}}
-	matches, err = BuildRandomSchedule(teams, scheduleBlocks, model.Qualification)
+	matches, err = BuildRandomSchedule(teams, 3, scheduleBlocks, model.Qualification)
 	assert.Nil(t, err)
 	assertMatch(t, matches[0], model.Qualification, 1, 0, "Q1", "Qualification 1", "qm", 115, 111, 108, 109, 116, 117)
 	assertMatch(t, matches[1], model.Qualification, 2, 60, "Q2", "Qualification 2", "qm", 114, 112, 103, 101, 104, 118)
@@ -95,7 +114,7 @@ func TestScheduleTiming(t *testing.T) {
 		{0, model.Qualification, time.Unix(20000, 0).UTC(), 5, 1000},
 		{0, model.Qualification, time.Unix(100000, 0).UTC(), 15, 29},
 	}
-	matches, err := BuildRandomSchedule(teams, scheduleBlocks, model.Qualification)
+	matches, err := BuildRandomSchedule(teams, 3, scheduleBlocks, model.Qualification)
 	assert.Nil(t, err)
 	assert.Equal(t, time.Unix(100, 0).UTC(), matches[0].Time)
 	assert.Equal(t, time.Unix(775, 0).UTC(), matches[9].Time)
@@ -114,7 +133,7 @@ func TestScheduleSurrogates(t *testing.T) {
 		teams[i].Id = i + 101
 	}
 	scheduleBlocks := []model.ScheduleBlock{{0, model.Qualification, time.Unix(0, 0).UTC(), 64, 60}}
-	matches, _ := BuildRandomSchedule(teams, scheduleBlocks, model.Qualification)
+	matches, _ := BuildRandomSchedule(teams, 3, scheduleBlocks, model.Qualification)
 	for i, match := range matches {
 		if i == 13 || i == 14 {
 			if !match.Red1IsSurrogate || match.Red2IsSurrogate || match.Red3IsSurrogate ||
@@ -130,6 +149,72 @@ func TestScheduleSurrogates(t *testing.T) {
 	}
 }
 
+func TestScheduleTwoTeamAlliances(t *testing.T) {
+	rand.Seed(0)
+
+	teams := make([]model.Team, 10)
+	for i := 0; i < len(teams); i++ {
+		teams[i].Id = i + 101
+	}
+	scheduleBlocks := []model.ScheduleBlock{{0, model.Qualification, time.Unix(0, 0).UTC(), 21, 60}}
+	matches, err := BuildRandomSchedule(teams, 2, scheduleBlocks, model.Qualification)
+	assert.Nil(t, err)
+	assert.Equal(t, 20, len(matches))
+	matchCounts := make(map[int]int)
+	for _, match := range matches {
+		assert.Equal(t, 0, match.Red3)
+		assert.Equal(t, 0, match.Blue3)
+		assert.False(t, match.Red3IsSurrogate)
+		assert.False(t, match.Blue3IsSurrogate)
+		for _, teamId := range []int{match.Red1, match.Red2, match.Blue1, match.Blue2} {
+			matchCounts[teamId]++
+		}
+	}
+	assert.Equal(t, len(teams), len(matchCounts))
+	for _, team := range teams {
+		assert.Equal(t, 8, matchCounts[team.Id])
+	}
+
+	_, err = BuildRandomSchedule(teams[0:3], 2, scheduleBlocks, model.Qualification)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "At least 4 teams are needed to generate a schedule", err.Error())
+	}
+	_, err = BuildRandomSchedule(teams, 1, scheduleBlocks, model.Qualification)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "Alliances must have 2 or 3 teams (requested 1)", err.Error())
+	}
+}
+
+func TestScheduleTeamAvailability(t *testing.T) {
+	rand.Seed(0)
+
+	teams := make([]model.Team, 18)
+	for i := 0; i < len(teams); i++ {
+		teams[i].Id = i + 101
+	}
+	teams[0].AvailableFrom = time.Unix(600, 0).UTC()
+	teams[1].AvailableFrom = time.Unix(600, 0).UTC()
+	teams[2].AvailableFrom = time.Unix(900, 0).UTC()
+	teams[3].AvailableUntil = time.Unix(1200, 0).UTC()
+	teams[4].AvailableFrom = time.Unix(300, 0).UTC()
+	teams[4].AvailableUntil = time.Unix(1500, 0).UTC()
+	scheduleBlocks := []model.ScheduleBlock{{0, model.Qualification, time.Unix(0, 0).UTC(), 30, 60}}
+	matches, err := BuildRandomSchedule(teams, 3, scheduleBlocks, model.Qualification)
+	assert.Nil(t, err)
+	assert.Equal(t, 30, len(matches))
+	analysis := AnalyzeSchedule(matches, teams, nil)
+	assert.Empty(t, analysis.AvailabilityViolations)
+	for _, teamAnalysis := range analysis.Teams {
+		assert.Equal(t, 10, teamAnalysis.NumMatches)
+	}
+
+	matches, err = BuildGeneratedSchedule(
+		teams, 2, scheduleBlocks, model.Qualification, ScheduleGeneratorOptions{Seed: 254},
+	)
+	assert.Nil(t, err)
+	assert.Empty(t, AnalyzeSchedule(matches, teams, nil).AvailabilityViolations)
+}
+
 func assertMatch(
 	t *testing.T,
 	match model.Match,
@@ -237,7 +237,20 @@ func (web *Web) allianceSelectionFinalizeHandler(w http.ResponseWriter, r *http.
 		return
 	}
 
-	
+	if web.arena.EventSettings.TbaPublishingEnabled {
+		// Publish alliances and schedule to The Blue Alliance. Don't fail the finalization if TBA can't be reached;
+		// the client will keep retrying in the background.
+		if err = web.arena.TbaClient.PublishAlliances(web.arena.Database); err != nil {
+			log.Printf("Failed to publish alliances: %s", err.Error())
+		}
+		if err = web.arena.TbaClient.PublishMatches(web.arena.Database); err != nil {
+			log.Printf("Failed to publish matches: %s", err.Error())
+		}
+	}
+
+	if alliances, err := web.arena.Database.GetAllAlliances(); err == nil {
+		web.arena.TriggerWebhook(model.WebhookAlliancesFinalized, alliances)
+	}
 
 	// Signal displays of the bracket to update themselves.
 	web.arena.ScorePostedNotifier.Notify()
@@ -11,6 +11,7 @@ import (
 	"github.com/Team254/cheesy-arena/websocket"
 	gorillawebsocket "github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
+	"strings"
 	"testing"
 	"time"
 )
@@ -189,3 +190,64 @@ func TestBracketSvgApiDoubleElimination(t *testing.T) {
 	assert.Equal(t, "image/svg+xml", recorder.Header()["Content-Type"][0])
 	assert.Contains(t, recorder.Body.String(), "Best-of-3")
 }
+
+func TestBroadcastApi(t *testing.T) {
+	web := setupTestWeb(t)
+
+	web.arena.Database.CreateTeam(&model.Team{Id: 254, Nickname: "ChezyPof"})
+	web.arena.Database.CreateTeam(&model.Team{Id: 1114, Nickname: "Simbots"})
+	web.arena.Database.CreateRanking(game.TestRanking1())
+	web.arena.Database.CreateRanking(game.TestRanking2())
+	match := model.Match{Type: model.Qualification, ShortName: "Q7", LongName: "Qualification 7", Red2: 254, Blue3: 1114}
+	web.arena.Database.CreateMatch(&match)
+	assert.Nil(t, web.arena.LoadMatch(&match))
+	web.arena.BlueRealtimeScore.CurrentScore.Mayhem.LeaveStatuses = [3]bool{true, false, true}
+
+	recorder := web.getHttpResponse("/api/broadcast/json?rankings=3")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header()["Content-Type"][0])
+	assert.Equal(t, "no-cache", recorder.Header()["Cache-Control"][0])
+	var feed map[string]any
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &feed))
+	assert.Equal(t, "Qualification", feed["MatchType"])
+	assert.Equal(t, "Q7", feed["MatchShortName"])
+	assert.Equal(t, "PRE_MATCH", feed["MatchState"])
+	assert.Equal(t, "0:15", feed["Timer"])
+	assert.Equal(t, 0.0, feed["Red1Team"])
+	assert.Equal(t, 254.0, feed["Red2Team"])
+	assert.Equal(t, "ChezyPof", feed["Red2Nickname"])
+	assert.Equal(t, 1.0, feed["Red2Rank"])
+	assert.Equal(t, "Simbots", feed["Blue3Nickname"])
+	assert.Equal(t, 0.0, feed["RedScore"])
+	assert.Equal(t, float64(web.arena.BlueScoreSummary().Score), feed["BlueScore"])
+	assert.Equal(t, "", feed["SeriesStatus"])
+	assert.Equal(t, 254.0, feed["Rank1Team"])
+	assert.Equal(t, "Simbots", feed["Rank2Nickname"])
+	assert.Equal(t, 0.0, feed["Rank3Team"])
+	assert.NotContains(t, feed, "Rank4Team")
+
+	// The XML and CSV formats should contain the same fields in the same order.
+	recorder = web.getHttpResponse("/api/broadcast/xml?rankings=3")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "application/xml", recorder.Header()["Content-Type"][0])
+	assert.Contains(t, recorder.Body.String(), "<broadcast>\n  <MatchType>Qualification</MatchType>\n")
+	assert.Contains(t, recorder.Body.String(), "<Red2Nickname>ChezyPof</Red2Nickname>")
+	recorder = web.getHttpResponse("/api/broadcast/csv?rankings=3")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "text/csv", recorder.Header()["Content-Type"][0])
+	rows := strings.Split(strings.TrimSpace(recorder.Body.String()), "\n")
+	if assert.Equal(t, 2, len(rows)) {
+		names := strings.Split(rows[0], ",")
+		values := strings.Split(rows[1], ",")
+		assert.Equal(t, len(feed), len(names))
+		assert.Equal(t, []string{"MatchType", "MatchShortName"}, names[0:2])
+		assert.Equal(t, []string{"Qualification", "Q7"}, values[0:2])
+	}
+
+	recorder = web.getHttpResponse("/api/broadcast/yaml")
+	assert.Equal(t, 404, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Invalid broadcast feed format")
+	recorder = web.getHttpResponse("/api/broadcast/json?rankings=-1")
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Invalid number of rankings")
+}
@@ -0,0 +1,352 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web API for providing a flat, pollable data feed for broadcast graphics systems such as vMix and H2R Graphics.
+
+package web
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/Team254/cheesy-arena/field"
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/playoff"
+)
+
+const defaultBroadcastNumRankings = 10
+
+// Names of the match states as used in the JavaScript client code.
+var matchStateNames = map[field.MatchState]string{
+	field.PreMatch:      "PRE_MATCH",
+	field.StartMatch:    "START_MATCH",
+	field.WarmupPeriod:  "WARMUP_PERIOD",
+	field.AutoPeriod:    "AUTO_PERIOD",
+	field.PausePeriod:   "PAUSE_PERIOD",
+	field.TeleopPeriod:  "TELEOP_PERIOD",
+	field.PostMatch:     "POST_MATCH",
+	field.TimeoutActive: "TIMEOUT_ACTIVE",
+	field.PostTimeout:   "POST_TIMEOUT",
+}
+
+// A single named value in the broadcast feed.
+type broadcastField struct {
+	Name  string
+	Value any
+}
+
+// Subsets of the arena notifier messages that are needed to build the feed.
+type broadcastMatchLoadMessage struct {
+	Match    model.Match
+	Teams    map[string]*model.Team
+	Rankings map[string]int
+	Matchup  *struct {
+		RedAllianceId    int
+		BlueAllianceId   int
+		RedAllianceWins  int
+		BlueAllianceWins int
+	}
+}
+
+type broadcastRealtimeScoreMessage struct {
+	Red        struct{ ScoreSummary *game.ScoreSummary }
+	Blue       struct{ ScoreSummary *game.ScoreSummary }
+	RedCards   map[string]string
+	BlueCards  map[string]string
+	MatchState field.MatchState
+}
+
+type broadcastScorePostedMessage struct {
+	Match             *model.Match
+	RedScoreSummary   *game.ScoreSummary
+	BlueScoreSummary  *game.ScoreSummary
+	RedRankingPoints  int
+	BlueRankingPoints int
+	RedWon            bool
+	BlueWon           bool
+	RedWins           int
+	BlueWins          int
+}
+
+// Serves the broadcast data feed in the format given in the path; one of "json", "xml" or "csv". The number of
+// rankings to include can be set using the "rankings" query parameter.
+func (web *Web) broadcastApiHandler(w http.ResponseWriter, r *http.Request) {
+	numRankings := defaultBroadcastNumRankings
+	if numRankingsValue := r.URL.Query().Get("rankings"); numRankingsValue != "" {
+		var err error
+		if numRankings, err = strconv.Atoi(numRankingsValue); err != nil || numRankings < 0 {
+			http.Error(w, fmt.Sprintf("Error: Invalid number of rankings: %s", numRankingsValue), 400)
+			return
+		}
+	}
+
+	fields, err := web.generateBroadcastFields(numRankings)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	var body []byte
+	switch r.PathValue("format") {
+	case "json":
+		w.Header().Set("Content-Type", "application/json")
+		body, err = encodeBroadcastJson(fields)
+	case "xml":
+		w.Header().Set("Content-Type", "application/xml")
+		body, err = encodeBroadcastXml(fields)
+	case "csv":
+		w.Header().Set("Content-Type", "text/csv")
+		body, err = encodeBroadcastCsv(fields)
+	default:
+		http.Error(w, fmt.Sprintf("Error: Invalid broadcast feed format: %s", r.PathValue("format")), 404)
+		return
+	}
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	// Graphics systems typically poll the feed, so make sure they always get the current data.
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	if _, err = w.Write(body); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Builds the ordered list of feed fields from the same messages that are sent to the audience display.
+func (web *Web) generateBroadcastFields(numRankings int) ([]broadcastField, error) {
+	var matchLoad broadcastMatchLoadMessage
+	if err := convertArenaMessage(web.arena.GenerateMatchLoadMessage(), &matchLoad); err != nil {
+		return nil, err
+	}
+	var realtimeScore broadcastRealtimeScoreMessage
+	if err := convertArenaMessage(web.arena.GenerateRealtimeScoreMessage(), &realtimeScore); err != nil {
+		return nil, err
+	}
+	var scorePosted broadcastScorePostedMessage
+	if err := convertArenaMessage(web.arena.GenerateScorePostedMessage(), &scorePosted); err != nil {
+		return nil, err
+	}
+
+	teams, err := web.arena.Database.GetAllTeams()
+	if err != nil {
+		return nil, err
+	}
+	teamNicknames := make(map[int]string)
+	for _, team := range teams {
+		teamNicknames[team.Id] = team.Nickname
+	}
+
+	// Current match and timer.
+	fields := []broadcastField{
+		{"MatchType", matchLoad.Match.Type.String()},
+		{"MatchShortName", matchLoad.Match.ShortName},
+		{"MatchLongName", matchLoad.Match.LongName},
+		{"MatchNameDetail", matchLoad.Match.NameDetail},
+		{"MatchState", matchStateNames[realtimeScore.MatchState]},
+		{"MatchTimeSec", int(web.arena.MatchTimeSec())},
+		{"Timer", field.GenerateTimerText(web.arena)},
+	}
+
+	// Teams in the current match.
+	for _, alliance := range []string{"Red", "Blue"} {
+		for i := 1; i <= 3; i++ {
+			prefix := fmt.Sprintf("%s%d", alliance, i)
+			team := matchLoad.Teams[fmt.Sprintf("%c%d", alliance[0], i)]
+			var teamId, rank int
+			var nickname string
+			if team != nil {
+				teamId = team.Id
+				nickname = team.Nickname
+				rank = matchLoad.Rankings[strconv.Itoa(team.Id)]
+			}
+			fields = append(
+				fields,
+				broadcastField{prefix + "Team", teamId},
+				broadcastField{prefix + "Nickname", nickname},
+				broadcastField{prefix + "Rank", rank},
+			)
+		}
+	}
+
+	// Live score breakdowns.
+	fields = appendScoreSummaryFields(fields, "Red", realtimeScore.Red.ScoreSummary)
+	fields = appendScoreSummaryFields(fields, "Blue", realtimeScore.Blue.ScoreSummary)
+	fields = append(
+		fields,
+		broadcastField{"RedCards", len(realtimeScore.RedCards)},
+		broadcastField{"BlueCards", len(realtimeScore.BlueCards)},
+	)
+
+	// Playoff series status for the current match.
+	var redAllianceId, blueAllianceId, redSeriesWins, blueSeriesWins int
+	var seriesLeader, seriesStatus string
+	if matchLoad.Matchup != nil {
+		redAllianceId = matchLoad.Matchup.RedAllianceId
+		blueAllianceId = matchLoad.Matchup.BlueAllianceId
+		redSeriesWins = matchLoad.Matchup.RedAllianceWins
+		blueSeriesWins = matchLoad.Matchup.BlueAllianceWins
+		matchGroup := web.arena.PlayoffTournament.MatchGroups()[matchLoad.Match.PlayoffMatchGroupId]
+		if matchup, ok := matchGroup.(*playoff.Matchup); ok {
+			seriesLeader, seriesStatus = matchup.StatusText()
+		}
+	}
+	fields = append(
+		fields,
+		broadcastField{"RedAllianceId", redAllianceId},
+		broadcastField{"BlueAllianceId", blueAllianceId},
+		broadcastField{"RedSeriesWins", redSeriesWins},
+		broadcastField{"BlueSeriesWins", blueSeriesWins},
+		broadcastField{"SeriesLeader", seriesLeader},
+		broadcastField{"SeriesStatus", seriesStatus},
+	)
+
+	// Result of the most recently posted match.
+	var lastMatchShortName, lastMatchWinner string
+	if scorePosted.Match != nil {
+		lastMatchShortName = scorePosted.Match.ShortName
+		if scorePosted.Match.IsComplete() {
+			lastMatchWinner = "Tie"
+			if scorePosted.RedWon {
+				lastMatchWinner = "Red"
+			} else if scorePosted.BlueWon {
+				lastMatchWinner = "Blue"
+			}
+		}
+	}
+	fields = append(fields, broadcastField{"LastMatchShortName", lastMatchShortName})
+	fields = appendScoreSummaryFields(fields, "LastRed", scorePosted.RedScoreSummary)
+	fields = appendScoreSummaryFields(fields, "LastBlue", scorePosted.BlueScoreSummary)
+	fields = append(
+		fields,
+		broadcastField{"LastRedRankingPoints", scorePosted.RedRankingPoints},
+		broadcastField{"LastBlueRankingPoints", scorePosted.BlueRankingPoints},
+		broadcastField{"LastRedSeriesWins", scorePosted.RedWins},
+		broadcastField{"LastBlueSeriesWins", scorePosted.BlueWins},
+		broadcastField{"LastMatchWinner", lastMatchWinner},
+	)
+
+	// Rankings, padded out to the requested number so that the set of fields doesn't change as the event progresses.
+	rankings, err := web.arena.Database.GetAllRankings()
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < numRankings; i++ {
+		var ranking game.Ranking
+		if i < len(rankings) {
+			ranking = rankings[i]
+		}
+		prefix := fmt.Sprintf("Rank%d", i+1)
+		var record string
+		if ranking.TeamId > 0 {
+			record = fmt.Sprintf("%d-%d-%d", ranking.Wins, ranking.Losses, ranking.Ties)
+		}
+		fields = append(
+			fields,
+			broadcastField{prefix + "Team", ranking.TeamId},
+			broadcastField{prefix + "Nickname", teamNicknames[ranking.TeamId]},
+			broadcastField{prefix + "RankingPoints", ranking.RankingPoints},
+			broadcastField{prefix + "Record", record},
+			broadcastField{prefix + "Played", ranking.Played},
+		)
+	}
+
+	return fields, nil
+}
+
+// Converts the given arena notifier message into the given struct via its JSON representation, so that the feed
+// contains exactly what the websocket displays receive.
+func convertArenaMessage(message any, target any) error {
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(jsonData, target)
+}
+
+// Appends a field for each value in the given score summary, prefixed with the given string. The fields are listed
+// explicitly so that the feed's field names don't change when the score summary is reworked for a new game.
+func appendScoreSummaryFields(
+	fields []broadcastField, prefix string, scoreSummary *game.ScoreSummary,
+) []broadcastField {
+	if scoreSummary == nil {
+		scoreSummary = new(game.ScoreSummary)
+	}
+	return append(
+		fields,
+		broadcastField{prefix + "LeavePoints", scoreSummary.LeavePoints},
+		broadcastField{prefix + "AutoPoints", scoreSummary.AutoPoints},
+		broadcastField{prefix + "NumGamepiece1", scoreSummary.NumGamepiece1},
+		broadcastField{prefix + "Gamepiece1Points", scoreSummary.Gamepiece1Points},
+		broadcastField{prefix + "NumGamepiece2", scoreSummary.NumGamepiece2},
+		broadcastField{prefix + "Gamepiece2Points", scoreSummary.Gamepiece2Points},
+		broadcastField{prefix + "ParkPoints", scoreSummary.ParkPoints},
+		broadcastField{prefix + "MatchPoints", scoreSummary.MatchPoints},
+		broadcastField{prefix + "FoulPoints", scoreSummary.FoulPoints},
+		broadcastField{prefix + "Score", scoreSummary.Score},
+		broadcastField{prefix + "LeaveBonusRankingPoint", scoreSummary.LeaveBonusRankingPoint},
+		broadcastField{prefix + "Gamepiece1BonusRankingPoint", scoreSummary.Gamepiece1BonusRankingPoint},
+		broadcastField{prefix + "ParkBonusRankingPoint", scoreSummary.ParkBonusRankingPoint},
+		broadcastField{prefix + "BonusRankingPoints", scoreSummary.BonusRankingPoints},
+		broadcastField{prefix + "NumOpponentMajorFouls", scoreSummary.NumOpponentMajorFouls},
+	)
+}
+
+// Encodes the fields as a single flat JSON object, preserving their order.
+func encodeBroadcastJson(fields []broadcastField) ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString("{\n")
+	for i, feedField := range fields {
+		value, err := json.Marshal(feedField.Value)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&buffer, "  %q: %s", feedField.Name, value)
+		if i < len(fields)-1 {
+			buffer.WriteString(",")
+		}
+		buffer.WriteString("\n")
+	}
+	buffer.WriteString("}\n")
+	return buffer.Bytes(), nil
+}
+
+// Encodes the fields as child elements of a single root element.
+func encodeBroadcastXml(fields []broadcastField) ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(xml.Header)
+	buffer.WriteString("<broadcast>\n")
+	for _, feedField := range fields {
+		fmt.Fprintf(&buffer, "  <%s>", feedField.Name)
+		if err := xml.EscapeText(&buffer, []byte(fmt.Sprint(feedField.Value))); err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&buffer, "</%s>\n", feedField.Name)
+	}
+	buffer.WriteString("</broadcast>\n")
+	return buffer.Bytes(), nil
+}
+
+// Encodes the fields as a header row of field names followed by a single row of values.
+func encodeBroadcastCsv(fields []broadcastField) ([]byte, error) {
+	names := make([]string, len(fields))
+	values := make([]string, len(fields))
+	for i, feedField := range fields {
+		names[i] = feedField.Name
+		values[i] = fmt.Sprint(feedField.Value)
+	}
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	if err := writer.WriteAll([][]string{names, values}); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,165 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web API for controlling the match flow from external button panels such as Stream Deck via Bitfocus Companion.
+
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/Team254/cheesy-arena/field"
+)
+
+// Alliance station display modes that can be selected from the match play page.
+var controlAllianceStationDisplayModes = []string{"blank", "match", "logo", "timeout", "fieldReset", "signalCount"}
+
+// Snapshot of the match flow state, used by button panels to show feedback on which actions are available.
+type controlStatus struct {
+	MatchId                    int
+	MatchShortName             string
+	MatchState                 field.MatchState
+	MatchStateName             string
+	Timer                      string
+	RedScore                   int
+	BlueScore                  int
+	CanStartMatch              bool
+	CanAbortMatch              bool
+	CanCommitResults           bool
+	CanDiscardResults          bool
+	CanStartTimeout            bool
+	ScoresCommitted            bool
+	AudienceDisplayMode        string
+	AllianceStationDisplayMode string
+}
+
+// Returns the current match flow state as JSON.
+func (web *Web) controlStatusApiHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.apiUserIsAdmin(w, r) {
+		return
+	}
+
+	web.writeControlStatus(w)
+}
+
+// Performs the match flow action given in the path, subject to the same state checks as the match play page, and
+// returns the resulting match flow state as JSON.
+func (web *Web) controlActionApiHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.apiUserIsAdmin(w, r) {
+		return
+	}
+
+	var err error
+	switch action := r.PathValue("action"); action {
+	case "start_match":
+		web.arena.MuteMatchSounds = r.FormValue("muteMatchSounds") == "true"
+		err = web.arena.StartMatch()
+	case "abort_match":
+		err = web.arena.AbortMatch()
+	case "commit_results":
+		err = web.commitResultsAndLoadNextMatch()
+	case "discard_results":
+		// The match play page only offers discarding once the match has ended, so as not to skip an unplayed match.
+		if web.arena.MatchState != field.PostMatch {
+			err = fmt.Errorf("cannot discard results unless the match has ended")
+			break
+		}
+		err = web.discardResultsAndLoadNextMatch()
+	case "show_score":
+		err = web.setControlAudienceDisplayMode("score")
+	case "start_timeout":
+		durationSec, parseErr := strconv.Atoi(r.FormValue("durationSec"))
+		if parseErr != nil || durationSec <= 0 {
+			err = fmt.Errorf("invalid timeout duration '%s'", r.FormValue("durationSec"))
+			break
+		}
+		err = web.arena.StartTimeout("Timeout", durationSec)
+	case "audience_display":
+		err = web.setControlAudienceDisplayMode(r.FormValue("mode"))
+	case "alliance_station_display":
+		mode := r.FormValue("mode")
+		switch {
+		case !slices.Contains(controlAllianceStationDisplayModes, mode):
+			err = fmt.Errorf("invalid alliance station display mode '%s'", mode)
+		case mode == "fieldReset":
+			err = web.signalReset()
+		case mode == "signalCount":
+			err = web.signalVolunteers()
+		default:
+			web.arena.SetAllianceStationDisplayMode(mode)
+		}
+	default:
+		http.Error(w, fmt.Sprintf("Error: Invalid control action '%s'.", action), 404)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Error: "+err.Error(), 400)
+		return
+	}
+
+	web.writeControlStatus(w)
+}
+
+// Sets the audience display mode, disallowing the intro and score screens in the same match states as the match play
+// page does.
+func (web *Web) setControlAudienceDisplayMode(mode string) error {
+	if !slices.Contains(field.AudienceDisplayModes, mode) {
+		return fmt.Errorf("invalid audience display mode '%s'", mode)
+	}
+	switch mode {
+	case "intro":
+		if web.arena.MatchState != field.PreMatch && web.arena.MatchState != field.PostTimeout {
+			return fmt.Errorf("cannot show the intro screen until the next match is loaded or the timeout has ended")
+		}
+	case "score":
+		if web.controlMatchInProgress() || web.arena.MatchState == field.PostMatch {
+			return fmt.Errorf("cannot show the score screen while the match is in progress or its results are pending")
+		}
+	}
+	web.arena.SetAudienceDisplayMode(mode)
+	return nil
+}
+
+// Returns true if the match is between being started and ending.
+func (web *Web) controlMatchInProgress() bool {
+	switch web.arena.MatchState {
+	case field.StartMatch, field.WarmupPeriod, field.AutoPeriod, field.PausePeriod, field.TeleopPeriod:
+		return true
+	}
+	return false
+}
+
+func (web *Web) writeControlStatus(w http.ResponseWriter) {
+	status := controlStatus{
+		MatchId:                    web.arena.CurrentMatch.Id,
+		MatchShortName:             web.arena.CurrentMatch.ShortName,
+		MatchState:                 web.arena.MatchState,
+		MatchStateName:             matchStateNames[web.arena.MatchState],
+		Timer:                      field.GenerateTimerText(web.arena),
+		RedScore:                   web.arena.RedScoreSummary().Score,
+		BlueScore:                  web.arena.BlueScoreSummary().Score,
+		CanStartMatch:              web.arena.CanStartMatch(),
+		CanAbortMatch:              web.controlMatchInProgress() || web.arena.MatchState == field.TimeoutActive,
+		CanCommitResults:           web.arena.MatchState == field.PostMatch,
+		CanDiscardResults:          web.arena.MatchState == field.PostMatch,
+		CanStartTimeout:            web.arena.MatchState == field.PreMatch,
+		ScoresCommitted:            web.arena.PostMatchScoreReady(),
+		AudienceDisplayMode:        web.arena.AudienceDisplayMode,
+		AllianceStationDisplayMode: web.arena.AllianceStationDisplayMode,
+	}
+	jsonData, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if _, err = w.Write(jsonData); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Team254/cheesy-arena/field"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestControlApiAuth(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.EventSettings.AdminPassword = "password"
+
+	recorder := web.getHttpResponse("/api/control/status")
+	assert.Equal(t, 401, recorder.Code)
+	assert.Contains(t, recorder.Header().Get("WWW-Authenticate"), "Basic")
+
+	recorder = httptest.NewRecorder()
+	request, _ := http.NewRequest("POST", "/api/control/abort_match", nil)
+	request.SetBasicAuth("admin", "wrong")
+	web.newHandler().ServeHTTP(recorder, request)
+	assert.Equal(t, 401, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	request, _ = http.NewRequest("GET", "/api/control/status", nil)
+	request.SetBasicAuth("admin", "password")
+	web.newHandler().ServeHTTP(recorder, request)
+	assert.Equal(t, 200, recorder.Code)
+}
+
+func TestControlApiMatchFlow(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/api/control/status")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header()["Content-Type"][0])
+	status := readControlStatus(t, recorder)
+	assert.Equal(t, "PRE_MATCH", status.MatchStateName)
+	assert.False(t, status.CanStartMatch)
+	assert.False(t, status.CanAbortMatch)
+	assert.False(t, status.CanDiscardResults)
+	assert.True(t, status.CanStartTimeout)
+
+	// Discarding results before the match has been played would skip it.
+	recorder = web.postHttpResponse("/api/control/discard_results", "")
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "cannot discard results unless the match has ended")
+	assert.Equal(t, field.PreMatch, web.arena.MatchState)
+
+	recorder = web.postHttpResponse("/api/control/start_match", "")
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "cannot start match")
+	for _, allianceStation := range web.arena.AllianceStations {
+		allianceStation.Bypass = true
+	}
+	assert.True(t, readControlStatus(t, web.getHttpResponse("/api/control/status")).CanStartMatch)
+	recorder = web.postHttpResponse("/api/control/start_match", "muteMatchSounds=true")
+	assert.Equal(t, 200, recorder.Code)
+	status = readControlStatus(t, recorder)
+	assert.Equal(t, field.StartMatch, status.MatchState)
+	assert.True(t, status.CanAbortMatch)
+	assert.True(t, web.arena.MuteMatchSounds)
+
+	// Actions that aren't allowed while the match is running should be rejected.
+	recorder = web.postHttpResponse("/api/control/commit_results", "")
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "cannot commit match while it is in progress")
+	recorder = web.postHttpResponse("/api/control/discard_results", "")
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "cannot discard results unless the match has ended")
+	recorder = web.postHttpResponse("/api/control/show_score", "")
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "cannot show the score screen")
+	recorder = web.postHttpResponse("/api/control/alliance_station_display", "mode=fieldReset")
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "cannot signal a field reset")
+	recorder = web.postHttpResponse("/api/control/start_timeout", "durationSec=60")
+	assert.Equal(t, 400, recorder.Code)
+
+	recorder = web.postHttpResponse("/api/control/abort_match", "")
+	assert.Equal(t, 200, recorder.Code)
+	status = readControlStatus(t, recorder)
+	assert.Equal(t, "POST_MATCH", status.MatchStateName)
+	assert.True(t, status.CanCommitResults)
+	assert.True(t, status.CanDiscardResults)
+	assert.False(t, status.ScoresCommitted)
+	recorder = web.postHttpResponse("/api/control/audience_display", "mode=intro")
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "cannot show the intro screen")
+	recorder = web.postHttpResponse("/api/control/alliance_station_display", "mode=signalCount")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "signalCount", readControlStatus(t, recorder).AllianceStationDisplayMode)
+	assert.True(t, web.arena.FieldVolunteers)
+	assert.False(t, web.arena.FieldReset)
+	recorder = web.postHttpResponse("/api/control/alliance_station_display", "mode=fieldReset")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "fieldReset", readControlStatus(t, recorder).AllianceStationDisplayMode)
+	assert.False(t, web.arena.FieldVolunteers)
+	assert.True(t, web.arena.FieldReset)
+	recorder = web.postHttpResponse("/api/control/commit_results", "")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, field.PreMatch, readControlStatus(t, recorder).MatchState)
+	recorder = web.postHttpResponse("/api/control/show_score", "")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "score", readControlStatus(t, recorder).AudienceDisplayMode)
+
+	// Test timeouts and display modes.
+	recorder = web.postHttpResponse("/api/control/start_timeout", "durationSec=abc")
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "invalid timeout duration")
+	recorder = web.postHttpResponse("/api/control/start_timeout", "durationSec=60")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "TIMEOUT_ACTIVE", readControlStatus(t, recorder).MatchStateName)
+	recorder = web.postHttpResponse("/api/control/audience_display", "mode=intro")
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "cannot show the intro screen")
+	recorder = web.postHttpResponse("/api/control/alliance_station_display", "mode=signalCount")
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "cannot signal volunteers")
+	recorder = web.postHttpResponse("/api/control/audience_display", "mode=nonexistent")
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "invalid audience display mode")
+	recorder = web.postHttpResponse("/api/control/audience_display", "mode=logo")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "logo", web.arena.AudienceDisplayMode)
+	recorder = web.postHttpResponse("/api/control/alliance_station_display", "mode=timeout")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "timeout", web.arena.AllianceStationDisplayMode)
+
+	recorder = web.postHttpResponse("/api/control/self_destruct", "")
+	assert.Equal(t, 404, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Invalid control action")
+}
+
+func readControlStatus(t *testing.T, recorder *httptest.ResponseRecorder) controlStatus {
+	var status controlStatus
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &status))
+	return status
+}
@@ -0,0 +1,394 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web handler for exporting the event's results as a zip of FMS-style data files.
+
+package web
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+)
+
+var fmsExportAwardTypeNames = map[model.AwardType]string{
+	model.JudgedAward:   "Judged",
+	model.FinalistAward: "Finalist",
+	model.WinnerAward:   "Winner",
+}
+
+var fmsExportFilenameUnsafeCharacters = regexp.MustCompile("[^A-Za-z0-9_-]+")
+
+// Full result of a single played match, including the detailed per-alliance score breakdowns.
+type fmsExportMatchResult struct {
+	MatchType   string
+	ShortName   string
+	TbaMatchKey string
+	PlayNumber  int
+	RedTeams    [3]int
+	BlueTeams   [3]int
+	RedScore    *game.Score
+	BlueScore   *game.Score
+	RedSummary  *game.ScoreSummary
+	BlueSummary *game.ScoreSummary
+	RedCards    map[string]string
+	BlueCards   map[string]string
+	Winner      string
+	CommittedAt time.Time
+}
+
+// Generates a zip file containing the teams, schedule, match results, rankings, alliances and awards for the event.
+func (web *Web) fmsExportReportHandler(w http.ResponseWriter, r *http.Request) {
+	filename := fmsExportFilenameUnsafeCharacters.ReplaceAllString(web.arena.EventSettings.TbaEventCode, "_")
+	if filename == "" {
+		filename = fmsExportFilenameUnsafeCharacters.ReplaceAllString(web.arena.EventSettings.Name, "_")
+	}
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s_fms_export.zip\"", filename))
+	if err := generateFmsExport(web.arena.Database, web.arena.EventSettings, w); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Writes the FMS-style export of all the event data in the given database to the given writer as a zip file.
+func generateFmsExport(database *model.Database, eventSettings *model.EventSettings, writer io.Writer) error {
+	teams, err := database.GetAllTeams()
+	if err != nil {
+		return err
+	}
+	var matches []model.Match
+	for _, matchType := range []model.MatchType{model.Practice, model.Qualification, model.Playoff} {
+		matchesOfType, err := database.GetMatchesByType(matchType, false)
+		if err != nil {
+			return err
+		}
+		matches = append(matches, matchesOfType...)
+	}
+	matchResults, err := getFmsExportMatchResults(database, matches)
+	if err != nil {
+		return err
+	}
+	rankings, err := database.GetAllRankings()
+	if err != nil {
+		return err
+	}
+	alliances, err := database.GetAllAlliances()
+	if err != nil {
+		return err
+	}
+	awards, err := database.GetAllAwards()
+	if err != nil {
+		return err
+	}
+
+	zipWriter := zip.NewWriter(writer)
+	files := []struct {
+		name     string
+		generate func(io.Writer) error
+	}{
+		{"event.csv", func(w io.Writer) error { return writeFmsEventCsv(w, eventSettings) }},
+		{"teams.csv", func(w io.Writer) error { return writeFmsTeamsCsv(w, teams) }},
+		{"schedule.csv", func(w io.Writer) error { return writeFmsScheduleCsv(w, matches) }},
+		{"match_results.csv", func(w io.Writer) error { return writeFmsMatchResultsCsv(w, matchResults) }},
+		{"score_breakdowns.json", func(w io.Writer) error { return writeFmsScoreBreakdownsJson(w, matchResults) }},
+		{"rankings.csv", func(w io.Writer) error { return writeFmsRankingsCsv(w, rankings) }},
+		{"alliances.csv", func(w io.Writer) error { return writeFmsAlliancesCsv(w, alliances) }},
+		{"awards.csv", func(w io.Writer) error { return writeFmsAwardsCsv(w, awards) }},
+	}
+	for _, file := range files {
+		fileWriter, err := zipWriter.Create(file.name)
+		if err != nil {
+			return err
+		}
+		if err = file.generate(fileWriter); err != nil {
+			return fmt.Errorf("failed to generate %s: %v", file.name, err)
+		}
+	}
+	return zipWriter.Close()
+}
+
+// Returns the results of all the given matches that have been played, in schedule order.
+func getFmsExportMatchResults(database *model.Database, matches []model.Match) ([]fmsExportMatchResult, error) {
+	var matchResults []fmsExportMatchResult
+	for _, match := range matches {
+		matchResult, err := database.GetMatchResultForMatch(match.Id)
+		if err != nil {
+			return nil, err
+		}
+		if matchResult == nil {
+			continue
+		}
+		matchResults = append(
+			matchResults,
+			fmsExportMatchResult{
+				MatchType:   match.Type.String(),
+				ShortName:   match.ShortName,
+				TbaMatchKey: match.TbaMatchKey.String(),
+				PlayNumber:  matchResult.PlayNumber,
+				RedTeams:    [3]int{match.Red1, match.Red2, match.Red3},
+				BlueTeams:   [3]int{match.Blue1, match.Blue2, match.Blue3},
+				RedScore:    matchResult.RedScore,
+				BlueScore:   matchResult.BlueScore,
+				RedSummary:  matchResult.RedScoreSummary(),
+				BlueSummary: matchResult.BlueScoreSummary(),
+				RedCards:    matchResult.RedCards,
+				BlueCards:   matchResult.BlueCards,
+				Winner:      fmsExportWinner(match.Status),
+				CommittedAt: match.ScoreCommittedAt,
+			},
+		)
+	}
+	return matchResults, nil
+}
+
+func writeFmsEventCsv(w io.Writer, eventSettings *model.EventSettings) error {
+	playoffType := "DoubleElimination"
+	if eventSettings.PlayoffType == model.SingleEliminationPlayoff {
+		playoffType = "SingleElimination"
+	}
+	return writeFmsCsv(
+		w,
+		[]string{"Name", "EventCode", "PlayoffType", "NumPlayoffAlliances", "ExportedAt"},
+		[][]string{
+			{
+				eventSettings.Name,
+				eventSettings.TbaEventCode,
+				playoffType,
+				strconv.Itoa(eventSettings.NumPlayoffAlliances),
+				time.Now().Format(time.RFC3339),
+			},
+		},
+	)
+}
+
+func writeFmsTeamsCsv(w io.Writer, teams []model.Team) error {
+	var rows [][]string
+	for _, team := range teams {
+		rows = append(
+			rows,
+			[]string{
+				strconv.Itoa(team.Id),
+				team.Name,
+				team.Nickname,
+				team.SchoolName,
+				team.City,
+				team.StateProv,
+				team.Country,
+				strconv.Itoa(team.RookieYear),
+				team.RobotName,
+			},
+		)
+	}
+	return writeFmsCsv(
+		w,
+		[]string{
+			"TeamNumber", "Name", "Nickname", "SchoolName", "City", "StateProv", "Country", "RookieYear", "RobotName",
+		},
+		rows,
+	)
+}
+
+func writeFmsScheduleCsv(w io.Writer, matches []model.Match) error {
+	var rows [][]string
+	for _, match := range matches {
+		rows = append(
+			rows,
+			[]string{
+				match.Type.String(),
+				strconv.Itoa(match.TypeOrder),
+				match.ShortName,
+				match.LongName,
+				match.TbaMatchKey.String(),
+				match.Time.Format(time.RFC3339),
+				strconv.Itoa(match.Red1),
+				strconv.FormatBool(match.Red1IsSurrogate),
+				strconv.Itoa(match.Red2),
+				strconv.FormatBool(match.Red2IsSurrogate),
+				strconv.Itoa(match.Red3),
+				strconv.FormatBool(match.Red3IsSurrogate),
+				strconv.Itoa(match.Blue1),
+				strconv.FormatBool(match.Blue1IsSurrogate),
+				strconv.Itoa(match.Blue2),
+				strconv.FormatBool(match.Blue2IsSurrogate),
+				strconv.Itoa(match.Blue3),
+				strconv.FormatBool(match.Blue3IsSurrogate),
+			},
+		)
+	}
+	return writeFmsCsv(
+		w,
+		[]string{
+			"MatchType", "MatchNumber", "ShortName", "Description", "TbaMatchKey", "ScheduledTime", "Red1",
+			"Red1IsSurrogate", "Red2", "Red2IsSurrogate", "Red3", "Red3IsSurrogate", "Blue1", "Blue1IsSurrogate", "Blue2",
+			"Blue2IsSurrogate", "Blue3", "Blue3IsSurrogate",
+		},
+		rows,
+	)
+}
+
+// Writes one row per played match, with a column for each field of each alliance's score summary.
+func writeFmsMatchResultsCsv(w io.Writer, matchResults []fmsExportMatchResult) error {
+	summaryFields := reflect.VisibleFields(reflect.TypeOf(game.ScoreSummary{}))
+	header := []string{"MatchType", "ShortName", "PlayNumber", "Winner"}
+	for _, alliance := range []string{"Red", "Blue"} {
+		for _, field := range summaryFields {
+			header = append(header, alliance+field.Name)
+		}
+		header = append(header, alliance+"Cards", alliance+"PlayoffDq")
+	}
+
+	var rows [][]string
+	for _, matchResult := range matchResults {
+		row := []string{
+			matchResult.MatchType,
+			matchResult.ShortName,
+			strconv.Itoa(matchResult.PlayNumber),
+			matchResult.Winner,
+		}
+		for _, alliance := range []struct {
+			summary *game.ScoreSummary
+			score   *game.Score
+			cards   map[string]string
+		}{
+			{matchResult.RedSummary, matchResult.RedScore, matchResult.RedCards},
+			{matchResult.BlueSummary, matchResult.BlueScore, matchResult.BlueCards},
+		} {
+			summaryValue := reflect.ValueOf(*alliance.summary)
+			for _, field := range summaryFields {
+				row = append(row, fmt.Sprint(summaryValue.FieldByIndex(field.Index).Interface()))
+			}
+			row = append(row, fmsExportCards(alliance.cards), strconv.FormatBool(alliance.score.PlayoffDq))
+		}
+		rows = append(rows, row)
+	}
+	return writeFmsCsv(w, header, rows)
+}
+
+func writeFmsScoreBreakdownsJson(w io.Writer, matchResults []fmsExportMatchResult) error {
+	if matchResults == nil {
+		// Go marshals an empty slice to null, so explicitly create it so that it appears as an empty JSON array.
+		matchResults = make([]fmsExportMatchResult, 0)
+	}
+	jsonData, err := json.MarshalIndent(matchResults, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(jsonData)
+	return err
+}
+
+// Writes the rankings with a column for each of the game-specific tiebreaker fields.
+func writeFmsRankingsCsv(w io.Writer, rankings game.Rankings) error {
+	var rankingFields []reflect.StructField
+	for _, field := range reflect.VisibleFields(reflect.TypeOf(game.RankingFields{})) {
+		// The random tiebreaker is an internal detail that isn't meaningful outside of this system.
+		if field.Name != "Random" {
+			rankingFields = append(rankingFields, field)
+		}
+	}
+	header := []string{"Rank", "TeamNumber", "RankingScore"}
+	for _, field := range rankingFields {
+		header = append(header, field.Name)
+	}
+
+	var rows [][]string
+	for _, ranking := range rankings {
+		rankingScore := 0.0
+		if ranking.Played > 0 {
+			rankingScore = float64(ranking.RankingPoints) / float64(ranking.Played)
+		}
+		row := []string{strconv.Itoa(ranking.Rank), strconv.Itoa(ranking.TeamId), fmt.Sprintf("%.2f", rankingScore)}
+		fieldsValue := reflect.ValueOf(ranking.RankingFields)
+		for _, field := range rankingFields {
+			row = append(row, fmt.Sprint(fieldsValue.FieldByIndex(field.Index).Interface()))
+		}
+		rows = append(rows, row)
+	}
+	return writeFmsCsv(w, header, rows)
+}
+
+// Writes one row per alliance, with the captain followed by the picks in the order they were selected.
+func writeFmsAlliancesCsv(w io.Writer, alliances []model.Alliance) error {
+	maxTeams := 0
+	for _, alliance := range alliances {
+		maxTeams = max(maxTeams, len(alliance.TeamIds))
+	}
+	header := []string{"Alliance", "Captain"}
+	for i := 1; i < maxTeams; i++ {
+		header = append(header, fmt.Sprintf("Pick%d", i))
+	}
+
+	var rows [][]string
+	for _, alliance := range alliances {
+		row := []string{strconv.Itoa(alliance.Id)}
+		for i := 0; i < max(maxTeams, 1); i++ {
+			teamId := ""
+			if i < len(alliance.TeamIds) && alliance.TeamIds[i] > 0 {
+				teamId = strconv.Itoa(alliance.TeamIds[i])
+			}
+			row = append(row, teamId)
+		}
+		rows = append(rows, row)
+	}
+	return writeFmsCsv(w, header, rows)
+}
+
+func writeFmsAwardsCsv(w io.Writer, awards []model.Award) error {
+	sort.SliceStable(awards, func(i, j int) bool { return awards[i].Type < awards[j].Type })
+	var rows [][]string
+	for _, award := range awards {
+		teamId := ""
+		if award.TeamId > 0 {
+			teamId = strconv.Itoa(award.TeamId)
+		}
+		rows = append(rows, []string{fmsExportAwardTypeNames[award.Type], award.AwardName, teamId, award.PersonName})
+	}
+	return writeFmsCsv(w, []string{"Type", "AwardName", "TeamNumber", "PersonName"}, rows)
+}
+
+func writeFmsCsv(w io.Writer, header []string, rows [][]string) error {
+	csvWriter := csv.NewWriter(w)
+	if err := csvWriter.Write(header); err != nil {
+		return err
+	}
+	return csvWriter.WriteAll(rows)
+}
+
+func fmsExportWinner(status game.MatchStatus) string {
+	switch status {
+	case game.RedWonMatch:
+		return "Red"
+	case game.BlueWonMatch:
+		return "Blue"
+	case game.TieMatch:
+		return "Tie"
+	}
+	return ""
+}
+
+// Returns the given cards as a space-separated list of team:card pairs in team order.
+func fmsExportCards(cards map[string]string) string {
+	var teamIds []string
+	for teamId := range cards {
+		teamIds = append(teamIds, teamId)
+	}
+	sort.Strings(teamIds)
+	var pairs []string
+	for _, teamId := range teamIds {
+		pairs = append(pairs, teamId+":"+cards[teamId])
+	}
+	return strings.Join(pairs, " ")
+}
@@ -0,0 +1,166 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFmsExportReport(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.EventSettings.TbaEventCode = "2026cc"
+
+	web.arena.Database.CreateTeam(&model.Team{Id: 254, Nickname: "The Cheesy Poofs", City: "San Jose"})
+	web.arena.Database.CreateTeam(&model.Team{Id: 1114, Nickname: "Simbots"})
+	match1 := model.Match{
+		Type:             model.Qualification,
+		TypeOrder:        1,
+		ShortName:        "Q1",
+		Time:             time.Unix(1000, 0).UTC(),
+		Red1:             254,
+		Red2:             1868,
+		Blue1:            1114,
+		Blue1IsSurrogate: true,
+		Status:           game.RedWonMatch,
+		TbaMatchKey:      model.TbaMatchKey{CompLevel: "qm", MatchNumber: 1},
+	}
+	web.arena.Database.CreateMatch(&match1)
+	web.arena.Database.CreateMatch(&model.Match{Type: model.Qualification, TypeOrder: 2, ShortName: "Q2"})
+	web.arena.Database.CreateMatchResult(model.BuildTestMatchResult(match1.Id, 1))
+	web.arena.Database.CreateRanking(game.TestRanking1())
+	model.BuildTestAlliances(web.arena.Database)
+	web.arena.Database.CreateAward(&model.Award{Type: model.WinnerAward, AwardName: "Winner", TeamId: 254})
+	web.arena.Database.CreateAward(&model.Award{Type: model.JudgedAward, AwardName: "Safety Award", TeamId: 1114})
+
+	recorder := web.getHttpResponse("/reports/zip/fms_export")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "application/zip", recorder.Header()["Content-Type"][0])
+	assert.Equal(
+		t, "attachment; filename=\"2026cc_fms_export.zip\"", recorder.Header()["Content-Disposition"][0],
+	)
+	files := readZipFiles(t, recorder.Body.Bytes())
+	var filenames []string
+	for filename := range files {
+		filenames = append(filenames, filename)
+	}
+	assert.ElementsMatch(
+		t,
+		[]string{
+			"event.csv", "teams.csv", "schedule.csv", "match_results.csv", "score_breakdowns.json", "rankings.csv",
+			"alliances.csv", "awards.csv",
+		},
+		filenames,
+	)
+
+	teams := readCsv(t, files["teams.csv"])
+	if assert.Equal(t, 3, len(teams)) {
+		assert.Equal(t, []string{"254", "", "The Cheesy Poofs", "", "San Jose", "", "", "0", ""}, teams[1])
+	}
+
+	schedule := readCsv(t, files["schedule.csv"])
+	if assert.Equal(t, 3, len(schedule)) {
+		assert.Equal(t, "Blue1IsSurrogate", schedule[0][13])
+		assert.Equal(
+			t,
+			[]string{"Qualification", "1", "Q1", "", "qm1", "1970-01-01T00:16:40Z", "254", "false", "1868"},
+			schedule[1][0:9],
+		)
+		assert.Equal(t, "true", schedule[1][13])
+	}
+
+	// Only played matches should have results.
+	matchResults := readCsv(t, files["match_results.csv"])
+	if assert.Equal(t, 2, len(matchResults)) {
+		result := map[string]string{}
+		for i, name := range matchResults[0] {
+			result[name] = matchResults[1][i]
+		}
+		matchResult := model.BuildTestMatchResult(match1.Id, 1)
+		assert.Equal(t, "Q1", result["ShortName"])
+		assert.Equal(t, "Red", result["Winner"])
+		assert.Equal(t, "1868:yellow", result["RedCards"])
+		assert.Equal(t, "", result["BlueCards"])
+		assert.Equal(t, "false", result["BluePlayoffDq"])
+		assert.Equal(t, strconv.Itoa(matchResult.RedScoreSummary().Score), result["RedScore"])
+		assert.Equal(t, strconv.Itoa(matchResult.BlueScoreSummary().AutoPoints), result["BlueAutoPoints"])
+	}
+	var breakdowns []fmsExportMatchResult
+	assert.Nil(t, json.Unmarshal(files["score_breakdowns.json"], &breakdowns))
+	if assert.Equal(t, 1, len(breakdowns)) {
+		assert.Equal(t, *game.TestScore1(), *breakdowns[0].RedScore)
+		assert.Equal(t, [3]int{1114, 0, 0}, breakdowns[0].BlueTeams)
+	}
+
+	assert.Equal(
+		t,
+		[][]string{
+			{
+				"Rank", "TeamNumber", "RankingScore", "RankingPoints", "MatchPoints", "AutoPoints", "Gamepiece2Points",
+				"Wins", "Losses", "Ties", "Disqualifications", "Played",
+			},
+			{"1", "254", "2.00", "20", "625", "90", "40", "3", "2", "1", "0", "10"},
+		},
+		readCsv(t, files["rankings.csv"]),
+	)
+	assert.Equal(
+		t,
+		[][]string{
+			{"Alliance", "Captain", "Pick1", "Pick2", "Pick3", "Pick4"},
+			{"1", "254", "469", "2848", "74", "3175"},
+			{"2", "1718", "2451", "1619", "", ""},
+		},
+		readCsv(t, files["alliances.csv"]),
+	)
+	assert.Equal(
+		t,
+		[][]string{
+			{"Type", "AwardName", "TeamNumber", "PersonName"},
+			{"Judged", "Safety Award", "1114", ""},
+			{"Winner", "Winner", "254", ""},
+		},
+		readCsv(t, files["awards.csv"]),
+	)
+}
+
+func TestFmsExportReportEmpty(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/reports/zip/fms_export")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Header()["Content-Disposition"][0], "Untitled_Event_fms_export.zip")
+	files := readZipFiles(t, recorder.Body.Bytes())
+	assert.Equal(t, "[]", string(files["score_breakdowns.json"]))
+	assert.Equal(t, 1, len(readCsv(t, files["alliances.csv"])))
+}
+
+func readZipFiles(t *testing.T, data []byte) map[string][]byte {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	assert.Nil(t, err)
+	files := make(map[string][]byte)
+	for _, file := range reader.File {
+		fileReader, err := file.Open()
+		assert.Nil(t, err)
+		files[file.Name], err = io.ReadAll(fileReader)
+		assert.Nil(t, err)
+		fileReader.Close()
+	}
+	return files
+}
+
+func readCsv(t *testing.T, data []byte) [][]string {
+	rows, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	assert.Nil(t, err)
+	return rows
+}
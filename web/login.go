@@ -77,6 +77,24 @@ func (web *Web) userIsAdmin(w http.ResponseWriter, r *http.Request) bool {
 	}
 }
 
+// Returns true if the given request is authorized for admin operations, using either the session cookie or HTTP basic
+// authentication. Used for API clients that can't follow the redirect to the login page.
+func (web *Web) apiUserIsAdmin(w http.ResponseWriter, r *http.Request) bool {
+	if web.arena.EventSettings.AdminPassword == "" {
+		// Disable auth if there is no password configured.
+		return true
+	}
+	if session := web.getUserSessionFromCookie(r); session != nil && session.Username == adminUser {
+		return true
+	}
+	if user, password, ok := r.BasicAuth(); ok && web.checkAuthPassword(user, password) == nil {
+		return true
+	}
+	w.Header().Set("WWW-Authenticate", "Basic realm=\"Cheesy Arena\"")
+	http.Error(w, "Error: Invalid login credentials.", 401)
+	return false
+}
+
 func (web *Web) getUserSessionFromCookie(r *http.Request) *model.UserSession {
 	token, err := r.Cookie(sessionTokenCookie)
 	if err != nil {
@@ -25,6 +25,7 @@ type MatchLogsListItem struct {
 	BlueTeams  []int
 	ColorClass string
 	IsComplete bool
+	Videos     []model.MatchVideo
 }
 
 type MatchLogRow struct {
@@ -121,12 +122,24 @@ func (web *Web) matchLogsViewGetHandler(w http.ResponseWriter, r *http.Request)
 	if len(matchLogs.Logs) > 0 {
 		firstMatch = matchLogs.Logs[0].StartTime
 	}
+	wifiHistories, err := web.getWifiHistoriesForStation(match.Id, matchLogs.AllianceStation)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	matchVideos, err := web.arena.Database.GetMatchVideosForMatch(match.Id)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
 	data := struct {
 		*model.EventSettings
-		Match      *model.Match
-		MatchLogs  *MatchLogs
-		FirstMatch string
-	}{web.arena.EventSettings, match, matchLogs, firstMatch}
+		Match         *model.Match
+		MatchLogs     *MatchLogs
+		FirstMatch    string
+		WifiHistories []model.WifiHistory
+		MatchVideos   []model.MatchVideo
+	}{web.arena.EventSettings, match, matchLogs, firstMatch, wifiHistories, matchVideos}
 	err = template.ExecuteTemplate(w, "base", data)
 	if err != nil {
 		handleWebErr(w, err)
@@ -236,6 +249,22 @@ func (web *Web) getMatchLogFromRequest(r *http.Request) (*model.Match, *MatchLog
 	return match, &logs, false, nil
 }
 
+// Returns the Wi-Fi histories recorded by the arena for the given match and alliance station.
+func (web *Web) getWifiHistoriesForStation(matchId int, station string) ([]model.WifiHistory, error) {
+	wifiHistories, err := web.arena.Database.GetWifiHistoriesForMatch(matchId)
+	if err != nil {
+		return nil, err
+	}
+
+	var stationWifiHistories []model.WifiHistory
+	for _, wifiHistory := range wifiHistories {
+		if wifiHistory.Station == station {
+			stationWifiHistories = append(stationWifiHistories, wifiHistory)
+		}
+	}
+	return stationWifiHistories, nil
+}
+
 // Constructs the list of matches to display in the match Logs interface.
 func (web *Web) buildMatchLogsList(matchType model.MatchType) ([]MatchLogsListItem, error) {
 	matches, err := web.arena.Database.GetMatchesByType(matchType, false)
@@ -250,6 +279,9 @@ func (web *Web) buildMatchLogsList(matchType model.MatchType) ([]MatchLogsListIt
 		matchLogsList[i].Time = match.Time.Local().Format("Mon 1/02 03:04 PM")
 		matchLogsList[i].RedTeams = []int{match.Red1, match.Red2, match.Red3}
 		matchLogsList[i].BlueTeams = []int{match.Blue1, match.Blue2, match.Blue3}
+		if matchLogsList[i].Videos, err = web.arena.Database.GetMatchVideosForMatch(match.Id); err != nil {
+			return []MatchLogsListItem{}, err
+		}
 		if err != nil {
 			return []MatchLogsListItem{}, err
 		}
@@ -282,49 +282,23 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 				continue
 			}
 		case "signalVolunteers":
-			if web.arena.MatchState != field.PostMatch && web.arena.MatchState != field.PreMatch {
-				// Don't allow clearing the field until the match is over.
+			if err = web.signalVolunteers(); err != nil {
+				// Silently ignore the request if the match isn't over yet.
 				continue
 			}
-			web.arena.FieldVolunteers = true
-			web.arena.AllianceStationDisplayMode = "signalCount"
-			web.arena.AllianceStationDisplayModeNotifier.Notify()
 		case "signalReset":
-			if web.arena.MatchState != field.PostMatch && web.arena.MatchState != field.PreMatch {
-				// Don't allow clearing the field until the match is over.
+			if err = web.signalReset(); err != nil {
+				// Silently ignore the request if the match isn't over yet.
 				continue
 			}
-			web.arena.FieldVolunteers = false
-			web.arena.FieldReset = true
-			web.arena.AllianceStationDisplayMode = "fieldReset"
-			web.arena.AllianceStationDisplayModeNotifier.Notify()
 		case "commitResults":
-			if web.arena.MatchState != field.PostMatch {
-				ws.WriteError("cannot commit match while it is in progress")
-				continue
-			}
-			err = web.commitCurrentMatchScore()
-			if err != nil {
-				ws.WriteError(err.Error())
-				continue
-			}
-			err = web.arena.ResetMatch()
-			if err != nil {
-				ws.WriteError(err.Error())
-				continue
-			}
-			err = web.arena.LoadNextMatch(true)
+			err = web.commitResultsAndLoadNextMatch()
 			if err != nil {
 				ws.WriteError(err.Error())
 				continue
 			}
 		case "discardResults":
-			err = web.arena.ResetMatch()
-			if err != nil {
-				ws.WriteError(err.Error())
-				continue
-			}
-			err = web.arena.LoadNextMatch(false)
+			err = web.discardResultsAndLoadNextMatch()
 			if err != nil {
 				ws.WriteError(err.Error())
 				continue
@@ -372,6 +346,55 @@ func (web *Web) matchPlayWebsocketHandler(w http.ResponseWriter, r *http.Request
 	}
 }
 
+// Commits the score of the match that has just finished and loads the next one.
+func (web *Web) commitResultsAndLoadNextMatch() error {
+	if web.arena.MatchState != field.PostMatch {
+		return fmt.Errorf("cannot commit match while it is in progress")
+	}
+	if err := web.commitCurrentMatchScore(); err != nil {
+		return err
+	}
+	if err := web.arena.ResetMatch(); err != nil {
+		return err
+	}
+	return web.arena.LoadNextMatch(true)
+}
+
+// Discards the score of the match that has just finished and loads the next one.
+func (web *Web) discardResultsAndLoadNextMatch() error {
+	if err := web.arena.ResetMatch(); err != nil {
+		return err
+	}
+	return web.arena.LoadNextMatch(false)
+}
+
+// Signals the field volunteers that they may enter the field and shows the count of robots to clear on the alliance
+// station displays.
+func (web *Web) signalVolunteers() error {
+	if web.arena.MatchState != field.PostMatch && web.arena.MatchState != field.PreMatch {
+		// Don't allow clearing the field until the match is over.
+		return fmt.Errorf("cannot signal volunteers while the match is in progress")
+	}
+	web.arena.FieldVolunteers = true
+	web.arena.AllianceStationDisplayMode = "signalCount"
+	web.arena.AllianceStationDisplayModeNotifier.Notify()
+	return nil
+}
+
+// Signals that the field may be reset, lighting the field reset indicators and showing the field reset screen on the
+// alliance station displays.
+func (web *Web) signalReset() error {
+	if web.arena.MatchState != field.PostMatch && web.arena.MatchState != field.PreMatch {
+		// Don't allow clearing the field until the match is over.
+		return fmt.Errorf("cannot signal a field reset while the match is in progress")
+	}
+	web.arena.FieldVolunteers = false
+	web.arena.FieldReset = true
+	web.arena.AllianceStationDisplayMode = "fieldReset"
+	web.arena.AllianceStationDisplayModeNotifier.Notify()
+	return nil
+}
+
 // Saves the given match and result to the database, supplanting any previous result for the match.
 func (web *Web) commitMatchScore(match *model.Match, matchResult *model.MatchResult, isMatchReviewEdit bool) error {
 	var updatedRankings game.Rankings
@@ -432,6 +455,7 @@ func (web *Web) commitMatchScore(match *model.Match, matchResult *model.MatchRes
 				return err
 			}
 			updatedRankings = rankings
+			web.arena.TriggerWebhook(model.WebhookRankingsUpdated, rankings)
 		}
 
 		if match.ShouldUpdatePlayoffMatches() {
@@ -453,6 +477,10 @@ func (web *Web) commitMatchScore(match *model.Match, matchResult *model.MatchRes
 
 			// Generate awards if the tournament is over.
 			if web.arena.PlayoffTournament.IsComplete() {
+				existingWinnerAwards, err := web.arena.Database.GetAwardsByType(model.WinnerAward)
+				if err != nil {
+					return err
+				}
 				winnerAllianceId := web.arena.PlayoffTournament.WinningAllianceId()
 				finalistAllianceId := web.arena.PlayoffTournament.FinalistAllianceId()
 				if err = tournament.CreateOrUpdateWinnerAndFinalistAwards(
@@ -460,10 +488,37 @@ func (web *Web) commitMatchScore(match *model.Match, matchResult *model.MatchRes
 				); err != nil {
 					return err
 				}
+				if len(existingWinnerAwards) == 0 {
+					web.triggerAwardWebhooks(model.WinnerAward, model.FinalistAward)
+				}
 			}
 		}
 
-		
+		if web.arena.EventSettings.TbaPublishingEnabled && match.Type != model.Practice {
+			// Queue the updates for The Blue Alliance; the client sends them in order in the background.
+			if err := web.arena.TbaClient.PublishMatches(web.arena.Database); err != nil {
+				log.Printf("Failed to publish matches: %s", err.Error())
+			}
+			if match.ShouldUpdateRankings() {
+				if err := web.arena.TbaClient.PublishRankings(web.arena.Database); err != nil {
+					log.Printf("Failed to publish rankings: %s", err.Error())
+				}
+			}
+			if match.ShouldUpdatePlayoffMatches() && web.arena.PlayoffTournament.IsComplete() {
+				if err := web.arena.TbaClient.PublishAwards(web.arena.Database); err != nil {
+					log.Printf("Failed to publish awards: %s", err.Error())
+				}
+			}
+		}
+
+		web.arena.TriggerWebhook(
+			model.WebhookScoreCommitted,
+			struct {
+				Match            *model.Match
+				RedScoreSummary  *game.ScoreSummary
+				BlueScoreSummary *game.ScoreSummary
+			}{match, redScoreSummary, blueScoreSummary},
+		)
 
 		// Back up the database, but don't error out if it fails.
 		err = web.arena.Database.Backup(
@@ -24,6 +24,7 @@ type MatchReviewListItem struct {
 	BlueScore  int
 	ColorClass string
 	IsComplete bool
+	Videos     []model.MatchVideo
 }
 
 // Shows the match review interface.
@@ -190,6 +191,9 @@ func (web *Web) buildMatchReviewList(matchType model.MatchType) ([]MatchReviewLi
 		matchReviewList[i].Time = match.Time.Local().Format("Mon 1/02 03:04 PM")
 		matchReviewList[i].RedTeams = []int{match.Red1, match.Red2, match.Red3}
 		matchReviewList[i].BlueTeams = []int{match.Blue1, match.Blue2, match.Blue3}
+		if matchReviewList[i].Videos, err = web.arena.Database.GetMatchVideosForMatch(match.Id); err != nil {
+			return []MatchReviewListItem{}, err
+		}
 		matchResult, err := web.arena.Database.GetMatchResultForMatch(match.Id)
 		if err != nil {
 			return []MatchReviewListItem{}, err
@@ -0,0 +1,100 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web route for playing back the recorded video of a match from the folder that the clips were copied to.
+
+package web
+
+import (
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Team254/cheesy-arena/model"
+)
+
+var matchVideoExtensions = []string{".mov", ".mp4", ".m4v", ".mxf"}
+
+// Streams the clip file for the given match video.
+func (web *Web) matchVideoHandler(w http.ResponseWriter, r *http.Request) {
+	videoId, _ := strconv.Atoi(r.PathValue("videoId"))
+	matchVideo, err := web.arena.Database.GetMatchVideoById(videoId)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if matchVideo == nil {
+		http.Error(w, fmt.Sprintf("Error: No such match video: %d", videoId), 404)
+		return
+	}
+
+	path, err := findMatchVideoFile(web.arena.EventSettings.MatchVideoDirectory, matchVideo)
+	if err != nil {
+		http.Error(w, "Error: "+err.Error(), 404)
+		return
+	}
+	http.ServeFile(w, r, path)
+}
+
+// Searches the given folder and its subfolders for the clip file recorded for the given match video. HyperDeck devices
+// append a numeric suffix to the clip name, and clips from multiple devices are typically copied into a subfolder per
+// device, so a file within a path containing the device's address is preferred.
+func findMatchVideoFile(directory string, matchVideo *model.MatchVideo) (string, error) {
+	if directory == "" {
+		return "", fmt.Errorf("match video folder is not configured")
+	}
+
+	var candidatePaths []string
+	err := filepath.WalkDir(
+		directory,
+		func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !entry.IsDir() && isMatchVideoFileForClip(entry.Name(), matchVideo.ClipName) {
+				candidatePaths = append(candidatePaths, path)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+	if len(candidatePaths) == 0 {
+		return "", fmt.Errorf("no clip named %s found in %s", matchVideo.ClipName, directory)
+	}
+
+	host := matchVideo.DeviceAddress
+	if addressHost, _, err := net.SplitHostPort(host); err == nil {
+		host = addressHost
+	}
+	for _, path := range candidatePaths {
+		if strings.Contains(path, host) {
+			return path, nil
+		}
+	}
+	return candidatePaths[0], nil
+}
+
+// Returns whether the given filename is a video clip recorded under the given clip name.
+func isMatchVideoFileForClip(filename, clipName string) bool {
+	extension := filepath.Ext(filename)
+	if !slices.Contains(matchVideoExtensions, strings.ToLower(extension)) {
+		return false
+	}
+	baseName := strings.TrimSuffix(filename, extension)
+	if baseName == clipName {
+		return true
+	}
+	suffix, found := strings.CutPrefix(baseName, clipName+"_")
+	if !found || suffix == "" {
+		return false
+	}
+	_, err := strconv.Atoi(suffix)
+	return err == nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchVideo(t *testing.T) {
+	web := setupTestWeb(t)
+
+	match := model.Match{Type: model.Qualification, ShortName: "Q12", Red1: 254}
+	web.arena.Database.CreateMatch(&match)
+	startedAt := time.Unix(1000, 0)
+	matchVideo1 := model.MatchVideo{
+		MatchId: match.Id, DeviceAddress: "10.0.100.51", ClipName: "Q12", Timecode: "01:02:03:04", StartedAt: startedAt,
+	}
+	matchVideo2 := model.MatchVideo{
+		MatchId:       match.Id,
+		DeviceAddress: "10.0.100.52:9993",
+		ClipName:      "Q12",
+		StartedAt:     startedAt,
+	}
+	matchVideo3 := model.MatchVideo{
+		MatchId: match.Id, DeviceAddress: "10.0.100.51", ClipName: "Q12_Play2", StartedAt: startedAt.Add(time.Minute),
+	}
+	web.arena.Database.CreateMatchVideo(&matchVideo1)
+	web.arena.Database.CreateMatchVideo(&matchVideo2)
+	web.arena.Database.CreateMatchVideo(&matchVideo3)
+
+	// Nothing should be shown or served until the folder is configured.
+	recorder := web.getHttpResponse("/match_review")
+	assert.Equal(t, 200, recorder.Code)
+	assert.NotContains(t, recorder.Body.String(), "/match_videos/")
+	recorder = web.getHttpResponse("/match_videos/1")
+	assert.Equal(t, 404, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "match video folder is not configured")
+
+	directory := t.TempDir()
+	writeMatchVideoFile(t, filepath.Join(directory, "10.0.100.51", "Q12_0001.mov"), "deck 1 play 1")
+	writeMatchVideoFile(t, filepath.Join(directory, "10.0.100.51", "Q12_Play2_0001.mov"), "deck 1 play 2")
+	writeMatchVideoFile(t, filepath.Join(directory, "10.0.100.51", "Q12.xml"), "metadata")
+	writeMatchVideoFile(t, filepath.Join(directory, "10.0.100.52", "Q12.mp4"), "deck 2 play 1")
+	web.arena.EventSettings.MatchVideoDirectory = directory
+
+	recorder = web.getHttpResponse("/match_review")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "/match_videos/1")
+	assert.Contains(t, recorder.Body.String(), "/match_videos/3")
+	assert.Contains(t, recorder.Body.String(), "10.0.100.51 @ 01:02:03:04")
+	recorder = web.getHttpResponse("/match_logs")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "/match_videos/2")
+	recorder = web.getHttpResponse("/match_logs/1/R1/log")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "<video src=\"/match_videos/3\"")
+
+	recorder = web.getHttpResponse("/match_videos/1")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "deck 1 play 1", recorder.Body.String())
+	recorder = web.getHttpResponse("/match_videos/2")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "deck 2 play 1", recorder.Body.String())
+	recorder = web.getHttpResponse("/match_videos/3")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "deck 1 play 2", recorder.Body.String())
+
+	// Check that a missing clip or video record is reported as such.
+	os.RemoveAll(filepath.Join(directory, "10.0.100.51"))
+	recorder = web.getHttpResponse("/match_videos/3")
+	assert.Equal(t, 404, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "no clip named Q12_Play2 found")
+	recorder = web.getHttpResponse("/match_videos/4")
+	assert.Equal(t, 404, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "No such match video: 4")
+}
+
+func TestIsMatchVideoFileForClip(t *testing.T) {
+	assert.True(t, isMatchVideoFileForClip("Q12.mov", "Q12"))
+	assert.True(t, isMatchVideoFileForClip("Q12_0003.MP4", "Q12"))
+	assert.True(t, isMatchVideoFileForClip("Q12_Play2_0001.mov", "Q12_Play2"))
+	assert.False(t, isMatchVideoFileForClip("Q12_Play2_0001.mov", "Q12"))
+	assert.False(t, isMatchVideoFileForClip("Q120.mov", "Q12"))
+	assert.False(t, isMatchVideoFileForClip("Q12.xml", "Q12"))
+}
+
+func writeMatchVideoFile(t *testing.T, path, contents string) {
+	assert.Nil(t, os.MkdirAll(filepath.Dir(path), 0755))
+	assert.Nil(t, os.WriteFile(path, []byte(contents), 0644))
+}
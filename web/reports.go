@@ -13,6 +13,7 @@ import (
 	"net/http"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Team254/cheesy-arena/game"
@@ -411,7 +412,11 @@ func (web *Web) scheduleCsvReportHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	var buf bytes.Buffer
-	err = template.ExecuteTemplate(&buf, "schedule.csv", matches)
+	data := struct {
+		Matches      []model.Match
+		TwoVsTwoMode bool
+	}{matches, web.arena.EventSettings.TwoVsTwoMode}
+	err = template.ExecuteTemplate(&buf, "schedule.csv", data)
 	if err != nil {
 		handleWebErr(w, err)
 		return
@@ -446,13 +451,21 @@ func (web *Web) schedulePdfReportHandler(w http.ResponseWriter, r *http.Request)
 		handleWebErr(w, err)
 		return
 	}
+	teamsPerAlliance := web.arena.EventSettings.TeamsPerAlliance()
 	matchesPerTeam := 0
 	if len(teams) > 0 {
-		matchesPerTeam = len(matches) * tournament.TeamsPerMatch / len(teams)
+		matchesPerTeam = len(matches) * 2 * teamsPerAlliance / len(teams)
+	}
+
+	// Leave out the third station of each alliance if alliances only have two teams.
+	stationNames := []string{"Red 1", "Red 2", "Red 3", "Blue 1", "Blue 2", "Blue 3"}
+	stationIndices := []int{0, 1, 3, 4}
+	if teamsPerAlliance == 3 {
+		stationIndices = []int{0, 1, 2, 3, 4, 5}
 	}
 
 	// The widths of the table columns in mm, stored here so that they can be referenced for each row.
-	colWidths := map[string]float64{"Time": 35, "Match": 40, "Team": 20}
+	colWidths := map[string]float64{"Time": 35, "Match": 40, "Team": 120 / float64(len(stationIndices))}
 	rowHeight := 6.5
 
 	pdf := gofpdf.New("P", "mm", "Letter", "font")
@@ -464,12 +477,19 @@ func (web *Web) schedulePdfReportHandler(w http.ResponseWriter, r *http.Request)
 	pdf.CellFormat(195, rowHeight, "Match Schedule - "+web.arena.EventSettings.Name, "", 1, "C", false, 0, "")
 	pdf.CellFormat(colWidths["Time"], rowHeight, "Time", "1", 0, "C", true, 0, "")
 	pdf.CellFormat(colWidths["Match"], rowHeight, "Match", "1", 0, "C", true, 0, "")
-	pdf.CellFormat(colWidths["Team"], rowHeight, "Red 1", "1", 0, "C", true, 0, "")
-	pdf.CellFormat(colWidths["Team"], rowHeight, "Red 2", "1", 0, "C", true, 0, "")
-	pdf.CellFormat(colWidths["Team"], rowHeight, "Red 3", "1", 0, "C", true, 0, "")
-	pdf.CellFormat(colWidths["Team"], rowHeight, "Blue 1", "1", 0, "C", true, 0, "")
-	pdf.CellFormat(colWidths["Team"], rowHeight, "Blue 2", "1", 0, "C", true, 0, "")
-	pdf.CellFormat(colWidths["Team"], rowHeight, "Blue 3", "1", 1, "C", true, 0, "")
+	for i, stationIndex := range stationIndices {
+		pdf.CellFormat(
+			colWidths["Team"],
+			rowHeight,
+			stationNames[stationIndex],
+			"1",
+			lineBreak(i, stationIndices),
+			"C",
+			true,
+			0,
+			"",
+		)
+	}
 	pdf.SetFont("Arial", "", 10)
 	for _, match := range matches {
 		// Render break if there is one before this match.
@@ -478,10 +498,29 @@ func (web *Web) schedulePdfReportHandler(w http.ResponseWriter, r *http.Request)
 			formattedTime := scheduledBreak.Time.Local().Format("Mon 1/02 03:04 PM")
 			description := fmt.Sprintf("%s (%d minutes)", scheduledBreak.Description, scheduledBreak.DurationSec/60)
 			pdf.CellFormat(colWidths["Time"], rowHeight, formattedTime, "1", 0, "C", false, 0, "")
-			pdf.CellFormat(colWidths["Match"]+6*colWidths["Team"], rowHeight, description, "1", 1, "C", false, 0, "")
+			pdf.CellFormat(
+				colWidths["Match"]+float64(len(stationIndices))*colWidths["Team"],
+				rowHeight,
+				description,
+				"1",
+				1,
+				"C",
+				false,
+				0,
+				"",
+			)
 			breakIndex++
 		}
 
+		matchTeams := []int{match.Red1, match.Red2, match.Red3, match.Blue1, match.Blue2, match.Blue3}
+		surrogates := []bool{
+			match.Red1IsSurrogate,
+			match.Red2IsSurrogate,
+			match.Red3IsSurrogate,
+			match.Blue1IsSurrogate,
+			match.Blue2IsSurrogate,
+			match.Blue3IsSurrogate,
+		}
 		height := rowHeight
 		borderStr := "1"
 		alignStr := "CM"
@@ -516,36 +555,38 @@ func (web *Web) schedulePdfReportHandler(w http.ResponseWriter, r *http.Request)
 			"",
 		)
 		pdf.CellFormat(colWidths["Match"], height, match.LongName, borderStr, 0, alignStr, false, 0, "")
-		pdf.CellFormat(colWidths["Team"], height, formatTeam(match.Red1), borderStr, 0, alignStr, false, 0, "")
-		pdf.CellFormat(colWidths["Team"], height, formatTeam(match.Red2), borderStr, 0, alignStr, false, 0, "")
-		pdf.CellFormat(colWidths["Team"], height, formatTeam(match.Red3), borderStr, 0, alignStr, false, 0, "")
-		pdf.CellFormat(colWidths["Team"], height, formatTeam(match.Blue1), borderStr, 0, alignStr, false, 0, "")
-		pdf.CellFormat(colWidths["Team"], height, formatTeam(match.Blue2), borderStr, 0, alignStr, false, 0, "")
-		pdf.CellFormat(colWidths["Team"], height, formatTeam(match.Blue3), borderStr, 1, alignStr, false, 0, "")
+		for i, stationIndex := range stationIndices {
+			pdf.CellFormat(
+				colWidths["Team"],
+				height,
+				formatTeam(matchTeams[stationIndex]),
+				borderStr,
+				lineBreak(i, stationIndices),
+				alignStr,
+				false,
+				0,
+				"",
+			)
+		}
 		if surrogate {
 			// Render the text that indicates which teams are surrogates.
 			height := 4.0
 			pdf.SetFont("Arial", "", 8)
 			pdf.CellFormat(colWidths["Time"], height, "", "LBR", 0, "C", false, 0, "")
 			pdf.CellFormat(colWidths["Match"], height, "", "LBR", 0, "C", false, 0, "")
-			pdf.CellFormat(
-				colWidths["Team"], height, surrogateText(match.Red1IsSurrogate), "LBR", 0, "CT", false, 0, "",
-			)
-			pdf.CellFormat(
-				colWidths["Team"], height, surrogateText(match.Red2IsSurrogate), "LBR", 0, "CT", false, 0, "",
-			)
-			pdf.CellFormat(
-				colWidths["Team"], height, surrogateText(match.Red3IsSurrogate), "LBR", 0, "CT", false, 0, "",
-			)
-			pdf.CellFormat(
-				colWidths["Team"], height, surrogateText(match.Blue1IsSurrogate), "LBR", 0, "CT", false, 0, "",
-			)
-			pdf.CellFormat(
-				colWidths["Team"], height, surrogateText(match.Blue2IsSurrogate), "LBR", 0, "CT", false, 0, "",
-			)
-			pdf.CellFormat(
-				colWidths["Team"], height, surrogateText(match.Blue3IsSurrogate), "LBR", 1, "CT", false, 0, "",
-			)
+			for i, stationIndex := range stationIndices {
+				pdf.CellFormat(
+					colWidths["Team"],
+					height,
+					surrogateText(surrogates[stationIndex]),
+					"LBR",
+					lineBreak(i, stationIndices),
+					"CT",
+					false,
+					0,
+					"",
+				)
+			}
 			pdf.SetFont("Arial", "", 10)
 		}
 	}
@@ -566,6 +607,197 @@ func (web *Web) schedulePdfReportHandler(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// Generates a CSV-formatted report of the fairness statistics for each team in the practice or qualification schedule.
+func (web *Web) scheduleAnalysisCsvReportHandler(w http.ResponseWriter, r *http.Request) {
+	_, analysis, ok := web.getScheduleAnalysis(w, r)
+	if !ok {
+		return
+	}
+
+	// Don't set the content type as "text/csv", as that will trigger an automatic download in the browser.
+	w.Header().Set("Content-Type", "text/plain")
+	template, err := web.parseFiles("templates/schedule_analysis.csv")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	var buf bytes.Buffer
+	err = template.ExecuteTemplate(&buf, "schedule_analysis.csv", analysis)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	// Strip out carriage returns to ensure consistent behavior across platforms.
+	cleaned := bytes.ReplaceAll(buf.Bytes(), []byte("\r"), []byte(""))
+	w.Write(cleaned)
+}
+
+// Generates a PDF-formatted report of the fairness statistics for each team in the practice or qualification schedule.
+func (web *Web) scheduleAnalysisPdfReportHandler(w http.ResponseWriter, r *http.Request) {
+	matchType, analysis, ok := web.getScheduleAnalysis(w, r)
+	if !ok {
+		return
+	}
+
+	// The widths of the table columns in mm, stored here so that they can be referenced for each row.
+	colWidths := map[string]float64{"Team": 17, "Stat": 16, "Station": 13}
+	rowHeight := 6.5
+
+	pdf := gofpdf.New("P", "mm", "Letter", "font")
+	pdf.AddPage()
+
+	// Render the overall summary and the table header row.
+	pdf.SetFont("Arial", "B", 10)
+	pdf.SetFillColor(220, 220, 220)
+	title := fmt.Sprintf("%s Schedule Analysis - %s", matchType, web.arena.EventSettings.Name)
+	pdf.CellFormat(195, rowHeight, title, "", 1, "C", false, 0, "")
+	pdf.SetFont("Arial", "", 10)
+	summary := fmt.Sprintf(
+		"%d matches, %d teams, %d surrogate appearances, %d repeat partner pairings, %d repeat opponent pairings, "+
+			"minimum of %s matches between appearances",
+		analysis.NumMatches,
+		analysis.NumTeams,
+		analysis.NumSurrogates,
+		analysis.RepeatPartners,
+		analysis.RepeatOpponents,
+		matchesBetweenText(analysis.MinMatchesBetween),
+	)
+	pdf.MultiCell(195, 5, summary, "", "C", false)
+	if len(analysis.AvailabilityViolations) > 0 {
+		// List any teams that are scheduled outside of their availability windows ahead of everything else.
+		pdf.SetFont("Arial", "B", 10)
+		pdf.SetTextColor(200, 0, 0)
+		var violations []string
+		for _, violation := range analysis.AvailabilityViolations {
+			violations = append(
+				violations,
+				fmt.Sprintf(
+					"%d in %s (%s)",
+					violation.TeamId,
+					violation.MatchName,
+					violation.Time.Local().Format("Mon 1/02 03:04 PM"),
+				),
+			)
+		}
+		pdf.MultiCell(
+			195,
+			5,
+			"Teams scheduled outside of their availability: "+strings.Join(violations, ", "),
+			"",
+			"C",
+			false,
+		)
+		pdf.SetTextColor(0, 0, 0)
+		pdf.SetFont("Arial", "", 10)
+	}
+	pdf.Ln(2)
+	pdf.SetFont("Arial", "B", 10)
+	pdf.CellFormat(colWidths["Team"], rowHeight, "Team", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Stat"], rowHeight, "Matches", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Stat"], rowHeight, "Surrogate", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Stat"], rowHeight, "Min Gap", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Stat"], rowHeight, "Avg Gap", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Station"], rowHeight, "Red", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Station"], rowHeight, "Blue", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Station"], rowHeight, "Stn 1", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Station"], rowHeight, "Stn 2", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Station"], rowHeight, "Stn 3", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Stat"], rowHeight, "Rpt Part.", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Stat"], rowHeight, "Rpt Opp.", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Stat"], rowHeight, "SoS", "1", 1, "C", true, 0, "")
+	for _, team := range analysis.Teams {
+		pdf.SetFont("Arial", "B", 10)
+		pdf.CellFormat(colWidths["Team"], rowHeight, strconv.Itoa(team.TeamId), "1", 0, "C", false, 0, "")
+		pdf.SetFont("Arial", "", 10)
+		pdf.CellFormat(colWidths["Stat"], rowHeight, strconv.Itoa(team.NumMatches), "1", 0, "C", false, 0, "")
+		surrogates := strconv.Itoa(team.NumSurrogateMatches)
+		pdf.CellFormat(colWidths["Stat"], rowHeight, surrogates, "1", 0, "C", false, 0, "")
+		minGap := matchesBetweenText(team.MinMatchesBetween)
+		pdf.CellFormat(colWidths["Stat"], rowHeight, minGap, "1", 0, "C", false, 0, "")
+		averageGap := "-"
+		if team.MinMatchesBetween >= 0 {
+			averageGap = fmt.Sprintf("%.1f", team.AverageMatchesBetween)
+		}
+		pdf.CellFormat(colWidths["Stat"], rowHeight, averageGap, "1", 0, "C", false, 0, "")
+		pdf.CellFormat(colWidths["Station"], rowHeight, strconv.Itoa(team.RedCount), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(colWidths["Station"], rowHeight, strconv.Itoa(team.BlueCount), "1", 0, "C", false, 0, "")
+		for _, count := range team.StationCounts {
+			pdf.CellFormat(colWidths["Station"], rowHeight, strconv.Itoa(count), "1", 0, "C", false, 0, "")
+		}
+		pdf.CellFormat(colWidths["Stat"], rowHeight, strconv.Itoa(team.RepeatPartners), "1", 0, "C", false, 0, "")
+		pdf.CellFormat(colWidths["Stat"], rowHeight, strconv.Itoa(team.RepeatOpponents), "1", 0, "C", false, 0, "")
+		strengthOfSchedule := "-"
+		if analysis.HasStrengthOfSchedule {
+			strengthOfSchedule = fmt.Sprintf("%+.1f", team.StrengthOfSchedule)
+		}
+		pdf.CellFormat(colWidths["Stat"], rowHeight, strengthOfSchedule, "1", 1, "C", false, 0, "")
+	}
+
+	pdf.SetFont("Arial", "", 8)
+	pdf.MultiCell(
+		195,
+		4,
+		"Gaps are the number of other matches between a team's consecutive appearances. Repeat partners and opponents "+
+			"count each pairing with the same team beyond the first. Strength of schedule (SoS) is the average rank "+
+			"of a team's partners minus that of its opponents; positive values mean a tougher schedule.",
+		"",
+		"L",
+		false,
+	)
+	addTimeGeneratedFooter(pdf)
+
+	// Write out the PDF file as the HTTP response.
+	w.Header().Set("Content-Type", "application/pdf")
+	err := pdf.Output(w)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Loads the practice or qualification schedule given in the request and analyzes it, writing an error response and
+// returning false if that isn't possible.
+func (web *Web) getScheduleAnalysis(
+	w http.ResponseWriter, r *http.Request,
+) (model.MatchType, tournament.ScheduleAnalysis, bool) {
+	matchType, err := model.MatchTypeFromString(r.PathValue("type"))
+	if err != nil {
+		handleWebErr(w, err)
+		return matchType, tournament.ScheduleAnalysis{}, false
+	}
+	if matchType != model.Practice && matchType != model.Qualification {
+		http.Error(w, "Error: only practice and qualification schedules can be analyzed", 400)
+		return matchType, tournament.ScheduleAnalysis{}, false
+	}
+
+	matches, err := web.arena.Database.GetMatchesByType(matchType, false)
+	if err != nil {
+		handleWebErr(w, err)
+		return matchType, tournament.ScheduleAnalysis{}, false
+	}
+	teams, err := web.arena.Database.GetAllTeams()
+	if err != nil {
+		handleWebErr(w, err)
+		return matchType, tournament.ScheduleAnalysis{}, false
+	}
+	var rankings game.Rankings
+	if matchType == model.Qualification {
+		if rankings, err = web.arena.Database.GetAllRankings(); err != nil {
+			handleWebErr(w, err)
+			return matchType, tournament.ScheduleAnalysis{}, false
+		}
+	}
+	return matchType, tournament.AnalyzeSchedule(matches, teams, rankings), true
+}
+
+func matchesBetweenText(matchesBetween int) string {
+	if matchesBetween < 0 {
+		return "-"
+	}
+	return strconv.Itoa(matchesBetween)
+}
+
 // Generates a CSV-formatted report of the team list.
 func (web *Web) teamsCsvReportHandler(w http.ResponseWriter, r *http.Request) {
 	teams, err := web.arena.Database.GetAllTeams()
@@ -850,6 +1082,15 @@ func surrogateText(isSurrogate bool) string {
 	}
 }
 
+// Returns the PDF cell line break setting for the given position within a row of columns, so that the row ends after
+// its last column.
+func lineBreak(index int, columns []int) int {
+	if index == len(columns)-1 {
+		return 1
+	}
+	return 0
+}
+
 // Generates a PDF-formatted report of the match cycle times.
 func (web *Web) cyclePdfReportHandler(w http.ResponseWriter, r *http.Request) {
 	matchType, err := model.MatchTypeFromString(r.PathValue("type"))
@@ -981,6 +1222,100 @@ func (web *Web) ftaCsvReportHandler(w http.ResponseWriter, r *http.Request) {
 	w.Write(cleaned)
 }
 
+// Generates a CSV-formatted report of the recorded Wi-Fi statistics for each team, ordered from worst to best.
+func (web *Web) wifiCsvReportHandler(w http.ResponseWriter, r *http.Request) {
+	wifiHistories, err := web.arena.Database.GetAllWifiHistories()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	summaries := model.SummarizeWifiHistories(wifiHistories)
+
+	// Don't set the content type as "text/csv", as that will trigger an automatic download in the browser.
+	w.Header().Set("Content-Type", "text/plain")
+	template, err := web.parseFiles("templates/wifi.csv")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	var buf bytes.Buffer
+	err = template.ExecuteTemplate(&buf, "wifi.csv", summaries)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	// Strip out carriage returns to ensure consistent behavior across platforms.
+	cleaned := bytes.ReplaceAll(buf.Bytes(), []byte("\r"), []byte(""))
+	w.Write(cleaned)
+}
+
+// Generates a PDF-formatted report of the recorded Wi-Fi statistics for each team, ordered from worst to best.
+func (web *Web) wifiPdfReportHandler(w http.ResponseWriter, r *http.Request) {
+	wifiHistories, err := web.arena.Database.GetAllWifiHistories()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	summaries := model.SummarizeWifiHistories(wifiHistories)
+
+	// The widths of the table columns in mm, stored here so that they can be referenced for each row.
+	colWidths := map[string]float64{
+		"Team":     20,
+		"Matches":  20,
+		"Unlinked": 25,
+		"AvgSnr":   25,
+		"MinSnr":   20,
+		"Quality":  25,
+		"RxRate":   30,
+		"TxRate":   30,
+	}
+	rowHeight := 6.5
+
+	pdf := gofpdf.New("P", "mm", "Letter", "font")
+	pdf.AddPage()
+
+	// Render table header row.
+	pdf.SetFont("Arial", "B", 10)
+	pdf.SetFillColor(220, 220, 220)
+	pdf.CellFormat(195, rowHeight, "Wi-Fi Report - "+web.arena.EventSettings.Name, "", 1, "C", false, 0, "")
+	pdf.CellFormat(colWidths["Team"], rowHeight, "Team", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Matches"], rowHeight, "Matches", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Unlinked"], rowHeight, "Unlinked", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["AvgSnr"], rowHeight, "Avg SNR", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["MinSnr"], rowHeight, "Min SNR", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["Quality"], rowHeight, "Quality", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["RxRate"], rowHeight, "RX Rate (Mbps)", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths["TxRate"], rowHeight, "TX Rate (Mbps)", "1", 1, "C", true, 0, "")
+	for _, summary := range summaries {
+		pdf.SetFont("Arial", "B", 10)
+		pdf.CellFormat(colWidths["Team"], rowHeight, strconv.Itoa(summary.TeamId), "1", 0, "C", false, 0, "")
+		pdf.SetFont("Arial", "", 10)
+		pdf.CellFormat(colWidths["Matches"], rowHeight, strconv.Itoa(summary.NumMatches), "1", 0, "C", false, 0, "")
+		unlinked := fmt.Sprintf("%.1f%%", summary.UnlinkedPercent)
+		pdf.CellFormat(colWidths["Unlinked"], rowHeight, unlinked, "1", 0, "C", false, 0, "")
+		averageSnr := fmt.Sprintf("%.1f", summary.AverageSignalNoiseRatio)
+		pdf.CellFormat(colWidths["AvgSnr"], rowHeight, averageSnr, "1", 0, "C", false, 0, "")
+		pdf.CellFormat(colWidths["MinSnr"], rowHeight, strconv.Itoa(summary.MinSignalNoiseRatio), "1", 0, "C", false, 0, "")
+		quality := fmt.Sprintf("%.2f", summary.AverageConnectionQuality)
+		pdf.CellFormat(colWidths["Quality"], rowHeight, quality, "1", 0, "C", false, 0, "")
+		rxRate := fmt.Sprintf("%.1f", summary.AverageRxRate)
+		pdf.CellFormat(colWidths["RxRate"], rowHeight, rxRate, "1", 0, "C", false, 0, "")
+		txRate := fmt.Sprintf("%.1f", summary.AverageTxRate)
+		pdf.CellFormat(colWidths["TxRate"], rowHeight, txRate, "1", 1, "C", false, 0, "")
+	}
+
+	addTimeGeneratedFooter(pdf)
+
+	// Write out the PDF file as the HTTP response.
+	w.Header().Set("Content-Type", "application/pdf")
+	err = pdf.Output(w)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
 // Generates a PDF-formatted report of the judging schedule.
 func (web *Web) judgingSchedulePdfReportHandler(w http.ResponseWriter, r *http.Request) {
 	slots, err := web.arena.Database.GetAllJudgingSlots()
@@ -8,6 +8,7 @@ import (
 	"github.com/Team254/cheesy-arena/model"
 	"github.com/Team254/cheesy-arena/tournament"
 	"github.com/stretchr/testify/assert"
+	"strings"
 	"testing"
 	"time"
 )
@@ -98,6 +99,15 @@ func TestScheduleCsvReport(t *testing.T) {
 		",1,false,2,false,3,false,4,true,5,true,6,true\nQ2,Qualification," + match2Time.String() +
 		",7,true,8,true,9,true,10,false,11,false,12,false\n\n"
 	assert.Equal(t, expectedBody, recorder.Body.String())
+
+	// The third station of each alliance should be left out in 2v2 mode.
+	web.arena.EventSettings.TwoVsTwoMode = true
+	recorder = web.getHttpResponse("/reports/csv/schedule/qualification")
+	assert.Equal(t, 200, recorder.Code)
+	expectedBody = "Match,Type,Time,Red1,Red1IsSurrogate,Red2,Red2IsSurrogate,Blue1,Blue1IsSurrogate,Blue2," +
+		"Blue2IsSurrogate\nQ1,Qualification," + match1Time.String() + ",1,false,2,false,4,true,5,true\n" +
+		"Q2,Qualification," + match2Time.String() + ",7,true,8,true,10,false,11,false\n\n"
+	assert.Equal(t, expectedBody, recorder.Body.String())
 }
 
 func TestSchedulePdfReport(t *testing.T) {
@@ -203,6 +213,40 @@ func TestWpaKeysCsvReport(t *testing.T) {
 	assert.Equal(t, "254,12345678\r\n1114,9876543210\r\n", recorder.Body.String())
 }
 
+func TestWifiReports(t *testing.T) {
+	web := setupTestWeb(t)
+
+	web.arena.Database.CreateWifiHistory(
+		&model.WifiHistory{
+			MatchId: 1,
+			TeamId:  254,
+			Samples: []model.WifiSample{{RadioLinked: true, RxRate: 800, TxRate: 600, SignalNoiseRatio: 40}},
+		},
+	)
+	web.arena.Database.CreateWifiHistory(
+		&model.WifiHistory{
+			MatchId: 1,
+			TeamId:  1114,
+			Samples: []model.WifiSample{
+				{RadioLinked: true, RxRate: 400, TxRate: 300, SignalNoiseRatio: 20, ConnectionQuality: 2},
+				{RadioLinked: false},
+			},
+		},
+	)
+
+	recorder := web.getHttpResponse("/reports/csv/wifi")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "text/plain", recorder.Header()["Content-Type"][0])
+	expectedBody := "TeamId,Matches,Samples,UnlinkedPercent,AverageSnr,MinSnr,AverageConnectionQuality," +
+		"AverageRxRate,AverageTxRate\n1114,1,2,50.0,20.0,20,2.00,400.0,300.0\n254,1,1,0.0,40.0,40,0.00,800.0,600.0\n\n"
+	assert.Equal(t, expectedBody, recorder.Body.String())
+
+	// Can't really parse the PDF content and check it, so just check that what's sent back is a PDF.
+	recorder = web.getHttpResponse("/reports/pdf/wifi")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "application/pdf", recorder.Header()["Content-Type"][0])
+}
+
 func TestAlliancesPdfReport(t *testing.T) {
 	web := setupTestWeb(t)
 	tournament.CreateTestAlliances(web.arena.Database, 8)
@@ -231,3 +275,49 @@ func TestJudgingSchedulePdfReport(t *testing.T) {
 	assert.Equal(t, 200, recorder.Code)
 	assert.Equal(t, "application/pdf", recorder.Header()["Content-Type"][0])
 }
+
+func TestScheduleAnalysisReport(t *testing.T) {
+	web := setupTestWeb(t)
+
+	web.arena.Database.CreateMatch(
+		&model.Match{Type: model.Qualification, Red1: 1, Red2: 2, Red3: 3, Blue1: 4, Blue2: 5, Blue3: 6},
+	)
+	web.arena.Database.CreateMatch(
+		&model.Match{
+			Type: model.Qualification, Red1: 4, Red2: 1, Red3: 7, Blue1: 2, Blue2: 8, Blue3: 3, Blue3IsSurrogate: true,
+		},
+	)
+	web.arena.Database.CreateTeam(&model.Team{Id: 1, AvailableFrom: time.Unix(1000, 0)})
+
+	recorder := web.getHttpResponse("/reports/csv/schedule_analysis/qualification")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "text/plain", recorder.Header()["Content-Type"][0])
+	lines := strings.Split(recorder.Body.String(), "\n")
+	if assert.Equal(t, 11, len(lines)) {
+		assert.Equal(t, "1,2,0,0,0.00,2,0,1,1,0,0,0,,,,2", lines[1])
+		assert.Equal(t, "3,2,1,0,0.00,1,1,0,0,2,1,1,,,,0", lines[3])
+		assert.Equal(t, "8,1,0,,,0,1,0,1,0,0,0,,,,0", lines[8])
+	}
+
+	// Strength of schedule should be included once rankings exist.
+	for i := 1; i <= 8; i++ {
+		web.arena.Database.CreateRanking(&game.Ranking{TeamId: i, Rank: i})
+	}
+	recorder = web.getHttpResponse("/reports/csv/schedule_analysis/qualification")
+	lines = strings.Split(recorder.Body.String(), "\n")
+	if assert.Equal(t, 11, len(lines)) {
+		assert.Equal(t, "1,2,0,0,0.00,2,0,1,1,0,0,0,4.00,4.67,-0.67,2", lines[1])
+	}
+
+	// Can't really parse the PDF content and check it, so just check that what's sent back is a PDF.
+	recorder = web.getHttpResponse("/reports/pdf/schedule_analysis/qualification")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "application/pdf", recorder.Header()["Content-Type"][0])
+	recorder = web.getHttpResponse("/reports/pdf/schedule_analysis/practice")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "application/pdf", recorder.Header()["Content-Type"][0])
+
+	recorder = web.getHttpResponse("/reports/pdf/schedule_analysis/playoff")
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "only practice and qualification schedules can be analyzed")
+}
@@ -8,6 +8,7 @@ package web
 import (
 	"github.com/Team254/cheesy-arena/model"
 	"github.com/Team254/cheesy-arena/tournament"
+	"log"
 	"net/http"
 	"strconv"
 )
@@ -74,6 +75,15 @@ func (web *Web) awardsPostHandler(w http.ResponseWriter, r *http.Request) {
 			handleWebErr(w, err)
 			return
 		}
+		if awardId == 0 {
+			web.arena.TriggerWebhook(model.WebhookAwardCreated, award)
+		}
+	}
+
+	if web.arena.EventSettings.TbaPublishingEnabled {
+		if err := web.arena.TbaClient.PublishAwards(web.arena.Database); err != nil {
+			log.Printf("Failed to publish awards: %s", err.Error())
+		}
 	}
 
 	http.Redirect(w, r, "/setup/awards", 303)
@@ -0,0 +1,81 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web routes for programming team robot radios at the radio configuration kiosk.
+
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Team254/cheesy-arena/model"
+)
+
+// Shows the radio kiosk page.
+func (web *Web) radioKioskGetHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	web.renderRadioKiosk(w, r, 0, "")
+}
+
+// Programs the radio attached to the kiosk port for the selected team.
+func (web *Web) radioKioskPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	teamId, _ := strconv.Atoi(r.PostFormValue("teamId"))
+	team, err := web.arena.Database.GetTeamById(teamId)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if team == nil {
+		web.renderRadioKiosk(w, r, teamId, fmt.Sprintf("No such team: %d", teamId))
+		return
+	}
+
+	if err = web.arena.RadioKiosk.ConfigureRadio(team); err != nil {
+		web.renderRadioKiosk(w, r, teamId, fmt.Sprintf("Failed to configure radio for team %d: %v", teamId, err))
+		return
+	}
+	team.RadioConfiguredAt = time.Now()
+	if err = web.arena.Database.UpdateTeam(team); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/setup/radio_kiosk?configuredTeamId=%d", teamId), 303)
+}
+
+func (web *Web) renderRadioKiosk(w http.ResponseWriter, r *http.Request, selectedTeamId int, errorMessage string) {
+	teams, err := web.arena.Database.GetAllTeams()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	configuredTeamId, _ := strconv.Atoi(r.URL.Query().Get("configuredTeamId"))
+
+	template, err := web.parseFiles("templates/setup_radio_kiosk.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	data := struct {
+		*model.EventSettings
+		Teams            []model.Team
+		SelectedTeamId   int
+		ConfiguredTeamId int
+		ErrorMessage     string
+	}{web.arena.EventSettings, teams, selectedTeamId, configuredTeamId, errorMessage}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
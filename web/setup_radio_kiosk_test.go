@@ -0,0 +1,74 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetupRadioKiosk(t *testing.T) {
+	web := setupTestWeb(t)
+	kiosk := new(fakeRadioKiosk)
+	web.arena.RadioKiosk = kiosk
+	web.arena.EventSettings.NetworkSecurityEnabled = true
+	assert.Nil(t, web.arena.Database.CreateTeam(&model.Team{Id: 254, Nickname: "The Cheesy Poofs", WpaKey: "11111111"}))
+	assert.Nil(t, web.arena.Database.CreateTeam(&model.Team{Id: 1114, WpaKey: "22222222"}))
+
+	recorder := web.getHttpResponse("/setup/radio_kiosk")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "254 - The Cheesy Poofs")
+	assert.Contains(t, recorder.Body.String(), "1114")
+
+	recorder = web.postHttpResponse("/setup/radio_kiosk", "teamId=254")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, "/setup/radio_kiosk?configuredTeamId=254", recorder.Header().Get("Location"))
+	if assert.Equal(t, 1, len(kiosk.configuredTeams)) {
+		assert.Equal(t, "11111111", kiosk.configuredTeams[0].WpaKey)
+	}
+	team, _ := web.arena.Database.GetTeamById(254)
+	assert.False(t, team.RadioConfiguredAt.IsZero())
+	recorder = web.getHttpResponse("/setup/radio_kiosk?configuredTeamId=254")
+	assert.Contains(t, recorder.Body.String(), "The radio for team 254 was configured successfully.")
+	recorder = web.getHttpResponse("/setup/teams")
+	assert.Contains(t, recorder.Body.String(), "Radio Configured")
+	assert.Contains(t, recorder.Body.String(), team.RadioConfiguredAt.Local().Format("Mon 1/02 03:04 PM"))
+
+	// A failure from the radio should be shown without recording the team as configured.
+	kiosk.err = fmt.Errorf("radio not found")
+	recorder = web.postHttpResponse("/setup/radio_kiosk", "teamId=1114")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Failed to configure radio for team 1114: radio not found")
+	team, _ = web.arena.Database.GetTeamById(1114)
+	assert.True(t, team.RadioConfiguredAt.IsZero())
+
+	recorder = web.postHttpResponse("/setup/radio_kiosk", "teamId=9999")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "No such team: 9999")
+
+	// Changing the WPA key should require the radio to be reconfigured.
+	recorder = web.postHttpResponse("/setup/teams/254/edit", "wpaKey=33333333")
+	assert.Equal(t, 303, recorder.Code)
+	team, _ = web.arena.Database.GetTeamById(254)
+	assert.True(t, team.RadioConfiguredAt.IsZero())
+}
+
+type fakeRadioKiosk struct {
+	configuredTeams []model.Team
+	err             error
+}
+
+func (kiosk *fakeRadioKiosk) SetSettings(address, password string) {
+}
+
+func (kiosk *fakeRadioKiosk) ConfigureRadio(team *model.Team) error {
+	if kiosk.err != nil {
+		return kiosk.err
+	}
+	kiosk.configuredTeams = append(kiosk.configuredTeams, *team)
+	return nil
+}
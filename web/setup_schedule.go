@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"github.com/Team254/cheesy-arena/model"
 	"github.com/Team254/cheesy-arena/tournament"
+	"log"
 	"net/http"
 	"strconv"
 	"time"
@@ -81,39 +82,89 @@ func (web *Web) scheduleGeneratePostHandler(w http.ResponseWriter, r *http.Reque
 		)
 		return
 	}
-	if len(teams) < 6 {
+	teamsPerAlliance := web.arena.EventSettings.TeamsPerAlliance()
+	if len(teams) < 2*teamsPerAlliance {
 		web.renderSchedule(
 			w,
 			r,
-			fmt.Sprintf("There are only %d teams. There must be at least 6 teams to generate a schedule.", len(teams)),
+			fmt.Sprintf(
+				"There are only %d teams. There must be at least %d teams to generate a schedule.",
+				len(teams),
+				2*teamsPerAlliance,
+			),
 		)
 		return
 	}
 
-	matches, err := tournament.BuildRandomSchedule(teams, scheduleBlocks, matchType)
+	var matches []model.Match
+	if generatorSeed := r.PostFormValue("generatorSeed"); generatorSeed != "" {
+		seed, parseErr := strconv.ParseInt(generatorSeed, 10, 64)
+		if parseErr != nil {
+			web.renderSchedule(w, r, "The generator seed must be a whole number.")
+			return
+		}
+		matches, err = tournament.BuildGeneratedSchedule(
+			teams, teamsPerAlliance, scheduleBlocks, matchType, tournament.ScheduleGeneratorOptions{Seed: seed},
+		)
+	} else {
+		matches, err = tournament.BuildRandomSchedule(teams, teamsPerAlliance, scheduleBlocks, matchType)
+	}
 	if err != nil {
 		web.renderSchedule(w, r, fmt.Sprintf("Error generating schedule: %s.", err.Error()))
 		return
 	}
-	cachedMatches[matchType] = matches
+	cacheSchedule(matchType, matches)
 
-	// Determine each team's first match.
-	teamFirstMatches := make(map[int]string)
-	for _, match := range matches {
-		checkTeam := func(team int) {
-			_, ok := teamFirstMatches[team]
-			if !ok {
-				teamFirstMatches[team] = match.ShortName
-			}
-		}
-		checkTeam(match.Red1)
-		checkTeam(match.Red2)
-		checkTeam(match.Red3)
-		checkTeam(match.Blue1)
-		checkTeam(match.Blue2)
-		checkTeam(match.Blue3)
+	http.Redirect(w, r, "/setup/schedule?matchType="+matchTypeString, 303)
+}
+
+// Parses an externally generated schedule file and presents it for review without saving it.
+func (web *Web) scheduleImportPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
 	}
-	cachedTeamFirstMatches[matchType] = teamFirstMatches
+
+	matchTypeString := getMatchType(r)
+	matchType, err := model.MatchTypeFromString(matchTypeString)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	file, _, err := r.FormFile("scheduleFile")
+	if err != nil {
+		web.renderSchedule(w, r, "No schedule file was uploaded.")
+		return
+	}
+	defer file.Close()
+
+	teams, err := web.arena.Database.GetAllTeams()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if len(teams) == 0 {
+		web.renderSchedule(
+			w,
+			r,
+			"No team list is configured. Set up the list of teams at the event before importing the schedule.",
+		)
+		return
+	}
+	scheduleBlocks, err := web.arena.Database.GetScheduleBlocksByMatchType(matchType)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	matches, err := tournament.ImportSchedule(
+		file, teams, web.arena.EventSettings.TeamsPerAlliance(), scheduleBlocks, matchType,
+	)
+	if err != nil {
+		web.renderSchedule(w, r, fmt.Sprintf("Error importing schedule: %s.", err.Error()))
+		return
+	}
+	cacheSchedule(matchType, matches)
 
 	http.Redirect(w, r, "/setup/schedule?matchType="+matchTypeString, 303)
 }
@@ -158,6 +209,8 @@ func (web *Web) scheduleSavePostHandler(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
+	web.arena.UpdateNexusUpcomingMatches()
+
 	// Back up the database.
 	err = web.arena.Database.Backup(web.arena.EventSettings.Name, "post_scheduling")
 	if err != nil {
@@ -165,6 +218,13 @@ func (web *Web) scheduleSavePostHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if web.arena.EventSettings.TbaPublishingEnabled && matchType != model.Practice {
+		// Publish schedule to The Blue Alliance.
+		if err = web.arena.TbaClient.PublishMatches(web.arena.Database); err != nil {
+			log.Printf("Failed to publish matches: %s", err.Error())
+		}
+	}
+
 	http.Redirect(w, r, "/setup/schedule?matchType="+matchTypeString, 303)
 }
 
@@ -216,6 +276,29 @@ func (web *Web) renderSchedule(w http.ResponseWriter, r *http.Request, errorMess
 	}
 }
 
+// Holds the given schedule for review until it is saved, along with each team's first match.
+func cacheSchedule(matchType model.MatchType, matches []model.Match) {
+	cachedMatches[matchType] = matches
+
+	// Determine each team's first match.
+	teamFirstMatches := make(map[int]string)
+	for _, match := range matches {
+		checkTeam := func(team int) {
+			_, ok := teamFirstMatches[team]
+			if !ok && team != 0 {
+				teamFirstMatches[team] = match.ShortName
+			}
+		}
+		checkTeam(match.Red1)
+		checkTeam(match.Red2)
+		checkTeam(match.Red3)
+		checkTeam(match.Blue1)
+		checkTeam(match.Blue2)
+		checkTeam(match.Blue3)
+	}
+	cachedTeamFirstMatches[matchType] = teamFirstMatches
+}
+
 // Converts the post form variables into a slice of schedule blocks.
 func getScheduleBlocks(r *http.Request) ([]model.ScheduleBlock, error) {
 	numScheduleBlocks, err := strconv.Atoi(r.PostFormValue("numScheduleBlocks"))
@@ -4,6 +4,7 @@
 package web
 
 import (
+	"bytes"
 	"github.com/Team254/cheesy-arena/model"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -66,14 +67,6 @@ func TestSetupScheduleErrors(t *testing.T) {
 	assert.Equal(t, 200, recorder.Code)
 	assert.Contains(t, recorder.Body.String(), "There must be at least 6 teams to generate a schedule.")
 
-	// More matches per team than schedules exist for.
-	web.arena.Database.CreateTeam(&model.Team{Id: 118})
-	postData = "numScheduleBlocks=1&startTime0=2014-01-01 09:00:00 AM&numMatches0=700&matchSpacingSec0=480&" +
-		"matchType=practice"
-	recorder = web.postHttpResponse("/setup/schedule/generate", postData)
-	assert.Equal(t, 200, recorder.Code)
-	assert.Contains(t, recorder.Body.String(), "No schedule template exists for 6 teams and 700 matches")
-
 	// Incomplete scheduling data received.
 	postData = "numScheduleBlocks=1&startTime0=2014-01-01 09:00:00 AM&numMatches0=&matchSpacingSec0=480&" +
 		"matchType=practice"
@@ -95,3 +88,111 @@ func TestSetupScheduleErrors(t *testing.T) {
 	assert.Equal(t, 200, recorder.Code)
 	assert.Contains(t, recorder.Body.String(), "schedule of 2 Practice matches already exists")
 }
+
+func TestSetupScheduleImport(t *testing.T) {
+	web := setupTestWeb(t)
+	for i := 0; i < 6; i++ {
+		web.arena.Database.CreateTeam(&model.Team{Id: i + 101})
+	}
+
+	// The schedule CSV report should import back in unchanged.
+	web.arena.Database.CreateMatch(
+		&model.Match{
+			Type:             model.Practice,
+			Time:             time.Unix(1000, 0),
+			Red1:             101,
+			Red2:             102,
+			Red3:             103,
+			Blue1:            104,
+			Blue2:            105,
+			Blue3:            106,
+			Blue3IsSurrogate: true,
+		},
+	)
+	report := web.getHttpResponse("/reports/csv/schedule/practice").Body.String()
+	web.arena.Database.TruncateMatches()
+	recorder := web.postFileHttpResponse(
+		"/setup/schedule/import?matchType=practice", "scheduleFile", bytes.NewBufferString(report),
+	)
+	assert.Equal(t, 303, recorder.Code)
+	recorder = web.getHttpResponse("/setup/schedule?matchType=practice")
+	assert.Contains(t, recorder.Body.String(), "Practice 1")
+	recorder = web.postHttpResponse("/setup/schedule/save?matchType=practice", "")
+	assert.Equal(t, 303, recorder.Code)
+	matches, _ := web.arena.Database.GetMatchesByType(model.Practice, true)
+	if assert.Equal(t, 1, len(matches)) {
+		assert.Equal(t, "P1", matches[0].ShortName)
+		assert.Equal(t, int64(1000), matches[0].Time.Unix())
+		assert.Equal(t, 106, matches[0].Blue3)
+		assert.True(t, matches[0].Blue3IsSurrogate)
+	}
+
+	// Qualification schedules are held to a stricter standard for surrogates.
+	recorder = web.postFileHttpResponse(
+		"/setup/schedule/import?matchType=qualification", "scheduleFile", bytes.NewBufferString(report),
+	)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "most play 1 but these teams don&#39;t: 106 (0)")
+
+	// Invalid schedules should be rejected.
+	data := "Red1,Red2,Red3,Blue1,Blue2,Blue3\n101,102,103,104,105,<b>254</b>\n"
+	recorder = web.postFileHttpResponse(
+		"/setup/schedule/import?matchType=practice", "scheduleFile", bytes.NewBufferString(data),
+	)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(
+		t,
+		recorder.Body.String(),
+		"Error importing schedule: Row 1: invalid Blue3 team number &#39;&lt;b&gt;254&lt;/b&gt;&#39;",
+	)
+	recorder = web.postHttpResponse("/setup/schedule/import?matchType=practice", "")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "No schedule file was uploaded.")
+}
+
+func TestSetupScheduleGeneratorSeed(t *testing.T) {
+	web := setupTestWeb(t)
+	for i := 0; i < 18; i++ {
+		web.arena.Database.CreateTeam(&model.Team{Id: i + 101})
+	}
+
+	// The same seed should always produce the same schedule.
+	postData := "numScheduleBlocks=1&startTime0=2014-01-01 09:00:00 AM&numMatches0=24&matchSpacingSec0=480&" +
+		"matchType=practice&generatorSeed=254"
+	recorder := web.postHttpResponse("/setup/schedule/generate", postData)
+	assert.Equal(t, 303, recorder.Code)
+	matches := cachedMatches[model.Practice]
+	assert.Equal(t, 24, len(matches))
+	recorder = web.postHttpResponse("/setup/schedule/generate", postData)
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, matches, cachedMatches[model.Practice])
+
+	recorder = web.postHttpResponse("/setup/schedule/generate", postData+"x")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "The generator seed must be a whole number.")
+}
+
+func TestSetupScheduleTwoVsTwo(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.EventSettings.TwoVsTwoMode = true
+	for i := 0; i < 5; i++ {
+		web.arena.Database.CreateTeam(&model.Team{Id: i + 101})
+	}
+
+	postData := "numScheduleBlocks=1&startTime0=2014-01-01 09:00:00 AM&numMatches0=10&matchSpacingSec0=480&" +
+		"matchType=practice"
+	recorder := web.postHttpResponse("/setup/schedule/generate", postData)
+	assert.Equal(t, 303, recorder.Code)
+	matches := cachedMatches[model.Practice]
+	assert.Equal(t, 10, len(matches))
+	for _, match := range matches {
+		assert.Equal(t, 0, match.Red3)
+		assert.Equal(t, 0, match.Blue3)
+	}
+	assert.Equal(t, 5, len(cachedTeamFirstMatches[model.Practice]))
+
+	web.arena.EventSettings.TwoVsTwoMode = false
+	recorder = web.postHttpResponse("/setup/schedule/generate", postData)
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "There must be at least 6 teams to generate a schedule.")
+}
@@ -9,13 +9,16 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"log"
 	"net/http"
 	"os"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/Team254/cheesy-arena/lighting"
 	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
 )
 
 // Shows the event settings editing page.
@@ -68,6 +71,70 @@ func (web *Web) settingsPostHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
+	for _, actionsField := range []string{
+		"obsMatchLoadActions",
+		"obsMatchStartActions",
+		"obsMatchEndActions",
+		"obsScorePostedActions",
+		"obsTimeoutActions",
+		"obsAllianceSelectionActions",
+	} {
+		if _, err := partner.ParseObsActions(r.PostFormValue(actionsField)); err != nil {
+			web.renderSettings(w, r, err.Error())
+			return
+		}
+	}
+	var lightingProtocol model.LightingProtocol
+	switch r.PostFormValue("lightingProtocol") {
+	case "SacnLighting":
+		lightingProtocol = model.SacnLighting
+	case "ArtNetLighting":
+		lightingProtocol = model.ArtNetLighting
+	default:
+		lightingProtocol = model.NoLighting
+	}
+	lightingUniverse, _ := strconv.Atoi(r.PostFormValue("lightingUniverse"))
+	if err := lighting.CheckUniverse(lightingProtocol, lightingUniverse); err != nil {
+		web.renderSettings(w, r, err.Error()+".")
+		return
+	}
+	if lightingProtocol == model.ArtNetLighting && r.PostFormValue("lightingAddress") == "" {
+		web.renderSettings(w, r, "An address is required for Art-Net lighting output.")
+		return
+	}
+	for _, cueField := range []string{
+		"lightingIdleCue",
+		"lightingReadyCue",
+		"lightingAutoCue",
+		"lightingTeleopCue",
+		"lightingEndgameCue",
+		"lightingRedWinCue",
+		"lightingBlueWinCue",
+		"lightingTieCue",
+	} {
+		cue, err := lighting.ParseCue(r.PostFormValue(cueField), lightingUniverse)
+		if err == nil {
+			for _, universe := range cue.Universes() {
+				if err = lighting.CheckUniverse(lightingProtocol, universe); err != nil {
+					break
+				}
+			}
+		}
+		if err != nil {
+			web.renderSettings(w, r, fmt.Sprintf("Invalid lighting cue: %v", err))
+			return
+		}
+	}
+	for _, cuesField := range []string{"oscMatchStartCues", "oscMatchEndCues", "oscMatchAbortCues", "oscSoundCues"} {
+		if _, err := partner.ParseOscCues(r.PostFormValue(cuesField)); err != nil {
+			web.renderSettings(w, r, fmt.Sprintf("Invalid OSC cue: %v", err))
+			return
+		}
+	}
+	if strings.ContainsAny(r.PostFormValue("mqttTopicPrefix"), "+#") {
+		web.renderSettings(w, r, "MQTT topic prefix cannot contain wildcard characters.")
+		return
+	}
 	eventSettings.PlayoffType = playoffType
 
 	eventSettings.NumPlayoffAlliances = numAlliances
@@ -79,8 +146,23 @@ func (web *Web) settingsPostHandler(w http.ResponseWriter, r *http.Request) {
 	eventSettings.ApAddress = r.PostFormValue("apAddress")
 	eventSettings.ApPassword = r.PostFormValue("apPassword")
 	eventSettings.ApChannel, _ = strconv.Atoi(r.PostFormValue("apChannel"))
+	if r.PostFormValue("switchType") == "OpenWrtSwitch" {
+		eventSettings.SwitchType = model.OpenWrtSwitch
+	} else {
+		eventSettings.SwitchType = model.CiscoSwitch
+	}
 	eventSettings.SwitchAddress = r.PostFormValue("switchAddress")
 	eventSettings.SwitchPassword = r.PostFormValue("switchPassword")
+	eventSettings.TbaDownloadEnabled = r.PostFormValue("tbaDownloadEnabled") == "on"
+	eventSettings.TbaApiKey = r.PostFormValue("tbaApiKey")
+	eventSettings.TbaPublishingEnabled = r.PostFormValue("tbaPublishingEnabled") == "on"
+	eventSettings.TbaEventCode = r.PostFormValue("tbaEventCode")
+	eventSettings.TbaSecretId = r.PostFormValue("tbaSecretId")
+	eventSettings.TbaSecret = r.PostFormValue("tbaSecret")
+	eventSettings.NexusEnabled = r.PostFormValue("nexusEnabled") == "on"
+	eventSettings.NexusApiKey = r.PostFormValue("nexusApiKey")
+	eventSettings.RadioKioskAddress = r.PostFormValue("radioKioskAddress")
+	eventSettings.RadioKioskPassword = r.PostFormValue("radioKioskPassword")
 	eventSettings.PlcAddress = r.PostFormValue("plcAddress")
 	eventSettings.AdminPassword = r.PostFormValue("adminPassword")
 	eventSettings.TeamSignRed1Id, _ = strconv.Atoi(r.PostFormValue("teamSignRed1Id"))
@@ -93,6 +175,36 @@ func (web *Web) settingsPostHandler(w http.ResponseWriter, r *http.Request) {
 	eventSettings.TeamSignBlueTimerId, _ = strconv.Atoi(r.PostFormValue("teamSignBlueTimerId"))
 	eventSettings.UseLiteUdpPort = r.PostFormValue("useLiteUdpPort") == "on"
 	eventSettings.BlackmagicAddresses = r.PostFormValue("blackmagicAddresses")
+	eventSettings.MatchVideoDirectory = r.PostFormValue("matchVideoDirectory")
+	eventSettings.ObsAddress = r.PostFormValue("obsAddress")
+	eventSettings.ObsPassword = r.PostFormValue("obsPassword")
+	eventSettings.ObsRecordingEnabled = r.PostFormValue("obsRecordingEnabled") == "on"
+	eventSettings.ObsMatchLoadActions = r.PostFormValue("obsMatchLoadActions")
+	eventSettings.ObsMatchStartActions = r.PostFormValue("obsMatchStartActions")
+	eventSettings.ObsMatchEndActions = r.PostFormValue("obsMatchEndActions")
+	eventSettings.ObsScorePostedActions = r.PostFormValue("obsScorePostedActions")
+	eventSettings.ObsTimeoutActions = r.PostFormValue("obsTimeoutActions")
+	eventSettings.ObsAllianceSelectionActions = r.PostFormValue("obsAllianceSelectionActions")
+	eventSettings.LightingProtocol = lightingProtocol
+	eventSettings.LightingAddress = r.PostFormValue("lightingAddress")
+	if lightingProtocol != model.NoLighting {
+		eventSettings.LightingUniverse = lightingUniverse
+	}
+	eventSettings.LightingIdleCue = r.PostFormValue("lightingIdleCue")
+	eventSettings.LightingReadyCue = r.PostFormValue("lightingReadyCue")
+	eventSettings.LightingAutoCue = r.PostFormValue("lightingAutoCue")
+	eventSettings.LightingTeleopCue = r.PostFormValue("lightingTeleopCue")
+	eventSettings.LightingEndgameCue = r.PostFormValue("lightingEndgameCue")
+	eventSettings.LightingRedWinCue = r.PostFormValue("lightingRedWinCue")
+	eventSettings.LightingBlueWinCue = r.PostFormValue("lightingBlueWinCue")
+	eventSettings.LightingTieCue = r.PostFormValue("lightingTieCue")
+	eventSettings.OscTargets = r.PostFormValue("oscTargets")
+	eventSettings.OscMatchStartCues = r.PostFormValue("oscMatchStartCues")
+	eventSettings.OscMatchEndCues = r.PostFormValue("oscMatchEndCues")
+	eventSettings.OscMatchAbortCues = r.PostFormValue("oscMatchAbortCues")
+	eventSettings.OscSoundCues = r.PostFormValue("oscSoundCues")
+	eventSettings.MqttBrokerAddress = r.PostFormValue("mqttBrokerAddress")
+	eventSettings.MqttTopicPrefix = r.PostFormValue("mqttTopicPrefix")
 	eventSettings.WarmupDurationSec, _ = strconv.Atoi(r.PostFormValue("warmupDurationSec"))
 	eventSettings.AutoDurationSec, _ = strconv.Atoi(r.PostFormValue("autoDurationSec"))
 	eventSettings.PauseDurationSec, _ = strconv.Atoi(r.PostFormValue("pauseDurationSec"))
@@ -257,6 +369,34 @@ func (web *Web) clearDbHandler(w http.ResponseWriter, r *http.Request) {
 		web.arena.AllianceSelectionRankedTeams = []model.AllianceSelectionRankedTeam{}
 	}
 
+	if web.arena.EventSettings.TbaPublishingEnabled && matchType != model.Practice {
+		// Remove the cleared matches from The Blue Alliance and republish whatever remains.
+		if err = web.arena.TbaClient.DeletePublishedMatches(); err != nil {
+			log.Printf("Failed to delete published matches: %s", err.Error())
+		}
+		if err = web.arena.TbaClient.PublishMatches(web.arena.Database); err != nil {
+			log.Printf("Failed to publish matches: %s", err.Error())
+		}
+	}
+
+	http.Redirect(w, r, "/setup/settings", 303)
+}
+
+// Publishes all event data to The Blue Alliance, for use when enabling publishing partway through an event.
+func (web *Web) publishAllDataHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	if !web.arena.EventSettings.TbaPublishingEnabled {
+		web.renderSettings(w, r, "TBA publishing is not enabled.")
+		return
+	}
+	if err := web.arena.TbaClient.PublishAll(web.arena.Database); err != nil {
+		web.renderSettings(w, r, fmt.Sprintf("Failed to publish data to TBA: %s", err.Error()))
+		return
+	}
+
 	http.Redirect(w, r, "/setup/settings", 303)
 }
 
@@ -268,8 +408,9 @@ func (web *Web) renderSettings(w http.ResponseWriter, r *http.Request, errorMess
 	}
 	data := struct {
 		*model.EventSettings
-		ErrorMessage string
-	}{web.arena.EventSettings, errorMessage}
+		ErrorMessage        string
+		TbaPublishingStatus partner.TbaPublishingStatus
+	}{web.arena.EventSettings, errorMessage, web.arena.TbaClient.Status()}
 	err = template.ExecuteTemplate(w, "base", data)
 	if err != nil {
 		handleWebErr(w, err)
@@ -299,6 +440,12 @@ func (web *Web) deleteMatchDataForType(matchType model.MatchType) error {
 			}
 		}
 
+		if err = web.arena.Database.DeleteWifiHistoriesForMatch(match.Id); err != nil {
+			return err
+		}
+		if err = web.arena.Database.DeleteMatchVideosForMatch(match.Id); err != nil {
+			return err
+		}
 		if err = web.arena.Database.DeleteMatch(match.Id); err != nil {
 			return err
 		}
@@ -9,7 +9,9 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/Team254/cheesy-arena/game"
 	"github.com/Team254/cheesy-arena/model"
@@ -46,6 +48,172 @@ func TestSetupSettingsDoubleElimination(t *testing.T) {
 	assert.Equal(t, 8, web.arena.EventSettings.NumPlayoffAlliances)
 }
 
+func TestSetupSettingsSwitchType(t *testing.T) {
+	web := setupTestWeb(t)
+	assert.Equal(t, model.CiscoSwitch, web.arena.EventSettings.SwitchType)
+
+	recorder := web.postHttpResponse("/setup/settings", "switchType=OpenWrtSwitch&switchAddress=10.0.100.3")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, model.OpenWrtSwitch, web.arena.EventSettings.SwitchType)
+	assert.Equal(t, "10.0.100.3", web.arena.EventSettings.SwitchAddress)
+
+	recorder = web.postHttpResponse("/setup/settings", "switchType=CiscoSwitch")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, model.CiscoSwitch, web.arena.EventSettings.SwitchType)
+}
+
+func TestSetupSettingsTbaPublishing(t *testing.T) {
+	web := setupTestWeb(t)
+	var publishedPaths []string
+	var mutex sync.Mutex
+	tbaServer := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				mutex.Lock()
+				defer mutex.Unlock()
+				publishedPaths = append(publishedPaths, r.URL.Path)
+			},
+		),
+	)
+	defer tbaServer.Close()
+	web.arena.TbaClient.BaseUrl = tbaServer.URL
+	go web.arena.TbaClient.Run()
+
+	recorder := web.postHttpResponse("/setup/settings/publish_all", "")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "TBA publishing is not enabled.")
+	assert.Empty(t, publishedPaths)
+
+	recorder = web.postHttpResponse(
+		"/setup/settings", "tbaPublishingEnabled=on&tbaEventCode=2026test&tbaSecretId=id&tbaSecret=secret",
+	)
+	assert.Equal(t, 303, recorder.Code)
+	assert.True(t, web.arena.EventSettings.TbaPublishingEnabled)
+	assert.Equal(t, "2026test", web.arena.EventSettings.TbaEventCode)
+	assert.Equal(t, "id", web.arena.EventSettings.TbaSecretId)
+	assert.Equal(t, "secret", web.arena.EventSettings.TbaSecret)
+
+	recorder = web.postHttpResponse("/setup/settings/publish_all", "")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Eventually(
+		t, func() bool { return web.arena.TbaClient.Status().QueuedRequests == 0 }, time.Second, time.Millisecond,
+	)
+	mutex.Lock()
+	defer mutex.Unlock()
+	assert.Equal(
+		t,
+		[]string{
+			"/api/trusted/v1/event/2026test/team_list/update",
+			"/api/trusted/v1/event/2026test/matches/update",
+			"/api/trusted/v1/event/2026test/rankings/update",
+			"/api/trusted/v1/event/2026test/alliance_selections/update",
+			"/api/trusted/v1/event/2026test/awards/update",
+		},
+		publishedPaths,
+	)
+	recorder = web.getHttpResponse("/setup/settings")
+	assert.Contains(t, recorder.Body.String(), "Up to date")
+}
+
+func TestSetupSettingsObs(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.postHttpResponse(
+		"/setup/settings",
+		"obsAddress=10.0.100.70:4455&obsPassword=secret&obsRecordingEnabled=on&obsMatchLoadActions=Intro&"+
+			"obsMatchStartActions=Match%3BMatch/Score Bug&obsScorePostedActions=Match/-Score Bug%3BScore",
+	)
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, "10.0.100.70:4455", web.arena.EventSettings.ObsAddress)
+	assert.Equal(t, "secret", web.arena.EventSettings.ObsPassword)
+	assert.True(t, web.arena.EventSettings.ObsRecordingEnabled)
+	assert.Equal(t, "Intro", web.arena.EventSettings.ObsMatchLoadActions)
+	assert.Equal(t, "Match;Match/Score Bug", web.arena.EventSettings.ObsMatchStartActions)
+	assert.Equal(t, "Match/-Score Bug;Score", web.arena.EventSettings.ObsScorePostedActions)
+
+	// Invalid actions should be rejected.
+	recorder = web.postHttpResponse("/setup/settings", "obsAddress=10.0.100.70&obsTimeoutActions=Timeout/")
+	assert.Contains(t, recorder.Body.String(), "OBS action \"Timeout/\" is missing a source name")
+	assert.Equal(t, "Intro", web.arena.EventSettings.ObsMatchLoadActions)
+}
+
+func TestSetupSettingsLighting(t *testing.T) {
+	web := setupTestWeb(t)
+	assert.Equal(t, model.NoLighting, web.arena.EventSettings.LightingProtocol)
+	assert.Equal(t, 1, web.arena.EventSettings.LightingUniverse)
+
+	recorder := web.postHttpResponse(
+		"/setup/settings",
+		"lightingProtocol=SacnLighting&lightingUniverse=5&lightingAutoCue=1-3=255&"+
+			"lightingEndgameCue=1=255|1=0&lightingRedWinCue=1=255 2=0 3=0&lightingBlueWinCue=1=0 6:1-3=255",
+	)
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, model.SacnLighting, web.arena.EventSettings.LightingProtocol)
+	assert.Equal(t, 5, web.arena.EventSettings.LightingUniverse)
+	assert.Equal(t, "1-3=255", web.arena.EventSettings.LightingAutoCue)
+	assert.Equal(t, "1=255|1=0", web.arena.EventSettings.LightingEndgameCue)
+	assert.Equal(t, "1=255 2=0 3=0", web.arena.EventSettings.LightingRedWinCue)
+	assert.Equal(t, "1=0 6:1-3=255", web.arena.EventSettings.LightingBlueWinCue)
+
+	recorder = web.postHttpResponse(
+		"/setup/settings", "lightingProtocol=ArtNetLighting&lightingAddress=10.0.100.80&lightingUniverse=0",
+	)
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, model.ArtNetLighting, web.arena.EventSettings.LightingProtocol)
+	assert.Equal(t, "10.0.100.80", web.arena.EventSettings.LightingAddress)
+	assert.Equal(t, 0, web.arena.EventSettings.LightingUniverse)
+
+	// Invalid values should be rejected.
+	recorder = web.postHttpResponse("/setup/settings", "lightingProtocol=SacnLighting&lightingUniverse=0")
+	assert.Contains(t, recorder.Body.String(), "sACN universe must be between 1 and 63999.")
+	recorder = web.postHttpResponse("/setup/settings", "lightingProtocol=ArtNetLighting&lightingUniverse=1")
+	assert.Contains(t, recorder.Body.String(), "An address is required for Art-Net lighting output.")
+	recorder = web.postHttpResponse("/setup/settings", "lightingTieCue=600=1")
+	assert.Contains(t, recorder.Body.String(), "Invalid lighting cue: invalid channel")
+	recorder = web.postHttpResponse(
+		"/setup/settings", "lightingProtocol=ArtNetLighting&lightingAddress=10.0.100.80&lightingTieCue=40000:1=1",
+	)
+	assert.Contains(t, recorder.Body.String(), "Invalid lighting cue: Art-Net universe must be between 0 and 32767")
+	assert.Equal(t, model.ArtNetLighting, web.arena.EventSettings.LightingProtocol)
+}
+
+func TestSetupSettingsOsc(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.postHttpResponse(
+		"/setup/settings",
+		"oscTargets=10.0.100.90:53000,10.0.100.91:10023&oscMatchStartCues=/cue/{match}/start&"+
+			"oscMatchAbortCues=/cue/abort%3B/ch/01/mix/on 0&oscSoundCues=/sound {sound}",
+	)
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, "10.0.100.90:53000,10.0.100.91:10023", web.arena.EventSettings.OscTargets)
+	assert.Equal(t, "/cue/{match}/start", web.arena.EventSettings.OscMatchStartCues)
+	assert.Equal(t, "/cue/abort;/ch/01/mix/on 0", web.arena.EventSettings.OscMatchAbortCues)
+	assert.Equal(t, "/sound {sound}", web.arena.EventSettings.OscSoundCues)
+
+	// Invalid cues should be rejected.
+	recorder = web.postHttpResponse("/setup/settings", "oscMatchEndCues=cue/1/start")
+	assert.Contains(t, recorder.Body.String(), "Invalid OSC cue: OSC address \"cue/1/start\" must start with \"/\"")
+	assert.Equal(t, "", web.arena.EventSettings.OscMatchEndCues)
+}
+
+func TestSetupSettingsMqtt(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.postHttpResponse("/setup/settings", "mqttBrokerAddress=10.0.100.60:1883&mqttTopicPrefix=field1")
+	assert.Equal(t, 303, recorder.Code)
+	assert.Equal(t, "10.0.100.60:1883", web.arena.EventSettings.MqttBrokerAddress)
+	assert.Equal(t, "field1", web.arena.EventSettings.MqttTopicPrefix)
+
+	recorder = web.getHttpResponse("/match_play")
+	assert.Contains(t, recorder.Body.String(), "mqttStatus")
+
+	// Wildcards aren't allowed in the prefix.
+	recorder = web.postHttpResponse("/setup/settings", "mqttBrokerAddress=10.0.100.60&mqttTopicPrefix=field/%23")
+	assert.Contains(t, recorder.Body.String(), "MQTT topic prefix cannot contain wildcard characters.")
+	assert.Equal(t, "field1", web.arena.EventSettings.MqttTopicPrefix)
+}
+
 func TestSetupSettingsInvalidValues(t *testing.T) {
 	web := setupTestWeb(t)
 	recorder := web.postHttpResponse("/setup/settings", "playoffType=SingleEliminationPlayoff&numPlayoffAlliances=8")
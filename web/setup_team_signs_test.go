@@ -0,0 +1,42 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"testing"
+
+	"github.com/Team254/cheesy-arena/websocket"
+	gorillawebsocket "github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetupTeamSigns(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/setup/team_signs")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Team Signs")
+}
+
+func TestSetupTeamSignsWebsocket(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.TeamSigns.Red1.SetId(51)
+	web.arena.TeamSigns.Update(web.arena)
+
+	server, wsUrl := web.startTestServer()
+	defer server.Close()
+	conn, _, err := gorillawebsocket.DefaultDialer.Dial(wsUrl+"/setup/team_signs/websocket", nil)
+	assert.Nil(t, err)
+	defer conn.Close()
+	ws := websocket.NewTestWebsocket(conn)
+
+	signs, ok := readWebsocketType(t, ws, "teamSigns").([]any)
+	if assert.True(t, ok) && assert.Equal(t, 8, len(signs)) {
+		sign := signs[0].(map[string]any)
+		assert.Equal(t, "Red 1", sign["Name"])
+		assert.Equal(t, 51.0, sign["Id"])
+		assert.Equal(t, "#ff0000", sign["FrontColor"])
+		assert.Equal(t, "Blue Timer", signs[7].(map[string]any)["Name"])
+	}
+}
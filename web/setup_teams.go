@@ -7,15 +7,23 @@ package web
 
 import (
 	"fmt"
+	"log"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Team254/cheesy-arena/model"
 	"github.com/dchest/uniuri"
 )
 
-const wpaKeyLength = 8
+const (
+	wpaKeyLength               = 8
+	recentAwardYears           = 2
+	teamAvailabilityTimeFormat = "2006-01-02 03:04 PM"
+)
 
 // Global var to hold the team download progress percentage.
 var progressPercentage float64 = 5
@@ -50,8 +58,17 @@ func (web *Web) teamsPostHandler(w http.ResponseWriter, r *http.Request) {
 
 	progressPercentage = 5
 	progressIncrement := 95.0 / float64(len(teamNumbers))
+	downloadFromTba := web.arena.EventSettings.TbaDownloadEnabled && web.arena.TbaClient.CanDownload()
 	for _, teamNumber := range teamNumbers {
 		team := model.Team{Id: teamNumber}
+		if downloadFromTba {
+			if err := web.populateTeamFromTba(&team); err != nil {
+				// Stop trying to reach TBA for the rest of the import so that it doesn't stall; the remaining teams
+				// are created without details, which can be filled in by hand.
+				log.Printf("Failed to download data for team %d from TBA: %v", teamNumber, err)
+				downloadFromTba = false
+			}
+		}
 
 		if err := web.arena.Database.CreateTeam(&team); err != nil {
 			handleWebErr(w, err)
@@ -62,6 +79,12 @@ func (web *Web) teamsPostHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	progressPercentage = 100
 
+	if web.arena.EventSettings.TbaPublishingEnabled {
+		if err := web.arena.TbaClient.PublishTeams(web.arena.Database); err != nil {
+			log.Printf("Failed to publish teams: %s", err.Error())
+		}
+	}
+
 	http.Redirect(w, r, "/setup/teams", 303)
 }
 
@@ -109,7 +132,14 @@ func (web *Web) teamEditGetHandler(w http.ResponseWriter, r *http.Request) {
 	data := struct {
 		*model.EventSettings
 		*model.Team
-	}{web.arena.EventSettings, team}
+		AvailableFromText  string
+		AvailableUntilText string
+	}{
+		web.arena.EventSettings,
+		team,
+		formatTeamAvailabilityTime(team.AvailableFrom),
+		formatTeamAvailabilityTime(team.AvailableUntil),
+	}
 	err = template.ExecuteTemplate(w, "base", data)
 	if err != nil {
 		handleWebErr(w, err)
@@ -144,13 +174,30 @@ func (web *Web) teamEditPostHandler(w http.ResponseWriter, r *http.Request) {
 	team.RobotName = r.PostFormValue("robotName")
 	team.Accomplishments = r.PostFormValue("accomplishments")
 	if web.arena.EventSettings.NetworkSecurityEnabled {
-		team.WpaKey = r.PostFormValue("wpaKey")
-		if len(team.WpaKey) < 8 || len(team.WpaKey) > 63 {
+		wpaKey := r.PostFormValue("wpaKey")
+		if len(wpaKey) < 8 || len(wpaKey) > 63 {
 			handleWebErr(w, fmt.Errorf("WPA key must be between 8 and 63 characters."))
 			return
 		}
+		if wpaKey != team.WpaKey {
+			// The team's radio will need to be reprogrammed with the new key.
+			team.WpaKey = wpaKey
+			team.RadioConfiguredAt = time.Time{}
+		}
 	}
 	team.HasConnected = r.PostFormValue("hasConnected") == "on"
+	if team.AvailableFrom, err = parseTeamAvailabilityTime(r.PostFormValue("availableFrom")); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if team.AvailableUntil, err = parseTeamAvailabilityTime(r.PostFormValue("availableUntil")); err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	if hasEmptyTeamAvailability(team) {
+		handleWebErr(w, fmt.Errorf("The end of the team's availability must be after the start."))
+		return
+	}
 	err = web.arena.Database.UpdateTeam(team)
 	if err != nil {
 		handleWebErr(w, err)
@@ -207,6 +254,7 @@ func (web *Web) teamsGenerateWpaKeysHandler(w http.ResponseWriter, r *http.Reque
 	for _, team := range teams {
 		if len(team.WpaKey) == 0 || generateAllKeys {
 			team.WpaKey = uniuri.NewLen(wpaKeyLength)
+			team.RadioConfiguredAt = time.Time{}
 			web.arena.Database.UpdateTeam(&team)
 		}
 	}
@@ -244,6 +292,56 @@ func (web *Web) renderTeams(w http.ResponseWriter, r *http.Request, showErrorMes
 	}
 }
 
+// Fills in the given team's details, robot name, recent awards and avatar from The Blue Alliance.
+func (web *Web) populateTeamFromTba(team *model.Team) error {
+	tbaTeam, err := web.arena.TbaClient.GetTeam(team.Id)
+	if err != nil {
+		return err
+	}
+	if tbaTeam == nil {
+		// TBA doesn't know about this team (e.g. an offseason-only number); leave it blank.
+		return nil
+	}
+	team.Name = tbaTeam.Name
+	team.Nickname = tbaTeam.Nickname
+	team.City = tbaTeam.City
+	team.StateProv = tbaTeam.StateProv
+	team.Country = tbaTeam.Country
+	team.SchoolName = tbaTeam.SchoolName
+	team.RookieYear = tbaTeam.RookieYear
+
+	year := time.Now().Year()
+	if team.RobotName, err = web.arena.TbaClient.GetRobotName(team.Id, year); err != nil {
+		return err
+	}
+
+	awards, err := web.arena.TbaClient.GetTeamAwards(team.Id)
+	if err != nil {
+		return err
+	}
+	var accomplishments []string
+	for _, award := range awards {
+		if year-award.Year <= recentAwardYears {
+			accomplishments = append(
+				accomplishments, fmt.Sprintf("%d %s - %s", award.Year, award.EventName, award.Name),
+			)
+		}
+	}
+	team.Accomplishments = strings.Join(accomplishments, "\n")
+
+	avatar, err := web.arena.TbaClient.GetTeamAvatar(team.Id, year)
+	if err != nil {
+		return err
+	}
+	if avatar != nil {
+		avatarPath := filepath.Join(model.BaseDir, AvatarsDir, fmt.Sprintf("%d.png", team.Id))
+		if err = os.WriteFile(avatarPath, avatar, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Returns true if it is safe to change the team list (i.e. no matches/results exist yet).
 func (web *Web) canModifyTeamList() bool {
 	matches, err := web.arena.Database.GetMatchesByType(model.Qualification, true)
@@ -252,3 +350,30 @@ func (web *Web) canModifyTeamList() bool {
 	}
 	return true
 }
+
+// Returns the given team availability time in the format used for editing it, or an empty string if it isn't set.
+func formatTeamAvailabilityTime(availabilityTime time.Time) string {
+	if availabilityTime.IsZero() {
+		return ""
+	}
+	return availabilityTime.Local().Format(teamAvailabilityTimeFormat)
+}
+
+// Parses the given team availability time in the local time zone, treating an empty value as no limit.
+func parseTeamAvailabilityTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	availabilityTime, err := time.ParseInLocation(teamAvailabilityTimeFormat, value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Invalid availability time '%s'; the format is YYYY-MM-DD HH:MM AM/PM.", value)
+	}
+	return availabilityTime, nil
+}
+
+// Returns true if the given team's availability is limited at both ends in a way that leaves no time at all.
+func hasEmptyTeamAvailability(team *model.Team) bool {
+	return !team.AvailableFrom.IsZero() && !team.AvailableUntil.IsZero() &&
+		!team.AvailableUntil.After(team.AvailableFrom)
+}
@@ -0,0 +1,529 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web routes for bulk importing and exporting the full team list as CSV or JSON.
+
+package web
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Team254/cheesy-arena/model"
+)
+
+const (
+	teamImportModeMerge     = "merge"
+	teamImportModeOverwrite = "overwrite"
+)
+
+// A team field that can be imported and exported, along with the alternative column headers that map to it.
+type teamImportField struct {
+	name    string
+	aliases []string
+	get     func(team *model.Team) string
+	set     func(team *model.Team, value string) error
+}
+
+// Fields in the order in which they are exported. The column headers match those of the team list CSV report so that
+// it can be imported back in.
+var teamImportFields = []*teamImportField{
+	{
+		name:    "Number",
+		aliases: []string{"id", "team", "teamnumber", "teamid"},
+		get:     func(team *model.Team) string { return strconv.Itoa(team.Id) },
+		set: func(team *model.Team, value string) error {
+			teamId, err := strconv.Atoi(value)
+			if err != nil || teamId <= 0 {
+				return fmt.Errorf("invalid team number '%s'", value)
+			}
+			team.Id = teamId
+			return nil
+		},
+	},
+	stringTeamImportField("Name", []string{"fullname"}, func(team *model.Team) *string { return &team.Name }),
+	stringTeamImportField("Nickname", nil, func(team *model.Team) *string { return &team.Nickname }),
+	stringTeamImportField("SchoolName", []string{"school"}, func(team *model.Team) *string { return &team.SchoolName }),
+	stringTeamImportField("City", nil, func(team *model.Team) *string { return &team.City }),
+	stringTeamImportField(
+		"StateProv", []string{"state", "province"}, func(team *model.Team) *string { return &team.StateProv },
+	),
+	stringTeamImportField("Country", nil, func(team *model.Team) *string { return &team.Country }),
+	{
+		name:    "RookieYear",
+		aliases: []string{"rookie"},
+		get:     func(team *model.Team) string { return strconv.Itoa(team.RookieYear) },
+		set: func(team *model.Team, value string) error {
+			if value == "" {
+				team.RookieYear = 0
+				return nil
+			}
+			rookieYear, err := strconv.Atoi(value)
+			if err != nil || rookieYear < 0 {
+				return fmt.Errorf("invalid rookie year '%s'", value)
+			}
+			team.RookieYear = rookieYear
+			return nil
+		},
+	},
+	stringTeamImportField("RobotName", []string{"robot"}, func(team *model.Team) *string { return &team.RobotName }),
+	stringTeamImportField("Accomplishments", nil, func(team *model.Team) *string { return &team.Accomplishments }),
+	{
+		name: "WpaKey",
+		get:  func(team *model.Team) string { return team.WpaKey },
+		set: func(team *model.Team, value string) error {
+			if value != "" && (len(value) < 8 || len(value) > 63) {
+				return fmt.Errorf("WPA key must be between 8 and 63 characters")
+			}
+			team.WpaKey = value
+			return nil
+		},
+	},
+	stringTeamImportField("FtaNotes", nil, func(team *model.Team) *string { return &team.FtaNotes }),
+	{
+		name: "HasConnected",
+		get:  func(team *model.Team) string { return strconv.FormatBool(team.HasConnected) },
+		set: func(team *model.Team, value string) error {
+			if value == "" {
+				team.HasConnected = false
+				return nil
+			}
+			hasConnected, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("invalid value '%s' for HasConnected", value)
+			}
+			team.HasConnected = hasConnected
+			return nil
+		},
+	},
+	timeTeamImportField(
+		"AvailableFrom", []string{"notbefore"}, func(team *model.Team) *time.Time { return &team.AvailableFrom },
+	),
+	timeTeamImportField(
+		"AvailableUntil", []string{"notafter"}, func(team *model.Team) *time.Time { return &team.AvailableUntil },
+	),
+}
+
+// A single team parsed from the import data, along with the fields that were given for it.
+type teamImportRecord struct {
+	team   model.Team
+	fields []*teamImportField
+}
+
+// The effect that an import will have on a single team, for display in the preview.
+type teamImportChange struct {
+	TeamId      int
+	Action      string
+	Differences []string
+	oldTeam     *model.Team
+	newTeam     *model.Team
+}
+
+// Exports the full team list, including all fields, in the format given by the "format" query parameter.
+func (web *Web) teamsExportHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	teams, err := web.arena.Database.GetAllTeams()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	var buffer bytes.Buffer
+	format := r.URL.Query().Get("format")
+	switch format {
+	case "json":
+		records := make([]map[string]any, len(teams))
+		for i, team := range teams {
+			records[i] = map[string]any{}
+			for _, field := range teamImportFields {
+				records[i][field.name] = field.get(&team)
+			}
+			records[i]["Number"] = team.Id
+			records[i]["RookieYear"] = team.RookieYear
+			records[i]["HasConnected"] = team.HasConnected
+		}
+		jsonData, err := json.MarshalIndent(records, "", "  ")
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		buffer.Write(jsonData)
+		w.Header().Set("Content-Type", "application/json")
+	case "csv":
+		writer := csv.NewWriter(&buffer)
+		var header []string
+		for _, field := range teamImportFields {
+			header = append(header, field.name)
+		}
+		writer.Write(header)
+		for _, team := range teams {
+			var row []string
+			for _, field := range teamImportFields {
+				row = append(row, field.get(&team))
+			}
+			writer.Write(row)
+		}
+		writer.Flush()
+		w.Header().Set("Content-Type", "text/csv")
+	default:
+		http.Error(w, fmt.Sprintf("Error: Invalid export format '%s'.", format), 400)
+		return
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"teams.%s\"", format))
+	w.Write(buffer.Bytes())
+}
+
+// Parses the uploaded or pasted team data and shows a preview of the changes that importing it would make.
+func (web *Web) teamsImportPreviewHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	data := r.PostFormValue("data")
+	if file, _, err := r.FormFile("file"); err == nil {
+		fileData, err := io.ReadAll(file)
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+		data = string(fileData)
+	}
+	web.renderTeamsImport(w, r, data, r.PostFormValue("mode"), false)
+}
+
+// Applies the given team data to the team list, after validating it in the same way as for the preview.
+func (web *Web) teamsImportPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	data := r.PostFormValue("data")
+	mode := r.PostFormValue("mode")
+	changes, errors := web.planTeamImport(data, mode)
+	if len(errors) > 0 {
+		web.renderTeamsImport(w, r, data, mode, true)
+		return
+	}
+
+	for _, change := range changes {
+		var err error
+		switch change.Action {
+		case "Add":
+			err = web.arena.Database.CreateTeam(change.newTeam)
+		case "Update":
+			if change.newTeam.WpaKey != change.oldTeam.WpaKey {
+				// The team's radio will need to be reprogrammed with the new key.
+				change.newTeam.RadioConfiguredAt = time.Time{}
+			}
+			err = web.arena.Database.UpdateTeam(change.newTeam)
+		case "Delete":
+			err = web.arena.Database.DeleteTeam(change.TeamId)
+		}
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+	}
+
+	if web.arena.EventSettings.TbaPublishingEnabled {
+		if err := web.arena.TbaClient.PublishTeams(web.arena.Database); err != nil {
+			log.Printf("Failed to publish teams: %s", err.Error())
+		}
+	}
+
+	http.Redirect(w, r, "/setup/teams", 303)
+}
+
+func (web *Web) renderTeamsImport(w http.ResponseWriter, r *http.Request, data, mode string, showErrors bool) {
+	if mode != teamImportModeOverwrite {
+		mode = teamImportModeMerge
+	}
+	changes, errors := web.planTeamImport(data, mode)
+
+	template, err := web.parseFiles("templates/setup_teams_import.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	numChanges := 0
+	for _, change := range changes {
+		if change.Action != "Unchanged" {
+			numChanges++
+		}
+	}
+	if showErrors {
+		w.WriteHeader(400)
+	}
+	templateData := struct {
+		*model.EventSettings
+		Data       string
+		Mode       string
+		Changes    []teamImportChange
+		NumChanges int
+		Errors     []string
+	}{web.arena.EventSettings, data, mode, changes, numChanges, errors}
+	err = template.ExecuteTemplate(w, "base", templateData)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Parses the given data and works out the changes needed to apply it to the current team list. Returns the changes
+// sorted by team number and any validation errors, in which case the import should not be applied.
+func (web *Web) planTeamImport(data, mode string) ([]teamImportChange, []string) {
+	records, errors := parseTeamImportData(data)
+	if len(errors) > 0 {
+		return nil, errors
+	}
+	if len(records) == 0 {
+		return nil, []string{"No teams were found in the import data."}
+	}
+
+	teams, err := web.arena.Database.GetAllTeams()
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
+	existingTeams := make(map[int]*model.Team)
+	for i := range teams {
+		existingTeams[teams[i].Id] = &teams[i]
+	}
+
+	var changes []teamImportChange
+	for _, record := range records {
+		oldTeam := existingTeams[record.team.Id]
+		delete(existingTeams, record.team.Id)
+		newTeam := &model.Team{Id: record.team.Id}
+		if oldTeam != nil {
+			if mode == teamImportModeOverwrite {
+				// Keep the fields that aren't part of the import so that they aren't lost along with everything else.
+				newTeam.YellowCard = oldTeam.YellowCard
+				newTeam.RadioConfiguredAt = oldTeam.RadioConfiguredAt
+			} else {
+				*newTeam = *oldTeam
+			}
+		}
+		for _, field := range record.fields {
+			field.set(newTeam, field.get(&record.team))
+		}
+		if hasEmptyTeamAvailability(newTeam) {
+			// Only one end of the window was given, and it doesn't fit with the other end already set for the team.
+			errors = append(errors, fmt.Sprintf("Team %d: AvailableUntil must be after AvailableFrom", newTeam.Id))
+		}
+
+		change := teamImportChange{TeamId: newTeam.Id, oldTeam: oldTeam, newTeam: newTeam}
+		if oldTeam == nil {
+			change.Action = "Add"
+		} else {
+			for _, field := range teamImportFields {
+				if oldValue, newValue := field.get(oldTeam), field.get(newTeam); oldValue != newValue {
+					change.Differences = append(
+						change.Differences, fmt.Sprintf("%s: '%s' → '%s'", field.name, oldValue, newValue),
+					)
+				}
+			}
+			change.Action = "Unchanged"
+			if len(change.Differences) > 0 {
+				change.Action = "Update"
+			}
+		}
+		changes = append(changes, change)
+	}
+	if mode == teamImportModeOverwrite {
+		for _, oldTeam := range existingTeams {
+			changes = append(changes, teamImportChange{TeamId: oldTeam.Id, Action: "Delete", oldTeam: oldTeam})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].TeamId < changes[j].TeamId })
+	if len(errors) > 0 {
+		return changes, errors
+	}
+
+	// Teams can only be added or removed before the schedule is generated, in the same way as for the team list page.
+	if !web.canModifyTeamList() {
+		for _, change := range changes {
+			if change.Action == "Add" || change.Action == "Delete" {
+				return changes, []string{
+					"Teams can't be added or removed once the qualification schedule has been generated; only " +
+						"existing teams can be updated.",
+				}
+			}
+		}
+	}
+	return changes, nil
+}
+
+// Parses the given CSV or JSON team data, detecting the format from its first character.
+func parseTeamImportData(data string) ([]teamImportRecord, []string) {
+	data = strings.TrimSpace(strings.TrimPrefix(data, "\ufeff"))
+	if data == "" {
+		return nil, []string{"No import data was given."}
+	}
+
+	var rows []map[string]string
+	if strings.HasPrefix(data, "[") {
+		var objects []map[string]any
+		if err := json.Unmarshal([]byte(data), &objects); err != nil {
+			return nil, []string{fmt.Sprintf("Failed to parse JSON: %v", err)}
+		}
+		for _, object := range objects {
+			row := make(map[string]string)
+			for key, value := range object {
+				if value != nil {
+					row[key] = fmt.Sprint(value)
+				}
+			}
+			rows = append(rows, row)
+		}
+	} else {
+		reader := csv.NewReader(strings.NewReader(data))
+		reader.LazyQuotes = true
+		reader.FieldsPerRecord = -1
+		lines, err := reader.ReadAll()
+		if err != nil {
+			return nil, []string{fmt.Sprintf("Failed to parse CSV: %v", err)}
+		}
+		header := lines[0]
+		for _, line := range lines[1:] {
+			row := make(map[string]string)
+			for i, value := range line {
+				if i < len(header) {
+					row[header[i]] = value
+				}
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	var records []teamImportRecord
+	var errors []string
+	seenTeamIds := make(map[int]int)
+	for i, row := range rows {
+		// Rows are numbered from one, after the header row in the case of CSV.
+		rowNumber := i + 1
+		var record teamImportRecord
+		var keys []string
+		for key := range row {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			field := getTeamImportField(key)
+			if field == nil {
+				continue
+			}
+			value := strings.TrimSpace(row[key])
+			if value == "" {
+				// Leave blank cells out so that merging doesn't clear existing values; a team's fields start out blank
+				// anyway when it is added or overwritten.
+				continue
+			}
+			if err := field.set(&record.team, value); err != nil {
+				errors = append(errors, fmt.Sprintf("Row %d: %v", rowNumber, err))
+				continue
+			}
+			record.fields = append(record.fields, field)
+		}
+		if record.team.Id == 0 {
+			if !hasTeamImportError(errors, rowNumber) {
+				errors = append(errors, fmt.Sprintf("Row %d: missing team number", rowNumber))
+			}
+			continue
+		}
+		if hasEmptyTeamAvailability(&record.team) {
+			errors = append(errors, fmt.Sprintf("Row %d: AvailableUntil must be after AvailableFrom", rowNumber))
+			continue
+		}
+		if previousRowNumber, ok := seenTeamIds[record.team.Id]; ok {
+			errors = append(
+				errors,
+				fmt.Sprintf("Row %d: team %d is a duplicate of row %d", rowNumber, record.team.Id, previousRowNumber),
+			)
+			continue
+		}
+		seenTeamIds[record.team.Id] = rowNumber
+		records = append(records, record)
+	}
+	return records, errors
+}
+
+// Returns the field that the given column header maps to, ignoring case, spaces and punctuation, or nil if the column
+// should be ignored.
+func getTeamImportField(header string) *teamImportField {
+	normalizedHeader := strings.Map(
+		func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return unicode.ToLower(r)
+			}
+			return -1
+		},
+		header,
+	)
+	for _, field := range teamImportFields {
+		if normalizedHeader == strings.ToLower(field.name) {
+			return field
+		}
+		for _, alias := range field.aliases {
+			if normalizedHeader == alias {
+				return field
+			}
+		}
+	}
+	return nil
+}
+
+func hasTeamImportError(errors []string, rowNumber int) bool {
+	prefix := fmt.Sprintf("Row %d:", rowNumber)
+	for _, err := range errors {
+		if strings.HasPrefix(err, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func stringTeamImportField(
+	name string, aliases []string, fieldPointer func(team *model.Team) *string,
+) *teamImportField {
+	return &teamImportField{
+		name:    name,
+		aliases: aliases,
+		get:     func(team *model.Team) string { return *fieldPointer(team) },
+		set: func(team *model.Team, value string) error {
+			*fieldPointer(team) = value
+			return nil
+		},
+	}
+}
+
+// Returns a field for a team availability time, which is blank if the team's availability isn't limited.
+func timeTeamImportField(
+	name string, aliases []string, fieldPointer func(team *model.Team) *time.Time,
+) *teamImportField {
+	return &teamImportField{
+		name:    name,
+		aliases: aliases,
+		get:     func(team *model.Team) string { return formatTeamAvailabilityTime(*fieldPointer(team)) },
+		set: func(team *model.Team, value string) error {
+			availabilityTime, err := parseTeamAvailabilityTime(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s time '%s'", name, value)
+			}
+			*fieldPointer(team) = availabilityTime
+			return nil
+		},
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetupTeamsImportMerge(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.Database.CreateTeam(&model.Team{Id: 254, Name: "NASA", Nickname: "The Cheesy Poofs", RookieYear: 1999})
+	web.arena.Database.CreateTeam(
+		&model.Team{Id: 1114, Nickname: "Simbots", WpaKey: "12345678", RadioConfiguredAt: time.Unix(1000, 0)},
+	)
+
+	// The team list CSV report should import back in without any changes.
+	report := web.getHttpResponse("/reports/csv/teams").Body.String()
+	recorder := web.postHttpResponse("/setup/teams/import/preview", teamImportForm(report, "merge"))
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "0 of 2 teams will change")
+	assert.NotContains(t, recorder.Body.String(), "alert-danger")
+
+	// Columns are mapped from their headers, and those that aren't present are left alone.
+	data := "Team Number,nickname,Rookie Year,wpa_key,Unknown\n254,Teh Chezy Pofs,1999,,x\n1678,Citrus Circuits,2005,,\n" +
+		"1114,Simbots,2003,abcdefgh,\n"
+	recorder = web.postHttpResponse("/setup/teams/import/preview", teamImportForm(data, "merge"))
+	assert.Equal(t, 200, recorder.Code)
+	body := recorder.Body.String()
+	assert.Contains(t, body, "3 of 3 teams will change")
+	assert.Contains(t, body, "Nickname: &#39;The Cheesy Poofs&#39; → &#39;Teh Chezy Pofs&#39;")
+	assert.Contains(t, body, "RookieYear: &#39;0&#39; → &#39;2003&#39;")
+	assert.Contains(t, body, "WpaKey: &#39;12345678&#39; → &#39;abcdefgh&#39;")
+	assert.Contains(t, body, "bg-success\">Add")
+	recorder = web.postHttpResponse("/setup/teams/import", teamImportForm(data, "merge"))
+	assert.Equal(t, 303, recorder.Code)
+
+	team, _ := web.arena.Database.GetTeamById(254)
+	assert.Equal(t, "NASA", team.Name)
+	assert.Equal(t, "Teh Chezy Pofs", team.Nickname)
+	team, _ = web.arena.Database.GetTeamById(1678)
+	assert.Equal(t, model.Team{Id: 1678, Nickname: "Citrus Circuits", RookieYear: 2005}, *team)
+	team, _ = web.arena.Database.GetTeamById(1114)
+	assert.Equal(t, "abcdefgh", team.WpaKey)
+	assert.True(t, team.RadioConfiguredAt.IsZero())
+
+	// Blank cells shouldn't clear existing values when merging.
+	team.RadioConfiguredAt = time.Unix(2000, 0)
+	assert.Nil(t, web.arena.Database.UpdateTeam(team))
+	data = "Number,Nickname,WpaKey\n1114,,\n"
+	recorder = web.postHttpResponse("/setup/teams/import/preview", teamImportForm(data, "merge"))
+	assert.Contains(t, recorder.Body.String(), "0 of 1 teams will change")
+	recorder = web.postHttpResponse("/setup/teams/import", teamImportForm(data, "merge"))
+	assert.Equal(t, 303, recorder.Code)
+	team, _ = web.arena.Database.GetTeamById(1114)
+	assert.Equal(t, "Simbots", team.Nickname)
+	assert.Equal(t, "abcdefgh", team.WpaKey)
+	assert.Equal(t, time.Unix(2000, 0).Unix(), team.RadioConfiguredAt.Unix())
+}
+
+func TestSetupTeamsImportOverwrite(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.Database.CreateTeam(&model.Team{Id: 254, Name: "NASA", Nickname: "The Cheesy Poofs", YellowCard: true})
+	web.arena.Database.CreateTeam(&model.Team{Id: 1114, Nickname: "Simbots"})
+
+	data := "[{\"Number\": 254, \"Name\": \"NASA Ames\", \"HasConnected\": true}, " +
+		"{\"Team\": \"33\", \"City\": \"Auburn Hills\"}]"
+	recorder := web.postHttpResponse("/setup/teams/import/preview", teamImportForm(data, "overwrite"))
+	assert.Equal(t, 200, recorder.Code)
+	body := recorder.Body.String()
+	assert.Contains(t, body, "Overwrite mode")
+	assert.Contains(t, body, "Nickname: &#39;The Cheesy Poofs&#39; → &#39;&#39;")
+	assert.Contains(t, body, "bg-danger\">Delete")
+	recorder = web.postHttpResponse("/setup/teams/import", teamImportForm(data, "overwrite"))
+	assert.Equal(t, 303, recorder.Code)
+
+	teams, _ := web.arena.Database.GetAllTeams()
+	assert.Equal(
+		t,
+		[]model.Team{
+			{Id: 33, City: "Auburn Hills"},
+			{Id: 254, Name: "NASA Ames", HasConnected: true, YellowCard: true},
+		},
+		teams,
+	)
+}
+
+func TestSetupTeamsImportErrors(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.Database.CreateTeam(&model.Team{Id: 254})
+
+	data := "Number,RookieYear,WpaKey,HasConnected\nabc,,,\n254,199x,,\n1114,,short,\n1114,,,maybe\n1114,,,\n"
+	recorder := web.postHttpResponse("/setup/teams/import/preview", teamImportForm(data, "merge"))
+	assert.Equal(t, 200, recorder.Code)
+	body := recorder.Body.String()
+	assert.Contains(t, body, "Row 1: invalid team number &#39;abc&#39;")
+	assert.Contains(t, body, "Row 2: invalid rookie year &#39;199x&#39;")
+	assert.Contains(t, body, "Row 3: WPA key must be between 8 and 63 characters")
+	assert.Contains(t, body, "Row 4: invalid value &#39;maybe&#39; for HasConnected")
+	assert.Contains(t, body, "Row 5: team 1114 is a duplicate of row 3")
+	recorder = web.postHttpResponse("/setup/teams/import", teamImportForm(data, "merge"))
+	assert.Equal(t, 400, recorder.Code)
+	teams, _ := web.arena.Database.GetAllTeams()
+	assert.Equal(t, 1, len(teams))
+
+	recorder = web.postHttpResponse("/setup/teams/import/preview", teamImportForm("[{\"Number\": ", "merge"))
+	assert.Contains(t, recorder.Body.String(), "Failed to parse JSON")
+	recorder = web.postHttpResponse("/setup/teams/import/preview", teamImportForm("Nickname\nPoofs\n", "merge"))
+	assert.Contains(t, recorder.Body.String(), "Row 1: missing team number")
+	recorder = web.postHttpResponse(
+		"/setup/teams/import/preview", teamImportForm("Number,NotBefore\n254,Saturday\n", "merge"),
+	)
+	assert.Contains(t, recorder.Body.String(), "Row 1: invalid AvailableFrom time &#39;Saturday&#39;")
+
+	// A team's availability window can't be empty, whether both ends are in the import or only one of them is.
+	data = "Number,AvailableFrom,AvailableUntil\n254,2026-04-04 02:00 PM,2026-04-04 10:00 AM\n"
+	recorder = web.postHttpResponse("/setup/teams/import", teamImportForm(data, "merge"))
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Row 1: AvailableUntil must be after AvailableFrom")
+	team := model.Team{Id: 254, AvailableUntil: time.Date(2026, 4, 4, 10, 0, 0, 0, time.Local)}
+	assert.Nil(t, web.arena.Database.UpdateTeam(&team))
+	data = "Number,AvailableFrom\n254,2026-04-04 02:00 PM\n"
+	recorder = web.postHttpResponse("/setup/teams/import", teamImportForm(data, "merge"))
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Team 254: AvailableUntil must be after AvailableFrom")
+	assert.Nil(t, web.arena.Database.UpdateTeam(&model.Team{Id: 254}))
+
+	// Teams can only be updated, not added or removed, once the schedule exists.
+	web.arena.Database.CreateMatch(&model.Match{Type: model.Qualification})
+	recorder = web.postHttpResponse("/setup/teams/import", teamImportForm("Number\n254\n1114\n", "merge"))
+	assert.Equal(t, 400, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Teams can&#39;t be added or removed")
+	recorder = web.postHttpResponse("/setup/teams/import", teamImportForm("Number,Nickname\n254,Poofs\n", "merge"))
+	assert.Equal(t, 303, recorder.Code)
+	updatedTeam, _ := web.arena.Database.GetTeamById(254)
+	assert.Equal(t, "Poofs", updatedTeam.Nickname)
+}
+
+func TestSetupTeamsExport(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.Database.CreateTeam(
+		&model.Team{Id: 254, Nickname: "The \"Cheesy\" Poofs", Accomplishments: "Won\nLots", FtaNotes: "Radio, flaky"},
+	)
+	web.arena.Database.CreateTeam(
+		&model.Team{
+			Id:            1114,
+			RookieYear:    2003,
+			HasConnected:  true,
+			AvailableFrom: time.Date(2026, 4, 4, 10, 30, 0, 0, time.Local),
+		},
+	)
+
+	recorder := web.getHttpResponse("/setup/teams/export?format=csv")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "text/csv", recorder.Header()["Content-Type"][0])
+	assert.Equal(t, "attachment; filename=\"teams.csv\"", recorder.Header()["Content-Disposition"][0])
+	assert.Equal(
+		t,
+		"Number,Name,Nickname,SchoolName,City,StateProv,Country,RookieYear,RobotName,Accomplishments,WpaKey,FtaNotes,"+
+			"HasConnected,AvailableFrom,AvailableUntil\n254,,\"The \"\"Cheesy\"\" Poofs\",,,,,0,,\"Won\nLots\",,"+
+			"\"Radio, flaky\",false,,\n1114,,,,,,,2003,,,,,true,2026-04-04 10:30 AM,\n",
+		recorder.Body.String(),
+	)
+	csvExport := recorder.Body.String()
+
+	recorder = web.getHttpResponse("/setup/teams/export?format=json")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header()["Content-Type"][0])
+	assert.Contains(t, recorder.Body.String(), "\"Number\": 1114")
+	assert.Contains(t, recorder.Body.String(), "\"HasConnected\": true")
+	jsonExport := recorder.Body.String()
+
+	// Both exports should round-trip without any changes, even in overwrite mode.
+	for _, data := range []string{csvExport, jsonExport} {
+		recorder = web.postHttpResponse("/setup/teams/import/preview", teamImportForm(data, "overwrite"))
+		assert.Contains(t, recorder.Body.String(), "0 of 2 teams will change")
+	}
+
+	recorder = web.getHttpResponse("/setup/teams/export?format=xml")
+	assert.Equal(t, 400, recorder.Code)
+}
+
+func teamImportForm(data, mode string) string {
+	return url.Values{"data": {data}, "mode": {mode}}.Encode()
+}
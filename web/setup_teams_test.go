@@ -4,7 +4,13 @@
 package web
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/Team254/cheesy-arena/model"
 	"github.com/stretchr/testify/assert"
@@ -85,6 +91,37 @@ func TestSetupTeamsDisallowModification(t *testing.T) {
 	assert.Contains(t, recorder.Body.String(), "Teh Chezy Pofs")
 }
 
+func TestSetupTeamsAvailability(t *testing.T) {
+	web := setupTestWeb(t)
+	web.arena.Database.CreateTeam(&model.Team{Id: 254})
+
+	recorder := web.postHttpResponse(
+		"/setup/teams/254/edit", "availableFrom=2026-04-04 10:30 AM&availableUntil=2026-04-05 03:00 PM",
+	)
+	assert.Equal(t, 303, recorder.Code)
+	team, _ := web.arena.Database.GetTeamById(254)
+	assert.True(t, time.Date(2026, 4, 4, 10, 30, 0, 0, time.Local).Equal(team.AvailableFrom))
+	assert.True(t, time.Date(2026, 4, 5, 15, 0, 0, 0, time.Local).Equal(team.AvailableUntil))
+	recorder = web.getHttpResponse("/setup/teams/254/edit")
+	assert.Contains(t, recorder.Body.String(), "2026-04-04 10:30 AM")
+	assert.Contains(t, recorder.Body.String(), "2026-04-05 03:00 PM")
+
+	// Blank values should clear the limits.
+	recorder = web.postHttpResponse("/setup/teams/254/edit", "availableFrom=&availableUntil=2026-04-05 03:00 PM")
+	assert.Equal(t, 303, recorder.Code)
+	team, _ = web.arena.Database.GetTeamById(254)
+	assert.True(t, team.AvailableFrom.IsZero())
+
+	recorder = web.postHttpResponse("/setup/teams/254/edit", "availableFrom=10:30")
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Invalid availability time '10:30'")
+	recorder = web.postHttpResponse(
+		"/setup/teams/254/edit", "availableFrom=2026-04-05 03:00 PM&availableUntil=2026-04-04 10:30 AM",
+	)
+	assert.Equal(t, 500, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "must be after the start")
+}
+
 func TestSetupTeamsBadReqest(t *testing.T) {
 	web := setupTestWeb(t)
 
@@ -139,3 +176,73 @@ func TestSetupTeamsProgress(t *testing.T) {
 	assert.Equal(t, 200, recorder.Code)
 	assert.Equal(t, "25", recorder.Body.String())
 }
+
+func TestSetupTeamsTbaDownload(t *testing.T) {
+	web := setupTestWeb(t)
+	year := time.Now().Year()
+	tbaServer := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/api/v3/team/frc254":
+					fmt.Fprint(w, `{"team_number":254,"name":"NASA/Bellarmine","nickname":"The Cheesy Poofs",`+
+						`"city":"San Jose","state_prov":"CA","country":"USA","rookie_year":1999}`)
+				case "/api/v3/team/frc254/robots":
+					fmt.Fprintf(w, `[{"robot_name":"Dreadnought","year":%d}]`, year)
+				case "/api/v3/team/frc254/awards":
+					fmt.Fprintf(
+						w,
+						`[{"name":"Winner","event_key":"2000cc","year":2000},`+
+							`{"name":"Safety Award","event_key":"%dcc","year":%d}]`,
+						year,
+						year,
+					)
+				case fmt.Sprintf("/api/v3/event/%dcc", year):
+					fmt.Fprint(w, `{"name":"Chezy Champs"}`)
+				case fmt.Sprintf("/api/v3/team/frc254/media/%d", year):
+					fmt.Fprint(w, `[{"type":"avatar","details":{"base64Image":"iVBORw0KGgo="}}]`)
+				default:
+					http.NotFound(w, r)
+				}
+			},
+		),
+	)
+	defer tbaServer.Close()
+	web.arena.TbaClient.BaseUrl = tbaServer.URL
+	web.arena.TbaClient.SetSettings("", "", "", "api_key")
+	avatarPath := filepath.Join(model.BaseDir, AvatarsDir, "254.png")
+	t.Cleanup(
+		func() {
+			os.Remove(avatarPath)
+		},
+	)
+
+	recorder := web.postHttpResponse("/setup/teams", "teamNumbers=254\r\n9999")
+	assert.Equal(t, 303, recorder.Code)
+	team, _ := web.arena.Database.GetTeamById(254)
+	if assert.NotNil(t, team) {
+		assert.Equal(t, "NASA/Bellarmine", team.Name)
+		assert.Equal(t, "The Cheesy Poofs", team.Nickname)
+		assert.Equal(t, "San Jose", team.City)
+		assert.Equal(t, "CA", team.StateProv)
+		assert.Equal(t, 1999, team.RookieYear)
+		assert.Equal(t, "Dreadnought", team.RobotName)
+		assert.Equal(t, fmt.Sprintf("%d Chezy Champs - Safety Award", year), team.Accomplishments)
+	}
+	avatar, err := os.ReadFile(avatarPath)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n'}, avatar)
+
+	// Teams unknown to TBA should still be created.
+	team, _ = web.arena.Database.GetTeamById(9999)
+	if assert.NotNil(t, team) {
+		assert.Equal(t, "", team.Nickname)
+	}
+
+	// Teams should still be created if TBA can't be reached.
+	tbaServer.Close()
+	recorder = web.postHttpResponse("/setup/teams", "teamNumbers=1114\r\n148")
+	assert.Equal(t, 303, recorder.Code)
+	teams, _ := web.arena.Database.GetAllTeams()
+	assert.Equal(t, 4, len(teams))
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+//
+// Web routes for managing outbound webhook subscriptions and viewing their delivery log.
+
+package web
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
+)
+
+// Shows the webhooks configuration page.
+func (web *Web) webhooksGetHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	web.renderWebhooks(w, r, "")
+}
+
+// Saves the new or modified webhook subscription to the database.
+func (web *Web) webhooksPostHandler(w http.ResponseWriter, r *http.Request) {
+	if !web.userIsAdmin(w, r) {
+		return
+	}
+
+	subscriptionId, _ := strconv.Atoi(r.PostFormValue("id"))
+	if r.PostFormValue("action") == "delete" {
+		if err := web.arena.Database.DeleteWebhookSubscription(subscriptionId); err != nil {
+			handleWebErr(w, err)
+			return
+		}
+	} else {
+		subscription := model.WebhookSubscription{
+			Id: subscriptionId, Url: r.PostFormValue("url"), Secret: r.PostFormValue("secret"),
+		}
+		parsedUrl, err := url.Parse(subscription.Url)
+		if err != nil || (parsedUrl.Scheme != "http" && parsedUrl.Scheme != "https") || parsedUrl.Host == "" {
+			web.renderWebhooks(w, r, "Webhook URL must be a valid HTTP or HTTPS URL.")
+			return
+		}
+		for _, event := range r.Form["events"] {
+			if slices.Contains(model.WebhookEvents, event) {
+				subscription.Events = append(subscription.Events, event)
+			}
+		}
+		if subscription.Id == 0 {
+			err = web.arena.Database.CreateWebhookSubscription(&subscription)
+		} else {
+			err = web.arena.Database.UpdateWebhookSubscription(&subscription)
+		}
+		if err != nil {
+			handleWebErr(w, err)
+			return
+		}
+	}
+
+	http.Redirect(w, r, "/setup/webhooks", 303)
+}
+
+func (web *Web) renderWebhooks(w http.ResponseWriter, r *http.Request, errorMessage string) {
+	template, err := web.parseFiles("templates/setup_webhooks.html", "templates/base.html")
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+	subscriptions, err := web.arena.Database.GetAllWebhookSubscriptions()
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+
+	// Append a blank subscription to the end that can be used to add a new one.
+	subscriptions = append(subscriptions, model.WebhookSubscription{})
+
+	data := struct {
+		*model.EventSettings
+		ErrorMessage  string
+		Subscriptions []model.WebhookSubscription
+		Events        []string
+		Deliveries    []partner.WebhookDelivery
+	}{web.arena.EventSettings, errorMessage, subscriptions, model.WebhookEvents, web.arena.WebhookClient.Deliveries()}
+	err = template.ExecuteTemplate(w, "base", data)
+	if err != nil {
+		handleWebErr(w, err)
+		return
+	}
+}
+
+// Notifies webhook subscribers of each existing award of the given types.
+func (web *Web) triggerAwardWebhooks(awardTypes ...model.AwardType) {
+	for _, awardType := range awardTypes {
+		awards, err := web.arena.Database.GetAwardsByType(awardType)
+		if err != nil {
+			continue
+		}
+		for _, award := range awards {
+			web.arena.TriggerWebhook(model.WebhookAwardCreated, award)
+		}
+	}
+}
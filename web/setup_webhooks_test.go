@@ -0,0 +1,116 @@
+// Copyright 2026 Team 254. All Rights Reserved.
+// Author: pat@patfairbank.com (Patrick Fairbank)
+
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Team254/cheesy-arena/game"
+	"github.com/Team254/cheesy-arena/model"
+	"github.com/Team254/cheesy-arena/partner"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetupWebhooks(t *testing.T) {
+	web := setupTestWeb(t)
+
+	recorder := web.getHttpResponse("/setup/webhooks")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "No webhooks have been delivered yet.")
+
+	recorder = web.postHttpResponse(
+		"/setup/webhooks",
+		"url=https://example.com/hook&secret=shh&events=match_loaded&events=award_created&events=bogus",
+	)
+	assert.Equal(t, 303, recorder.Code)
+	subscriptions, _ := web.arena.Database.GetAllWebhookSubscriptions()
+	if assert.Equal(t, 1, len(subscriptions)) {
+		subscription := subscriptions[0]
+		assert.Equal(t, 1, subscription.Id)
+		assert.Equal(t, "https://example.com/hook", subscription.Url)
+		assert.Equal(t, "shh", subscription.Secret)
+		assert.Equal(t, []string{model.WebhookMatchLoaded, model.WebhookAwardCreated}, subscription.Events)
+	}
+	recorder = web.getHttpResponse("/setup/webhooks")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "https://example.com/hook")
+
+	recorder = web.postHttpResponse("/setup/webhooks", "id=1&url=http://10.0.100.5:8000/hook&events=match_ended")
+	assert.Equal(t, 303, recorder.Code)
+	subscriptions, _ = web.arena.Database.GetAllWebhookSubscriptions()
+	if assert.Equal(t, 1, len(subscriptions)) {
+		assert.Equal(t, "http://10.0.100.5:8000/hook", subscriptions[0].Url)
+		assert.Equal(t, []string{model.WebhookMatchEnded}, subscriptions[0].Events)
+	}
+
+	// Invalid URLs should be rejected.
+	recorder = web.postHttpResponse("/setup/webhooks", "url=ftp://example.com&events=match_loaded")
+	assert.Contains(t, recorder.Body.String(), "Webhook URL must be a valid HTTP or HTTPS URL.")
+	recorder = web.postHttpResponse("/setup/webhooks", "url=example.com/hook")
+	assert.Contains(t, recorder.Body.String(), "Webhook URL must be a valid HTTP or HTTPS URL.")
+	subscriptions, _ = web.arena.Database.GetAllWebhookSubscriptions()
+	assert.Equal(t, 1, len(subscriptions))
+
+	recorder = web.postHttpResponse("/setup/webhooks", "action=delete&id=1")
+	assert.Equal(t, 303, recorder.Code)
+	subscriptions, _ = web.arena.Database.GetAllWebhookSubscriptions()
+	assert.Empty(t, subscriptions)
+}
+
+func TestCommitMatchWebhooks(t *testing.T) {
+	web := setupTestWeb(t)
+	var events []string
+	var mutex sync.Mutex
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				mutex.Lock()
+				defer mutex.Unlock()
+				events = append(events, r.Header.Get(partner.WebhookEventHeader))
+			},
+		),
+	)
+	defer server.Close()
+	assert.Nil(
+		t,
+		web.arena.Database.CreateWebhookSubscription(
+			&model.WebhookSubscription{
+				Url: server.URL, Events: []string{model.WebhookScoreCommitted, model.WebhookRankingsUpdated},
+			},
+		),
+	)
+
+	match := &model.Match{Type: model.Qualification, Red1: 101, Red2: 102, Red3: 103, Blue1: 104, Blue2: 105, Blue3: 106}
+	assert.Nil(t, web.arena.Database.CreateMatch(match))
+	matchResult := model.NewMatchResult()
+	matchResult.MatchId = match.Id
+	matchResult.RedScore = &game.Score{}
+	matchResult.BlueScore = &game.Score{}
+	assert.Nil(t, web.commitMatchScore(match, matchResult, false))
+
+	assert.Eventually(
+		t,
+		func() bool {
+			mutex.Lock()
+			defer mutex.Unlock()
+			return len(events) == 2
+		},
+		time.Second,
+		10*time.Millisecond,
+	)
+	mutex.Lock()
+	sort.Strings(events)
+	assert.Equal(t, []string{model.WebhookRankingsUpdated, model.WebhookScoreCommitted}, events)
+	mutex.Unlock()
+
+	recorder := web.getHttpResponse("/setup/webhooks")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), model.WebhookScoreCommitted)
+	assert.Contains(t, recorder.Body.String(), server.URL)
+}
@@ -137,6 +137,9 @@ func (web *Web) newHandler() http.Handler {
 	mux.HandleFunc("GET /api/alliances", web.alliancesApiHandler)
 	mux.HandleFunc("GET /api/arena/websocket", web.arenaWebsocketApiHandler)
 	mux.HandleFunc("GET /api/bracket/svg", web.bracketSvgApiHandler)
+	mux.HandleFunc("GET /api/broadcast/{format}", web.broadcastApiHandler)
+	mux.HandleFunc("POST /api/control/{action}", web.controlActionApiHandler)
+	mux.HandleFunc("GET /api/control/status", web.controlStatusApiHandler)
 	mux.HandleFunc("GET /api/matches/{type}", web.matchesApiHandler)
 	mux.HandleFunc("GET /api/rankings", web.rankingsApiHandler)
 	mux.HandleFunc("GET /api/sponsor_slides", web.sponsorSlidesApiHandler)
@@ -176,6 +179,7 @@ func (web *Web) newHandler() http.Handler {
 	mux.HandleFunc("GET /match_review", web.matchReviewHandler)
 	mux.HandleFunc("GET /match_review/{matchId}/edit", web.matchReviewEditGetHandler)
 	mux.HandleFunc("POST /match_review/{matchId}/edit", web.matchReviewEditPostHandler)
+	mux.HandleFunc("GET /match_videos/{videoId}", web.matchVideoHandler)
 	mux.HandleFunc("GET /panels/scoring/{position}", web.scoringPanelHandler)
 	mux.HandleFunc("GET /panels/scoring/{position}/websocket", web.scoringPanelWebsocketHandler)
 	mux.HandleFunc("GET /panels/referee", web.refereePanelHandler)
@@ -185,7 +189,9 @@ func (web *Web) newHandler() http.Handler {
 	mux.HandleFunc("GET /reports/csv/fta", web.ftaCsvReportHandler)
 	mux.HandleFunc("GET /reports/csv/rankings", web.rankingsCsvReportHandler)
 	mux.HandleFunc("GET /reports/csv/schedule/{type}", web.scheduleCsvReportHandler)
+	mux.HandleFunc("GET /reports/csv/schedule_analysis/{type}", web.scheduleAnalysisCsvReportHandler)
 	mux.HandleFunc("GET /reports/csv/teams", web.teamsCsvReportHandler)
+	mux.HandleFunc("GET /reports/csv/wifi", web.wifiCsvReportHandler)
 	mux.HandleFunc("GET /reports/csv/wpa_keys", web.wpaKeysCsvReportHandler)
 	mux.HandleFunc("GET /reports/pdf/alliances", web.alliancesPdfReportHandler)
 	mux.HandleFunc("GET /reports/pdf/backups", web.backupsPdfReportHandler)
@@ -195,7 +201,10 @@ func (web *Web) newHandler() http.Handler {
 	mux.HandleFunc("GET /reports/pdf/judging_schedule", web.judgingSchedulePdfReportHandler)
 	mux.HandleFunc("GET /reports/pdf/rankings", web.rankingsPdfReportHandler)
 	mux.HandleFunc("GET /reports/pdf/schedule/{type}", web.schedulePdfReportHandler)
+	mux.HandleFunc("GET /reports/pdf/schedule_analysis/{type}", web.scheduleAnalysisPdfReportHandler)
 	mux.HandleFunc("GET /reports/pdf/teams", web.teamsPdfReportHandler)
+	mux.HandleFunc("GET /reports/pdf/wifi", web.wifiPdfReportHandler)
+	mux.HandleFunc("GET /reports/zip/fms_export", web.fmsExportReportHandler)
 	mux.HandleFunc("GET /setup/awards", web.awardsGetHandler)
 	mux.HandleFunc("POST /setup/awards", web.awardsPostHandler)
 	mux.HandleFunc("GET /setup/breaks", web.breaksGetHandler)
@@ -212,22 +221,33 @@ func (web *Web) newHandler() http.Handler {
 	mux.HandleFunc("POST /setup/judging/generate", web.judgingGeneratePostHandler)
 	mux.HandleFunc("GET /setup/lower_thirds", web.lowerThirdsGetHandler)
 	mux.HandleFunc("GET /setup/lower_thirds/websocket", web.lowerThirdsWebsocketHandler)
+	mux.HandleFunc("GET /setup/radio_kiosk", web.radioKioskGetHandler)
+	mux.HandleFunc("POST /setup/radio_kiosk", web.radioKioskPostHandler)
 	mux.HandleFunc("GET /setup/schedule", web.scheduleGetHandler)
 	mux.HandleFunc("POST /setup/schedule/generate", web.scheduleGeneratePostHandler)
+	mux.HandleFunc("POST /setup/schedule/import", web.scheduleImportPostHandler)
 	mux.HandleFunc("POST /setup/schedule/save", web.scheduleSavePostHandler)
 	mux.HandleFunc("GET /setup/settings", web.settingsGetHandler)
 	mux.HandleFunc("POST /setup/settings", web.settingsPostHandler)
+	mux.HandleFunc("POST /setup/settings/publish_all", web.publishAllDataHandler)
 
 	mux.HandleFunc("GET /setup/sponsor_slides", web.sponsorSlidesGetHandler)
 	mux.HandleFunc("POST /setup/sponsor_slides", web.sponsorSlidesPostHandler)
+	mux.HandleFunc("GET /setup/team_signs", web.teamSignsGetHandler)
+	mux.HandleFunc("GET /setup/team_signs/websocket", web.teamSignsWebsocketHandler)
 	mux.HandleFunc("GET /setup/teams", web.teamsGetHandler)
 	mux.HandleFunc("POST /setup/teams", web.teamsPostHandler)
 	mux.HandleFunc("POST /setup/teams/{id}/delete", web.teamDeletePostHandler)
 	mux.HandleFunc("GET /setup/teams/{id}/edit", web.teamEditGetHandler)
 	mux.HandleFunc("POST /setup/teams/{id}/edit", web.teamEditPostHandler)
 	mux.HandleFunc("POST /setup/teams/clear", web.teamsClearHandler)
+	mux.HandleFunc("GET /setup/teams/export", web.teamsExportHandler)
 	mux.HandleFunc("GET /setup/teams/generate_wpa_keys", web.teamsGenerateWpaKeysHandler)
+	mux.HandleFunc("POST /setup/teams/import", web.teamsImportPostHandler)
+	mux.HandleFunc("POST /setup/teams/import/preview", web.teamsImportPreviewHandler)
 	mux.HandleFunc("GET /setup/teams/progress", web.teamsUpdateProgressBarHandler)
+	mux.HandleFunc("GET /setup/webhooks", web.webhooksGetHandler)
+	mux.HandleFunc("POST /setup/webhooks", web.webhooksPostHandler)
 
 	return mux
 }
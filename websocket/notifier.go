@@ -6,6 +6,7 @@
 package websocket
 
 import (
+	"encoding/json"
 	"log"
 	"sync"
 )
@@ -17,6 +18,7 @@ type Notifier struct {
 	messageType     string
 	messageProducer func() any
 	listeners       map[chan messageEnvelope]struct{} // The map is essentially a set; the value is ignored.
+	subscribers     map[chan []byte]struct{}
 	mutex           sync.Mutex
 }
 
@@ -28,6 +30,7 @@ type messageEnvelope struct {
 func NewNotifier(messageType string, messageProducer func() any) *Notifier {
 	notifier := &Notifier{messageType: messageType, messageProducer: messageProducer}
 	notifier.listeners = make(map[chan messageEnvelope]struct{})
+	notifier.subscribers = make(map[chan []byte]struct{})
 	return notifier
 }
 
@@ -47,6 +50,23 @@ func (notifier *Notifier) NotifyWithMessage(messageBody any) {
 	for listener := range notifier.listeners {
 		notifier.notifyListener(listener, message)
 	}
+
+	if len(notifier.subscribers) > 0 {
+		// Serialize the message here rather than in the subscribers' goroutines, since it may reference state that the
+		// caller will go on to modify.
+		messageJson, err := json.Marshal(messageBody)
+		if err != nil {
+			log.Printf("Failed to serialize a '%s' notification: %v", notifier.messageType, err)
+			return
+		}
+		for subscriber := range notifier.subscribers {
+			select {
+			case subscriber <- messageJson:
+			default:
+				log.Printf("Failed to send a '%s' notification due to blocked subscriber.", notifier.messageType)
+			}
+		}
+	}
 }
 
 func (notifier *Notifier) notifyListener(listener chan messageEnvelope, message messageEnvelope) {
@@ -78,6 +98,30 @@ func (notifier *Notifier) listen() chan messageEnvelope {
 	return listener
 }
 
+// Registers a handler that is called with the JSON-serialized body of every subsequent notification, for consumers
+// other than websocket clients. The handler runs on its own goroutine so that a slow consumer can't block the notifier.
+// Returns a function that cancels the subscription and stops the goroutine.
+func (notifier *Notifier) Subscribe(handler func(messageJson []byte)) func() {
+	notifier.mutex.Lock()
+	defer notifier.mutex.Unlock()
+
+	subscriber := make(chan []byte, notifyBufferSize)
+	notifier.subscribers[subscriber] = struct{}{}
+	go func() {
+		for messageJson := range subscriber {
+			handler(messageJson)
+		}
+	}()
+	return func() {
+		notifier.mutex.Lock()
+		defer notifier.mutex.Unlock()
+		if _, ok := notifier.subscribers[subscriber]; ok {
+			delete(notifier.subscribers, subscriber)
+			close(subscriber)
+		}
+	}
+}
+
 // Invokes the message producer to get the message, or returns nil if no producer is defined.
 func (notifier *Notifier) getMessageBody() any {
 	if notifier.messageProducer == nil {
@@ -85,6 +85,34 @@ func TestNotifyMultipleListeners(t *testing.T) {
 	}
 }
 
+func TestNotifierSubscribe(t *testing.T) {
+	notifier := NewNotifier("testMessageType3", generateTestMessage)
+	messages := make(chan string, 2)
+	done := make(chan struct{})
+	unsubscribe := notifier.Subscribe(func(messageJson []byte) { messages <- string(messageJson) })
+	notifier.Subscribe(func(messageJson []byte) {})
+
+	// The message should be serialized before it is handed off, so later changes to it shouldn't be seen.
+	message := map[string]int{"value": 1}
+	notifier.Notify()
+	notifier.NotifyWithMessage(message)
+	message["value"] = 2
+	assert.Equal(t, "\"test message\"", <-messages)
+	assert.Equal(t, "{\"value\":1}", <-messages)
+
+	// Unsubscribing should stop delivery and not affect other subscribers.
+	unsubscribe()
+	unsubscribe()
+	assert.Equal(t, 1, len(notifier.subscribers))
+	notifier.Subscribe(func(messageJson []byte) { close(done) })
+	notifier.NotifyWithMessage(12345)
+	<-done
+	assert.Empty(t, messages)
+
+	// A message that can't be serialized should be skipped.
+	notifier.NotifyWithMessage(make(chan int))
+}
+
 func generateTestMessage() any {
 	return "test message"
 }